@@ -0,0 +1,379 @@
+package arc_cache
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/cache"
+	"github.com/Scanf-s/goods/cache/internal/nodelist"
+)
+
+// entry is the bookkeeping of one key, cached or ghost.
+type entry[K comparable, V any] struct {
+	// node holds the key, and the value while the key is cached
+	node *cache.Node[K, V]
+
+	// list is the list node is in: t1, t2, b1 or b2
+	list *nodelist.List[K, V]
+}
+
+// ARCCache is a fixed-capacity cache using the Adaptive Replacement Cache
+// policy of Megiddo and Modha, which balances recency against frequency and
+// tunes the balance to the workload as it runs.
+//
+// The cached keys are split between two LRU lists: t1 holds keys used once
+// since they were cached, and t2 keys used at least twice. A hit in either
+// moves the key to t2, so a scan of keys used once only ever churns t1 and
+// leaves the frequently used keys in t2 alone.
+//
+// Each list has a ghost list, b1 and b2, remembering the keys recently evicted
+// from it, without their values. A Put of a key found in b1 means t1 was too
+// small to keep it, so the target size of t1 grows; a Put of a key found in b2
+// shrinks it in favor of t2. Evictions take the LRU key of t1 while t1 is over
+// its target, and of t2 otherwise. Together the four lists track at most twice
+// the capacity in keys.
+//
+// Get misses never adapt the cache; it learns from the Put that follows a miss,
+// which is how a cache in front of a slower store is filled.
+type ARCCache[K comparable, V any] struct {
+	// capacity represents the maximum number of cached keys, c in the paper
+	capacity int
+
+	// target is the size t1 is steered towards, p in the paper
+	target int
+
+	// t1 holds the cached keys used once, in LRU order
+	t1 *nodelist.List[K, V]
+
+	// t2 holds the cached keys used at least twice, in LRU order
+	t2 *nodelist.List[K, V]
+
+	// b1 holds the ghosts of keys evicted from t1
+	b1 *nodelist.List[K, V]
+
+	// b2 holds the ghosts of keys evicted from t2
+	b2 *nodelist.List[K, V]
+
+	// entries maps every cached or ghost key to its entry
+	entries map[K]*entry[K, V]
+
+	// onEvict is called with every value that leaves the cache, or nil
+	onEvict cache.EvictFunc[K, V]
+}
+
+// Compile-time check that ARCCache implements the cache.Cache interface.
+var _ cache.Cache[int, int] = (*ARCCache[int, int])(nil)
+
+// NewARCCache returns an empty ARCCache holding at most capacity keys.
+// Returns an error wrapping goods.ErrCapacity if capacity is not positive.
+// Time Complexity: O(1)
+func NewARCCache[K comparable, V any](capacity int) (*ARCCache[K, V], error) {
+	if capacity <= 0 {
+		return nil, fmt.Errorf("capacity must be positive, got %d: %w", capacity, goods.ErrCapacity)
+	}
+	return &ARCCache[K, V]{
+		capacity: capacity,
+		t1:       nodelist.New[K, V](),
+		t2:       nodelist.New[K, V](),
+		b1:       nodelist.New[K, V](),
+		b2:       nodelist.New[K, V](),
+		entries:  make(map[K]*entry[K, V], 2*capacity),
+	}, nil
+}
+
+// Get returns the value cached under key and moves key to the most recently
+// used end of t2. A ghost key is a miss.
+// Time Complexity: O(1)
+func (c *ARCCache[K, V]) Get(key K) (V, bool) {
+	e := c.entries[key]
+	if e == nil || !c.cached(e) {
+		var defaultValue V
+		return defaultValue, false
+	}
+	c.move(e, c.t2)
+	return e.node.Data, true
+}
+
+// Put stores value under key. A cached key moves to t2. A ghost key adapts the
+// target size of t1 and comes back into t2, since it has now been used twice.
+// A new key goes into t1. Either of the last two may evict a key to make room.
+// Time Complexity: O(1)
+func (c *ARCCache[K, V]) Put(key K, value V) {
+	e := c.entries[key]
+	switch {
+	case e != nil && c.cached(e):
+		old := e.node.Data
+		e.node.Data = value
+		c.move(e, c.t2)
+		c.evicted(key, old, cache.EvictedReplaced)
+		return
+
+	case e != nil && e.list == c.b1:
+		// Case II: t1 evicted this key too early, so favor recency
+		c.target = min(c.capacity, c.target+max(c.b2.Len()/c.b1.Len(), 1))
+		c.makeRoom(false)
+		e.node.Data = value
+		c.move(e, c.t2)
+		return
+
+	case e != nil:
+		// Case III: t2 evicted this key too early, so favor frequency
+		c.target = max(0, c.target-max(c.b1.Len()/c.b2.Len(), 1))
+		c.makeRoom(true)
+		e.node.Data = value
+		c.move(e, c.t2)
+		return
+	}
+
+	// Case IV: a key seen for the first time, or forgotten
+	if c.t1.Len()+c.b1.Len() >= c.capacity {
+		if c.t1.Len() < c.capacity {
+			c.dropGhost(c.b1)
+			c.makeRoom(false)
+		} else {
+			// t1 alone fills the cache: evict without leaving a ghost
+			c.remove(c.entries[c.t1.Front().Key], cache.EvictedCapacity)
+		}
+	} else if c.t1.Len()+c.t2.Len()+c.b1.Len()+c.b2.Len() >= c.capacity {
+		if c.t1.Len()+c.t2.Len()+c.b1.Len()+c.b2.Len() >= 2*c.capacity {
+			c.dropGhost(c.b2)
+		}
+		c.makeRoom(false)
+	}
+	e = &entry[K, V]{node: &cache.Node[K, V]{Key: key, Data: value}, list: c.t1}
+	c.entries[key] = e
+	c.t1.PushBack(e.node)
+}
+
+// UpdateCapacity changes the capacity, evicting keys in the order of Keys until
+// the cache fits and forgetting the oldest ghosts beyond the new bounds.
+// Returns an error wrapping goods.ErrCapacity if capacity is not positive.
+// Time Complexity: O(k) where k is the number of evicted or forgotten keys
+func (c *ARCCache[K, V]) UpdateCapacity(capacity int) error {
+	if capacity <= 0 {
+		return fmt.Errorf("capacity must be positive, got %d: %w", capacity, goods.ErrCapacity)
+	}
+	c.capacity = capacity
+	for c.Len() > capacity {
+		c.replace(false)
+	}
+	// Clamp the target only now, so that the evictions follow the order of Keys
+	c.target = min(c.target, capacity)
+	for c.t1.Len()+c.b1.Len() > capacity && c.b1.Len() > 0 {
+		c.dropGhost(c.b1)
+	}
+	for c.Len()+c.b1.Len()+c.b2.Len() > 2*capacity && c.b2.Len() > 0 {
+		c.dropGhost(c.b2)
+	}
+	return nil
+}
+
+// Capacity returns the maximum number of keys the cache holds.
+// Time Complexity: O(1)
+func (c *ARCCache[K, V]) Capacity() int {
+	return c.capacity
+}
+
+// Target returns the size the cache currently steers t1, its list of keys used
+// once, towards. It grows while recency pays off and shrinks while frequency does.
+// Time Complexity: O(1)
+func (c *ARCCache[K, V]) Target() int {
+	return c.target
+}
+
+// Delete removes key from the cache and reports whether it was cached. A ghost
+// key is forgotten, but is not reported as cached.
+// Time Complexity: O(1)
+func (c *ARCCache[K, V]) Delete(key K) bool {
+	e := c.entries[key]
+	if e == nil {
+		return false
+	}
+	if !c.cached(e) {
+		e.list.Remove(e.node)
+		delete(c.entries, key)
+		return false
+	}
+	c.remove(e, cache.EvictedDeleted)
+	return true
+}
+
+// Peek returns the value cached under key without moving it.
+// Time Complexity: O(1)
+func (c *ARCCache[K, V]) Peek(key K) (V, bool) {
+	if e := c.entries[key]; e != nil && c.cached(e) {
+		return e.node.Data, true
+	}
+	var defaultValue V
+	return defaultValue, false
+}
+
+// Contains reports whether key is cached, without moving it. Ghost keys are
+// not cached.
+// Time Complexity: O(1)
+func (c *ARCCache[K, V]) Contains(key K) bool {
+	e := c.entries[key]
+	return e != nil && c.cached(e)
+}
+
+// Len returns the number of cached keys, not counting ghosts.
+// Time Complexity: O(1)
+func (c *ARCCache[K, V]) Len() int {
+	return c.t1.Len() + c.t2.Len()
+}
+
+// Keys returns the cached keys in the order UpdateCapacity would evict them:
+// the oldest keys of t1 beyond its target, then t2, then the rest of t1, each
+// from the least to the most recently used.
+// Time Complexity: O(n)
+func (c *ARCCache[K, V]) Keys() []K {
+	keys := make([]K, 0, c.Len())
+	for key := range c.All() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// OnEvict sets fn to be called with every entry that leaves the cache, and why:
+// evicted by Put or UpdateCapacity, deleted by Delete or Purge, or replaced by
+// a Put of the same key. A nil fn removes the callback. An evicted key that
+// becomes a ghost is reported when it is evicted; forgetting the ghost later is
+// not reported. fn runs after the cache has finished removing the entry and
+// must not call methods of c.
+func (c *ARCCache[K, V]) OnEvict(fn func(key K, value V, reason cache.EvictionReason)) {
+	c.onEvict = fn
+}
+
+// Purge removes every key from the cache, ghosts included, and resets the
+// adaptation. Cached keys are reported to the OnEvict callback as deleted.
+// Time Complexity: O(1) without an OnEvict callback, O(n) with one
+func (c *ARCCache[K, V]) Purge() {
+	var purged []*cache.Node[K, V]
+	if c.onEvict != nil {
+		for node := range c.nodes() {
+			purged = append(purged, node)
+		}
+	}
+	for _, l := range []*nodelist.List[K, V]{c.t1, c.t2, c.b1, c.b2} {
+		l.Clear()
+	}
+	clear(c.entries)
+	c.target = 0
+	for _, node := range purged {
+		c.onEvict(node.Key, node.Data, cache.EvictedDeleted)
+	}
+}
+
+// All returns an iterator over the cached key-value pairs in the same order as
+// Keys, without moving them.
+//
+// The loop body may Delete the key it was given. Any other change to the cache
+// during iteration may cause keys to be skipped or visited twice.
+// Time Complexity: O(n) for a full iteration
+func (c *ARCCache[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for node := range c.nodes() {
+			if !yield(node.Key, node.Data) {
+				return
+			}
+		}
+	}
+}
+
+// nodes returns an iterator over the cached nodes in the order of Keys.
+func (c *ARCCache[K, V]) nodes() iter.Seq[*cache.Node[K, V]] {
+	return func(yield func(*cache.Node[K, V]) bool) {
+		// Count the excess up front: deleting from t1 during iteration must
+		// not change which part of t1 comes first
+		excess := max(c.t1.Len()-c.target, 0)
+		if c.t2.Len() == 0 {
+			excess = c.t1.Len()
+		}
+		var rest []*cache.Node[K, V]
+		i := 0
+		for node := range c.t1.All() {
+			if i >= excess {
+				rest = append(rest, node)
+			} else if !yield(node) {
+				return
+			}
+			i++
+		}
+		for node := range c.t2.All() {
+			if !yield(node) {
+				return
+			}
+		}
+		for _, node := range rest {
+			if !yield(node) {
+				return
+			}
+		}
+	}
+}
+
+// cached reports whether e is a cached key rather than a ghost.
+func (c *ARCCache[K, V]) cached(e *entry[K, V]) bool {
+	return e.list == c.t1 || e.list == c.t2
+}
+
+// makeRoom evicts one key into its ghost list if the cache is full.
+func (c *ARCCache[K, V]) makeRoom(inB2 bool) {
+	if c.Len() >= c.capacity {
+		c.replace(inB2)
+	}
+}
+
+// replace is the REPLACE subroutine of the paper: it evicts the LRU key of t1
+// into b1 if t1 is over its target, and the LRU key of t2 into b2 otherwise.
+// inB2 reports whether the key being made room for is a ghost from b2, which
+// breaks the tie when t1 is exactly at its target.
+func (c *ARCCache[K, V]) replace(inB2 bool) {
+	t1 := c.t1.Len()
+	if t1 > 0 && (t1 > c.target || (inB2 && t1 == c.target) || c.t2.Len() == 0) {
+		c.demote(c.entries[c.t1.Front().Key], c.b1)
+		return
+	}
+	c.demote(c.entries[c.t2.Front().Key], c.b2)
+}
+
+// demote evicts the cached key of e, leaving a ghost in the list ghosts.
+func (c *ARCCache[K, V]) demote(e *entry[K, V], ghosts *nodelist.List[K, V]) {
+	value := e.node.Data
+	var defaultValue V
+	// The ghost only remembers the key; release the value
+	e.node.Data = defaultValue
+	c.move(e, ghosts)
+	c.evicted(e.node.Key, value, cache.EvictedCapacity)
+}
+
+// dropGhost forgets the oldest ghost of ghosts, if there is one.
+func (c *ARCCache[K, V]) dropGhost(ghosts *nodelist.List[K, V]) {
+	if node := ghosts.Front(); node != nil {
+		ghosts.Remove(node)
+		delete(c.entries, node.Key)
+	}
+}
+
+// remove deletes the cached key of e without leaving a ghost and reports it
+// to the OnEvict callback with reason.
+func (c *ARCCache[K, V]) remove(e *entry[K, V], reason cache.EvictionReason) {
+	e.list.Remove(e.node)
+	delete(c.entries, e.node.Key)
+	c.evicted(e.node.Key, e.node.Data, reason)
+}
+
+// move makes e the most recently used entry of list.
+func (c *ARCCache[K, V]) move(e *entry[K, V], list *nodelist.List[K, V]) {
+	e.list.Remove(e.node)
+	list.PushBack(e.node)
+	e.list = list
+}
+
+// evicted calls the OnEvict callback, if there is one.
+func (c *ARCCache[K, V]) evicted(key K, value V, reason cache.EvictionReason) {
+	if c.onEvict != nil {
+		c.onEvict(key, value, reason)
+	}
+}
@@ -0,0 +1,156 @@
+package arc_cache
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/cache"
+	"github.com/Scanf-s/goods/cache/cachetest"
+	"github.com/Scanf-s/goods/cache/internal/nodelist"
+)
+
+func TestARCSuite(t *testing.T) {
+	newCache := func(capacity int) cache.Cache[int, int] {
+		c, err := NewARCCache[int, int](capacity)
+		if err != nil {
+			t.Fatalf("NewARCCache(%d) failed: %s", capacity, err)
+		}
+		return c
+	}
+	cachetest.RunCacheSuite(t, newCache)
+	cachetest.RunScanResistantSuite(t, newCache)
+}
+
+func TestCapacityErrors(t *testing.T) {
+	if _, err := NewARCCache[int, int](0); !errors.Is(err, goods.ErrCapacity) {
+		t.Fatalf("NewARCCache(0) error = %v, want goods.ErrCapacity", err)
+	}
+	c, _ := NewARCCache[int, int](1)
+	if err := c.UpdateCapacity(-1); !errors.Is(err, goods.ErrCapacity) {
+		t.Fatalf("UpdateCapacity(-1) error = %v, want goods.ErrCapacity", err)
+	}
+}
+
+// checkInvariants verifies the bounds of the paper and that the map and the
+// lists agree.
+func checkInvariants(t *testing.T, c *ARCCache[int, int]) {
+	t.Helper()
+	t1, t2, b1, b2 := c.t1.Len(), c.t2.Len(), c.b1.Len(), c.b2.Len()
+	switch {
+	case t1+t2 > c.capacity:
+		t.Fatalf("|t1|+|t2| = %d exceeds capacity %d", t1+t2, c.capacity)
+	case t1+b1 > c.capacity:
+		t.Fatalf("|t1|+|b1| = %d exceeds capacity %d", t1+b1, c.capacity)
+	case t1+t2+b1+b2 > 2*c.capacity:
+		t.Fatalf("directory holds %d keys, more than twice the capacity %d", t1+t2+b1+b2, c.capacity)
+	case c.target < 0 || c.target > c.capacity:
+		t.Fatalf("target %d outside [0, %d]", c.target, c.capacity)
+	case len(c.entries) != t1+t2+b1+b2:
+		t.Fatalf("map holds %d entries, lists hold %d", len(c.entries), t1+t2+b1+b2)
+	}
+	for _, l := range []*nodelist.List[int, int]{c.t1, c.t2, c.b1, c.b2} {
+		for node := range l.All() {
+			if e := c.entries[node.Key]; e == nil || e.list != l || e.node != node {
+				t.Fatalf("entry of key %d does not point at its list", node.Key)
+			}
+		}
+	}
+}
+
+func TestInvariants(t *testing.T) {
+	rng := rand.New(rand.NewPCG(61, 62))
+	c, _ := NewARCCache[int, int](16)
+	for range 20000 {
+		k := rng.IntN(48)
+		switch rng.IntN(20) {
+		case 0:
+			c.Delete(k)
+		case 1:
+			_ = c.UpdateCapacity(1 + rng.IntN(24))
+		case 2, 3, 4, 5, 6, 7, 8:
+			c.Get(k)
+		default:
+			c.Put(k, k)
+		}
+		checkInvariants(t, c)
+	}
+}
+
+func TestGhostHitsAdaptTarget(t *testing.T) {
+	c, _ := NewARCCache[int, int](4)
+	for k := range 4 {
+		c.Put(k, k)
+	}
+	c.Get(2)
+	c.Get(3) // t1: 0 1, t2: 2 3
+	c.Put(4, 4)
+	if !slices.Equal(c.b1.Keys(), []int{0}) {
+		t.Fatalf("b1 = %v; the LRU key of t1 should have become a ghost", c.b1.Keys())
+	}
+	if _, ok := c.Get(0); ok {
+		t.Fatal("a ghost key must be a miss")
+	}
+
+	// Putting the ghost back grows t1's target and puts the key in t2
+	c.Put(0, 0)
+	if c.Target() != 1 {
+		t.Fatalf("Target() = %d after a b1 ghost hit; want 1", c.Target())
+	}
+	if !slices.Contains(c.t2.Keys(), 0) {
+		t.Fatalf("t2 = %v; a returning ghost has been used twice and belongs in t2", c.t2.Keys())
+	}
+	checkInvariants(t, c)
+
+	// Evict from t2 until a b2 ghost comes back, which shrinks the target
+	for k := 10; c.b2.Len() == 0; k++ {
+		c.Put(k, k)
+	}
+	ghost := c.b2.Front().Key
+	c.Put(ghost, ghost)
+	if c.Target() != 0 {
+		t.Fatalf("Target() = %d after a b2 ghost hit; want 0", c.Target())
+	}
+	checkInvariants(t, c)
+}
+
+func TestDeleteForgetsGhost(t *testing.T) {
+	c, _ := NewARCCache[int, int](1)
+	c.Put(1, 1)
+	c.Put(2, 2) // t1 alone fills the cache, so 1 leaves without a ghost
+	c.Get(2)
+	c.Put(3, 3) // 2 is in t2: it becomes a ghost in b2
+	if !slices.Equal(c.b2.Keys(), []int{2}) {
+		t.Fatalf("b2 = %v; want [2]", c.b2.Keys())
+	}
+	if c.Delete(2) {
+		t.Fatal("Delete of a ghost key reported a cached key")
+	}
+	if c.b2.Len() != 0 || len(c.entries) != 1 {
+		t.Fatalf("Delete should forget the ghost: b2 = %v", c.b2.Keys())
+	}
+}
+
+func TestPurgeResetsAdaptation(t *testing.T) {
+	c, _ := NewARCCache[int, int](2)
+	c.Put(1, 1)
+	c.Put(2, 2)
+	c.Get(2)    // t1: 1, t2: 2
+	c.Put(3, 3) // 1 becomes a ghost in b1
+	c.Put(1, 1) // the b1 ghost hit grows the target and makes 2 a ghost in b2
+	if c.Target() != 1 || !slices.Equal(c.b2.Keys(), []int{2}) {
+		t.Fatalf("Target() = %d, b2 = %v; want 1, [2]", c.Target(), c.b2.Keys())
+	}
+
+	c.Purge()
+	if c.Target() != 0 || len(c.entries) != 0 || c.Len() != 0 {
+		t.Fatalf("Purge left target %d and %d entries", c.Target(), len(c.entries))
+	}
+	// The ghosts are forgotten too: key 2 comes back as a new key in t1
+	c.Put(2, 2)
+	if !slices.Equal(c.t1.Keys(), []int{2}) || c.Target() != 0 {
+		t.Fatalf("t1 = %v, Target() = %d after Purge; want [2], 0", c.t1.Keys(), c.Target())
+	}
+}
@@ -1,5 +1,7 @@
 package cache
 
+import "iter"
+
 type (
 
 	// Node of Cached data
@@ -20,5 +22,29 @@ type (
 		Put(key K, data V)
 
 		UpdateCapacity(capacity int) error
+
+		// Delete removes key from the cache and reports whether it was cached.
+		Delete(key K) bool
+
+		// Peek returns the value cached under key like Get, but without
+		// counting as a use: the eviction order does not change.
+		Peek(key K) (V, bool)
+
+		// Contains reports whether key is cached, without counting as a use.
+		Contains(key K) bool
+
+		// Len returns the number of cached keys.
+		Len() int
+
+		// Keys returns the cached keys in eviction order: the key that would be
+		// evicted first comes first.
+		Keys() []K
+
+		// Purge removes every key from the cache.
+		Purge()
+
+		// All returns an iterator over the cached key-value pairs in the same
+		// order as Keys. Iterating does not count as a use of the keys.
+		All() iter.Seq2[K, V]
 	}
 )
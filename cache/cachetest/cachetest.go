@@ -0,0 +1,642 @@
+// Package cachetest provides conformance suites for cache.Cache implementations.
+//
+// RunCacheSuite checks the policy-independent contract that every cache must
+// honor, including the OnEvict callback of caches that implement Evicter.
+// RunLRUSuite and RunLFUSuite additionally check least-recently-used and
+// least-frequently-used eviction order, and RunScanResistantSuite checks that a
+// scan does not flush out the keys in use.
+// The suites use Contains, Peek and Keys to inspect a cache, so an
+// implementation must get those right before the rest can be trusted.
+//
+//	func TestMyCache(t *testing.T) {
+//		cachetest.RunCacheSuite(t, func(capacity int) cache.Cache[int, int] {
+//			return NewMyCache[int, int](capacity)
+//		})
+//	}
+package cachetest
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/cache"
+)
+
+// Evicter is implemented by caches that report the entries leaving them to a
+// callback, such as lru_cache.LRUCache.
+type Evicter interface {
+	OnEvict(fn func(key, value int, reason cache.EvictionReason))
+}
+
+// RunCacheSuite checks that the caches returned by newCache honor the
+// cache.Cache contract regardless of eviction policy: a hit returns the value
+// most recently Put for that key, the cache never holds more keys than its
+// capacity, UpdateCapacity rejects non-positive values with goods.ErrCapacity,
+// and Delete, Peek, Contains, Len, Keys, Purge and All agree with each other.
+//
+// If the caches implement Evicter, it also checks that every value leaving
+// the cache is reported once with the right reason, and that UpdateCapacity
+// evicts keys in the order of Keys.
+// newCache must return a new, empty cache with the given positive capacity.
+func RunCacheSuite(t *testing.T, newCache func(capacity int) cache.Cache[int, int]) {
+	t.Helper()
+
+	t.Run("Miss", func(t *testing.T) { testMiss(t, newCache(2)) })
+	t.Run("PutGet", func(t *testing.T) { testPutGet(t, newCache(4)) })
+	t.Run("Overwrite", func(t *testing.T) { testOverwrite(t, newCache(2)) })
+	t.Run("CapacityBound", func(t *testing.T) { testCapacityBound(t, newCache(3), 3) })
+	t.Run("UpdateCapacity", func(t *testing.T) { testUpdateCapacity(t, newCache(4)) })
+	t.Run("Delete", func(t *testing.T) { testDelete(t, newCache(4)) })
+	t.Run("PeekContains", func(t *testing.T) { testPeekContains(t, newCache(4)) })
+	t.Run("KeysAll", func(t *testing.T) { testKeysAll(t, newCache(8)) })
+	t.Run("Purge", func(t *testing.T) { testPurge(t, newCache(4)) })
+	t.Run("RandomizedModel", func(t *testing.T) { testRandomizedModel(t, newCache(8), 8) })
+	t.Run("OnEvictReasons", func(t *testing.T) { testOnEvictReasons(t, newCache(8)) })
+	t.Run("KeysIsShrinkOrder", func(t *testing.T) { testKeysIsShrinkOrder(t, newCache(16), 16) })
+}
+
+// RunLRUSuite runs RunCacheSuite and then checks that newCache evicts the
+// least recently used key, where both Get hits and Put count as a use, and
+// that Keys lists the keys from the least to the most recently used.
+func RunLRUSuite(t *testing.T, newCache func(capacity int) cache.Cache[int, int]) {
+	t.Helper()
+
+	RunCacheSuite(t, newCache)
+	t.Run("LRUEvictionOrder", func(t *testing.T) { testLRUEvictionOrder(t, newCache(3)) })
+	t.Run("LRUShrinkOrder", func(t *testing.T) { testLRUShrinkOrder(t, newCache(4)) })
+	t.Run("LRUPeekDoesNotPromote", func(t *testing.T) { testLRUPeekDoesNotPromote(t, newCache(2)) })
+	t.Run("LRUKeysOrder", func(t *testing.T) { testLRUKeysOrder(t, newCache(4)) })
+	t.Run("LRURandomizedModel", func(t *testing.T) { testLRURandomizedModel(t, newCache(5), 5) })
+}
+
+// RunLFUSuite runs RunCacheSuite and then checks that newCache evicts the
+// least frequently used key, and the least recently used one among keys with
+// the same frequency, where both Get hits and Put count as a use. Keys must
+// list the keys in that order.
+func RunLFUSuite(t *testing.T, newCache func(capacity int) cache.Cache[int, int]) {
+	t.Helper()
+
+	RunCacheSuite(t, newCache)
+	t.Run("LFUEvictionOrder", func(t *testing.T) { testLFUEvictionOrder(t, newCache(3)) })
+	t.Run("LFURandomizedModel", func(t *testing.T) { testLFURandomizedModel(t, newCache(5), 5) })
+}
+
+// RunScanResistantSuite checks that the caches returned by newCache keep the
+// keys used repeatedly while a long scan of keys used once passes through,
+// which LFU, ARC and 2Q do and LRU does not. It does not run RunCacheSuite.
+// newCache must return a new, empty cache with the given positive capacity.
+func RunScanResistantSuite(t *testing.T, newCache func(capacity int) cache.Cache[int, int]) {
+	t.Helper()
+
+	t.Run("ScanResistance", func(t *testing.T) { testScanResistance(t, newCache(10), 10) })
+}
+
+func testMiss(t *testing.T, c cache.Cache[int, int]) {
+	if v, ok := c.Get(1); ok || v != 0 {
+		t.Errorf("Get on an empty cache = (%d, %v), expected (0, false)", v, ok)
+	}
+}
+
+func testPutGet(t *testing.T, c cache.Cache[int, int]) {
+	for k := range 4 {
+		c.Put(k, k*10)
+	}
+	for k := range 4 {
+		if v, ok := c.Get(k); !ok || v != k*10 {
+			t.Errorf("Get(%d) = (%d, %v), expected (%d, true)", k, v, ok, k*10)
+		}
+	}
+}
+
+func testOverwrite(t *testing.T, c cache.Cache[int, int]) {
+	c.Put(1, 1)
+	c.Put(1, 2)
+	if v, ok := c.Get(1); !ok || v != 2 {
+		t.Errorf("Get(1) after overwrite = (%d, %v), expected (2, true)", v, ok)
+	}
+	// Overwriting must not consume extra capacity
+	c.Put(2, 2)
+	c.Put(1, 3)
+	if residents(c, []int{1, 2}) != 2 {
+		t.Error("overwriting a key should not evict another key")
+	}
+}
+
+func testCapacityBound(t *testing.T, c cache.Cache[int, int], capacity int) {
+	keys := make([]int, 0, capacity*4)
+	for k := range capacity * 4 {
+		c.Put(k, k)
+		keys = append(keys, k)
+		if n := residents(c, keys); n > capacity {
+			t.Fatalf("cache holds %d keys after %d puts, capacity is %d", n, k+1, capacity)
+		}
+	}
+	// The most recently inserted key must always be resident
+	if v, ok := c.Get(keys[len(keys)-1]); !ok || v != keys[len(keys)-1] {
+		t.Errorf("the most recently Put key should be resident, got (%d, %v)", v, ok)
+	}
+}
+
+func testUpdateCapacity(t *testing.T, c cache.Cache[int, int]) {
+	for _, capacity := range []int{0, -1} {
+		if err := c.UpdateCapacity(capacity); !errors.Is(err, goods.ErrCapacity) {
+			t.Errorf("UpdateCapacity(%d) error = %v, expected goods.ErrCapacity", capacity, err)
+		}
+	}
+
+	keys := []int{1, 2, 3, 4}
+	for _, k := range keys {
+		c.Put(k, k)
+	}
+	if err := c.UpdateCapacity(2); err != nil {
+		t.Fatalf("UpdateCapacity(2) failed: %s", err)
+	}
+	if n := residents(c, keys); n > 2 {
+		t.Errorf("cache holds %d keys after shrinking to 2", n)
+	}
+
+	if err := c.UpdateCapacity(6); err != nil {
+		t.Fatalf("UpdateCapacity(6) failed: %s", err)
+	}
+	keys = []int{10, 11, 12, 13, 14, 15}
+	for _, k := range keys {
+		c.Put(k, k)
+	}
+	if n := residents(c, keys); n != 6 {
+		t.Errorf("cache holds %d of 6 new keys after growing to 6", n)
+	}
+}
+
+// testRandomizedModel mixes Put and Get over a key space larger than the
+// capacity. Any hit must return the last value Put for that key.
+func testRandomizedModel(t *testing.T, c cache.Cache[int, int], capacity int) {
+	rng := rand.New(rand.NewPCG(9, 10))
+	model := make(map[int]int)
+	keys := make([]int, 0, capacity*3)
+	for k := range capacity * 3 {
+		keys = append(keys, k)
+	}
+	for step := range 5000 {
+		k := keys[rng.IntN(len(keys))]
+		var v int
+		var ok bool
+		switch rng.IntN(8) {
+		case 0, 1, 2:
+			value := rng.Int()
+			c.Put(k, value)
+			model[k] = value
+			continue
+		case 3:
+			// A deleted key must miss until it is Put again
+			c.Delete(k)
+			delete(model, k)
+			continue
+		case 4:
+			v, ok = c.Peek(k)
+		default:
+			v, ok = c.Get(k)
+		}
+		if !ok {
+			continue
+		}
+		if expected, present := model[k]; !present || v != expected {
+			t.Fatalf("step %d: lookup of %d = %d, expected last Put value %d", step, k, v, expected)
+		}
+	}
+	if n := residents(c, keys); n > capacity {
+		t.Errorf("cache holds %d keys, capacity is %d", n, capacity)
+	}
+	if n := c.Len(); n != residents(c, keys) {
+		t.Errorf("Len() = %d, but %d keys are cached", n, residents(c, keys))
+	}
+}
+
+func testDelete(t *testing.T, c cache.Cache[int, int]) {
+	if c.Delete(1) {
+		t.Error("Delete on an empty cache reported a deleted key")
+	}
+	for k := range 4 {
+		c.Put(k, k)
+	}
+	if !c.Delete(2) {
+		t.Fatal("Delete(2) reported that 2 was not cached")
+	}
+	if v, ok := c.Get(2); ok {
+		t.Errorf("Get(2) after Delete = (%d, true), expected a miss", v)
+	}
+	if c.Delete(2) {
+		t.Error("second Delete(2) reported a deleted key")
+	}
+	if c.Len() != 3 {
+		t.Errorf("Len() = %d after deleting one of 4 keys, expected 3", c.Len())
+	}
+	// The freed room is used without evicting anything
+	c.Put(4, 4)
+	if n := residents(c, []int{0, 1, 3, 4}); n != 4 {
+		t.Errorf("cache holds %d of 4 keys after refilling a deleted slot", n)
+	}
+}
+
+func testPeekContains(t *testing.T, c cache.Cache[int, int]) {
+	if v, ok := c.Peek(1); ok || v != 0 {
+		t.Errorf("Peek on an empty cache = (%d, %v), expected (0, false)", v, ok)
+	}
+	if c.Contains(1) {
+		t.Error("Contains on an empty cache = true")
+	}
+	c.Put(1, 10)
+	c.Put(2, 0)
+	if v, ok := c.Peek(1); !ok || v != 10 {
+		t.Errorf("Peek(1) = (%d, %v), expected (10, true)", v, ok)
+	}
+	if v, ok := c.Peek(2); !ok || v != 0 {
+		t.Errorf("Peek(2) = (%d, %v), expected (0, true)", v, ok)
+	}
+	if !c.Contains(1) || !c.Contains(2) || c.Contains(3) {
+		t.Errorf("Contains(1, 2, 3) = (%v, %v, %v), expected (true, true, false)",
+			c.Contains(1), c.Contains(2), c.Contains(3))
+	}
+}
+
+func testKeysAll(t *testing.T, c cache.Cache[int, int]) {
+	if keys := c.Keys(); len(keys) != 0 || c.Len() != 0 {
+		t.Errorf("empty cache: Keys() = %v, Len() = %d", keys, c.Len())
+	}
+	for k := range 20 {
+		c.Put(k, k*10)
+	}
+	keys := c.Keys()
+	if len(keys) != c.Len() || c.Len() > 8 {
+		t.Fatalf("Keys() has %d keys and Len() = %d, capacity is 8", len(keys), c.Len())
+	}
+	var allKeys []int
+	for k, v := range c.All() {
+		if v != k*10 {
+			t.Errorf("All yielded (%d, %d), expected value %d", k, v, k*10)
+		}
+		allKeys = append(allKeys, k)
+	}
+	if !slices.Equal(allKeys, keys) {
+		t.Errorf("All() yielded keys %v, Keys() = %v", allKeys, keys)
+	}
+	// Iterating must not change the order
+	if after := c.Keys(); !slices.Equal(after, keys) {
+		t.Errorf("Keys() = %v after iterating, expected %v", after, keys)
+	}
+	for range c.All() {
+		break
+	}
+}
+
+func testPurge(t *testing.T, c cache.Cache[int, int]) {
+	for k := range 4 {
+		c.Put(k, k)
+	}
+	c.Purge()
+	if c.Len() != 0 || len(c.Keys()) != 0 || residents(c, []int{0, 1, 2, 3}) != 0 {
+		t.Fatalf("cache not empty after Purge: Len() = %d, Keys() = %v", c.Len(), c.Keys())
+	}
+	// The capacity is unchanged
+	for k := range 4 {
+		c.Put(k, k)
+	}
+	if n := residents(c, []int{0, 1, 2, 3}); n != 4 {
+		t.Errorf("cache holds %d of 4 keys after Purge and refill", n)
+	}
+}
+
+func testLRUPeekDoesNotPromote(t *testing.T, c cache.Cache[int, int]) {
+	c.Put(1, 1)
+	c.Put(2, 2)
+	c.Peek(1)
+	c.Contains(1)
+	c.Put(3, 3) // evicts 1: Peek and Contains are not uses
+	if c.Contains(1) {
+		t.Error("key 1 should have been evicted; Peek must not refresh its recency")
+	}
+}
+
+func testLRUKeysOrder(t *testing.T, c cache.Cache[int, int]) {
+	for k := 1; k <= 4; k++ {
+		c.Put(k, k)
+	}
+	c.Get(2)
+	c.Put(1, 1)
+	if keys := c.Keys(); !slices.Equal(keys, []int{3, 4, 2, 1}) {
+		t.Errorf("Keys() = %v, expected least to most recently used [3 4 2 1]", keys)
+	}
+}
+
+func testLRUEvictionOrder(t *testing.T, c cache.Cache[int, int]) {
+	c.Put(1, 1)
+	c.Put(2, 2)
+	c.Put(3, 3)
+	c.Get(1)    // recency: 2, 3, 1
+	c.Put(2, 2) // recency: 3, 1, 2
+	c.Put(4, 4) // evicts 3
+	if _, ok := c.Get(3); ok {
+		t.Fatal("key 3 was least recently used and should have been evicted")
+	}
+	c.Put(5, 5) // recency was 1, 2, 4: evicts 1
+	if _, ok := c.Get(1); ok {
+		t.Fatal("key 1 was least recently used and should have been evicted")
+	}
+	for _, k := range []int{2, 4, 5} {
+		if _, ok := c.Get(k); !ok {
+			t.Errorf("key %d should still be cached", k)
+		}
+	}
+}
+
+func testLRUShrinkOrder(t *testing.T, c cache.Cache[int, int]) {
+	for k := 1; k <= 4; k++ {
+		c.Put(k, k)
+	}
+	c.Get(1) // recency: 2, 3, 4, 1
+	if err := c.UpdateCapacity(2); err != nil {
+		t.Fatalf("UpdateCapacity(2) failed: %s", err)
+	}
+	for k, expected := range map[int]bool{1: true, 2: false, 3: false, 4: true} {
+		if _, ok := c.Get(k); ok != expected {
+			t.Errorf("after shrinking, Get(%d) hit = %v, expected %v", k, ok, expected)
+		}
+	}
+}
+
+// testLRURandomizedModel compares c against a reference LRU kept as a slice
+// ordered from least to most recently used, including the order of Keys.
+func testLRURandomizedModel(t *testing.T, c cache.Cache[int, int], capacity int) {
+	rng := rand.New(rand.NewPCG(11, 12))
+	values := make(map[int]int)
+	var recency []int
+	touch := func(k int) {
+		if i := slices.Index(recency, k); i >= 0 {
+			recency = slices.Delete(recency, i, i+1)
+		}
+		recency = append(recency, k)
+	}
+
+	for step := range 5000 {
+		k := rng.IntN(capacity * 2)
+		switch rng.IntN(8) {
+		case 0, 1, 2:
+			v := rng.Int()
+			c.Put(k, v)
+			values[k] = v
+			touch(k)
+			if len(recency) > capacity {
+				delete(values, recency[0])
+				recency = recency[1:]
+			}
+		case 3:
+			_, present := values[k]
+			if deleted := c.Delete(k); deleted != present {
+				t.Fatalf("step %d: Delete(%d) = %v, expected %v", step, k, deleted, present)
+			}
+			if i := slices.Index(recency, k); i >= 0 {
+				recency = slices.Delete(recency, i, i+1)
+			}
+			delete(values, k)
+		case 4:
+			v, ok := c.Peek(k)
+			expected, present := values[k]
+			if ok != present || v != expected {
+				t.Fatalf("step %d: Peek(%d) = (%d, %v), expected (%d, %v)", step, k, v, ok, expected, present)
+			}
+		default:
+			v, ok := c.Get(k)
+			expected, present := values[k]
+			if ok != present || v != expected {
+				t.Fatalf("step %d: Get(%d) = (%d, %v), expected (%d, %v)", step, k, v, ok, expected, present)
+			}
+			if present {
+				touch(k)
+			}
+		}
+		if keys := c.Keys(); !slices.Equal(keys, recency) {
+			t.Fatalf("step %d: Keys() = %v, expected %v", step, keys, recency)
+		}
+	}
+}
+
+func testLFUEvictionOrder(t *testing.T, c cache.Cache[int, int]) {
+	c.Put(1, 1)
+	c.Put(2, 2)
+	c.Put(3, 3)
+	c.Get(1)
+	c.Get(1)
+	c.Get(2) // frequencies: 1:3, 2:2, 3:1
+	c.Put(4, 4)
+	if c.Contains(3) {
+		t.Fatal("key 3 was least frequently used and should have been evicted")
+	}
+	c.Get(4)    // 4:2 ties with 2:2, but 2 was used longer ago
+	c.Put(5, 5) // the victim is chosen among 1, 2 and 4 before 5 is added
+	if c.Contains(2) {
+		t.Fatal("key 2 was least recently used among the keys used twice and should have been evicted")
+	}
+	if keys := c.Keys(); !slices.Equal(keys, []int{5, 4, 1}) {
+		t.Errorf("Keys() = %v, expected [5 4 1] by frequency, then recency", keys)
+	}
+}
+
+// testLFURandomizedModel compares c against a reference LFU that stores the
+// frequency and the time of last use of every key and scans for the victim.
+func testLFURandomizedModel(t *testing.T, c cache.Cache[int, int], capacity int) {
+	type state struct{ value, frequency, lastUse int }
+	rng := rand.New(rand.NewPCG(13, 14))
+	model := make(map[int]*state)
+	use := func(s *state, step int) {
+		s.frequency++
+		s.lastUse = step
+	}
+	order := func() []int {
+		keys := make([]int, 0, len(model))
+		for k := range model {
+			keys = append(keys, k)
+		}
+		slices.SortFunc(keys, func(a, b int) int {
+			if d := model[a].frequency - model[b].frequency; d != 0 {
+				return d
+			}
+			return model[a].lastUse - model[b].lastUse
+		})
+		return keys
+	}
+
+	for step := range 5000 {
+		k := rng.IntN(capacity * 2)
+		switch rng.IntN(8) {
+		case 0, 1, 2:
+			v := rng.Int()
+			c.Put(k, v)
+			if s, ok := model[k]; ok {
+				s.value = v
+				use(s, step)
+				break
+			}
+			if len(model) == capacity {
+				delete(model, order()[0])
+			}
+			model[k] = &state{value: v, frequency: 1, lastUse: step}
+		case 3:
+			_, present := model[k]
+			if deleted := c.Delete(k); deleted != present {
+				t.Fatalf("step %d: Delete(%d) = %v, expected %v", step, k, deleted, present)
+			}
+			delete(model, k)
+		default:
+			v, ok := c.Get(k)
+			s, present := model[k]
+			if ok != present || (present && v != s.value) {
+				t.Fatalf("step %d: Get(%d) = (%d, %v), expected present = %v", step, k, v, ok, present)
+			}
+			if present {
+				use(s, step)
+			}
+		}
+		if keys, expected := c.Keys(), order(); !slices.Equal(keys, expected) {
+			t.Fatalf("step %d: Keys() = %v, expected %v", step, keys, expected)
+		}
+	}
+}
+
+// testOnEvictReasons runs a random workload and then purges the cache. Every
+// value Put must be reported exactly once, under its key: as replaced when it
+// was overwritten, as deleted by Delete or Purge, and as evicted otherwise.
+func testOnEvictReasons(t *testing.T, c cache.Cache[int, int]) {
+	e, ok := c.(Evicter)
+	if !ok {
+		t.Skip("the cache does not implement cachetest.Evicter")
+	}
+	type report struct {
+		key    int
+		reason cache.EvictionReason
+	}
+	reports := make(map[int][]report)
+	e.OnEvict(func(key, value int, reason cache.EvictionReason) {
+		reports[value] = append(reports[value], report{key, reason})
+	})
+
+	// Values are numbered in Put order; keyOf[v] is the key value v was put under
+	var keyOf []int
+	expected := make(map[int]cache.EvictionReason)
+	rng := rand.New(rand.NewPCG(51, 52))
+	for range 5000 {
+		k := rng.IntN(24)
+		switch rng.IntN(10) {
+		case 0:
+			if old, ok := c.Peek(k); ok {
+				expected[old] = cache.EvictedDeleted
+			}
+			c.Delete(k)
+		case 1, 2, 3:
+			c.Get(k)
+		default:
+			if old, ok := c.Peek(k); ok {
+				expected[old] = cache.EvictedReplaced
+			}
+			c.Put(k, len(keyOf))
+			keyOf = append(keyOf, k)
+		}
+	}
+	for _, v := range c.All() {
+		expected[v] = cache.EvictedDeleted
+	}
+	c.Purge()
+
+	for v, k := range keyOf {
+		reason, ok := expected[v]
+		if !ok {
+			reason = cache.EvictedCapacity
+		}
+		if r := reports[v]; len(r) != 1 || r[0] != (report{k, reason}) {
+			t.Fatalf("value %d of key %d reported as %v; want once as %s", v, k, r, reason)
+		}
+	}
+}
+
+// testKeysIsShrinkOrder runs a random workload and, every so often, shrinks
+// the cache to a single key. The keys evicted must be every key of Keys but
+// the last, in the same order.
+func testKeysIsShrinkOrder(t *testing.T, c cache.Cache[int, int], capacity int) {
+	e, ok := c.(Evicter)
+	if !ok {
+		t.Skip("the cache does not implement cachetest.Evicter")
+	}
+	var evicted []int
+	e.OnEvict(func(key, _ int, reason cache.EvictionReason) {
+		if reason == cache.EvictedCapacity {
+			evicted = append(evicted, key)
+		}
+	})
+
+	rng := rand.New(rand.NewPCG(31, 32))
+	for step := range 20000 {
+		k := rng.IntN(3 * capacity)
+		switch rng.IntN(10) {
+		case 0:
+			c.Delete(k)
+		case 1, 2, 3, 4:
+			c.Get(k)
+		default:
+			c.Put(k, k)
+		}
+
+		if step%1000 == 999 {
+			keys := c.Keys()
+			evicted = nil
+			if err := c.UpdateCapacity(1); err != nil {
+				t.Fatalf("UpdateCapacity(1) failed: %s", err)
+			}
+			if len(keys) > 0 && (!slices.Equal(evicted, keys[:len(keys)-1]) || !slices.Equal(c.Keys(), keys[len(keys)-1:])) {
+				t.Fatalf("step %d: shrinking evicted %v and kept %v; Keys() was %v", step, evicted, c.Keys(), keys)
+			}
+			if err := c.UpdateCapacity(capacity); err != nil {
+				t.Fatalf("UpdateCapacity(%d) failed: %s", capacity, err)
+			}
+		}
+	}
+}
+
+// testScanResistance makes a few keys hot by using them again and again while
+// other keys come and go, then scans many keys that are used once.
+func testScanResistance(t *testing.T, c cache.Cache[int, int], capacity int) {
+	hot := []int{0, 1, 2, 3}
+	next := 1000
+	for range 4 {
+		for _, k := range hot {
+			c.Put(k, k)
+			c.Get(k)
+		}
+		// Enough keys used once to push the hot keys out of a plain LRU
+		for range capacity {
+			c.Put(next, next)
+			next++
+		}
+	}
+	for range 100 * capacity {
+		c.Put(next, next)
+		next++
+	}
+	for _, k := range hot {
+		if !c.Contains(k) {
+			t.Errorf("hot key %d was flushed out by a scan; Keys() = %v", k, c.Keys())
+		}
+	}
+}
+
+// residents counts how many of keys are currently cached. It uses Contains,
+// so it does not change the recency or frequency state of the cache.
+func residents(c cache.Cache[int, int], keys []int) int {
+	count := 0
+	for _, k := range keys {
+		if c.Contains(k) {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,97 @@
+// Package cachetrace generates synthetic key access traces and replays them
+// against cache.Cache implementations to measure their hit ratios.
+//
+// Replay treats a cache as sitting in front of a slower store: every key is
+// looked up with Get, and a miss is followed by a Put. The benchmarks in this
+// package's tests compare the policies of this module on each trace:
+//
+//	go test ./cache/cachetrace -run '^$' -bench . -benchtime 1x
+package cachetrace
+
+import (
+	"math/rand/v2"
+
+	"github.com/Scanf-s/goods/cache"
+)
+
+// Trace is a sequence of accessed keys.
+type Trace []int
+
+// Zipf returns a trace of length accesses to keys 0 to keys-1, drawn from a
+// Zipf distribution with exponent s > 1: key k is accessed in proportion to
+// 1/(k+1)^s, so a few keys are hot and most are rarely used. Real workloads
+// such as web requests and database pages often look like this.
+func Zipf(length, keys int, s float64, seed uint64) Trace {
+	rng := rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))
+	zipf := rand.NewZipf(rng, s, 1, uint64(keys-1))
+	trace := make(Trace, length)
+	for i := range trace {
+		trace[i] = int(zipf.Uint64())
+	}
+	return trace
+}
+
+// Loop returns a trace of length accesses cycling through keys 0 to keys-1 in
+// order, like repeated sequential passes over a table. When keys exceeds the
+// capacity, LRU always evicts the key that is needed next and never hits.
+func Loop(length, keys int) Trace {
+	trace := make(Trace, length)
+	for i := range trace {
+		trace[i] = i % keys
+	}
+	return trace
+}
+
+// Scan returns a trace of length accesses that alternates between phases of
+// uniformly random accesses to a working set of hotKeys keys, each phase 4 *
+// hotKeys long, and scans of scanLength keys that are never accessed again.
+// The working set stays in use during a scan, as when a report runs next to
+// regular traffic: every third access of a scan phase goes to it. A
+// scan-resistant cache keeps the working set through the scans.
+func Scan(length, hotKeys, scanLength int, seed uint64) Trace {
+	rng := rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))
+	trace := make(Trace, 0, length+1)
+	next := hotKeys
+	for len(trace) < length {
+		for range 4 * hotKeys {
+			trace = append(trace, rng.IntN(hotKeys))
+		}
+		for i := range scanLength {
+			trace = append(trace, next)
+			next++
+			if i%2 == 1 {
+				trace = append(trace, rng.IntN(hotKeys))
+			}
+		}
+	}
+	return trace[:length]
+}
+
+// Result is the outcome of replaying a trace.
+type Result struct {
+	Hits   int
+	Misses int
+}
+
+// HitRatio returns the share of accesses that hit, between 0 and 1.
+func (r Result) HitRatio() float64 {
+	if r.Hits+r.Misses == 0 {
+		return 0
+	}
+	return float64(r.Hits) / float64(r.Hits+r.Misses)
+}
+
+// Replay looks up every key of trace in c, storing the key on each miss, and
+// counts the hits and misses.
+func Replay(c cache.Cache[int, int], trace Trace) Result {
+	var result Result
+	for _, key := range trace {
+		if _, ok := c.Get(key); ok {
+			result.Hits++
+			continue
+		}
+		result.Misses++
+		c.Put(key, key)
+	}
+	return result
+}
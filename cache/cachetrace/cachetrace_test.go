@@ -0,0 +1,144 @@
+package cachetrace
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Scanf-s/goods/cache"
+	"github.com/Scanf-s/goods/cache/arc_cache"
+	"github.com/Scanf-s/goods/cache/lfu_cache"
+	"github.com/Scanf-s/goods/cache/lru_cache"
+	"github.com/Scanf-s/goods/cache/two_queue_cache"
+)
+
+const capacity = 100
+
+type policy struct {
+	name     string
+	newCache func(capacity int) cache.Cache[int, int]
+}
+
+func policies() []policy {
+	return []policy{
+		{"LRU", func(capacity int) cache.Cache[int, int] {
+			c, _ := lru_cache.NewLRUCache[int, int](capacity)
+			return c
+		}},
+		{"LFU", func(capacity int) cache.Cache[int, int] {
+			c, _ := lfu_cache.NewLFUCache[int, int](capacity)
+			return c
+		}},
+		{"ARC", func(capacity int) cache.Cache[int, int] {
+			c, _ := arc_cache.NewARCCache[int, int](capacity)
+			return c
+		}},
+		{"2Q", func(capacity int) cache.Cache[int, int] {
+			c, _ := two_queue_cache.NewTwoQueueCache[int, int](capacity)
+			return c
+		}},
+	}
+}
+
+type namedTrace struct {
+	name  string
+	trace Trace
+}
+
+func traces() []namedTrace {
+	return []namedTrace{
+		{"Zipf", Zipf(100_000, 10*capacity, 1.1, 1)},
+		{"Loop", Loop(100_000, capacity+capacity/2)},
+		{"Scan", Scan(100_000, 3*capacity/4, 4*capacity, 2)},
+	}
+}
+
+// hitRatios replays every trace against every policy and returns the hit
+// ratios indexed by trace name, then policy name.
+func hitRatios() map[string]map[string]float64 {
+	ratios := make(map[string]map[string]float64)
+	for _, tr := range traces() {
+		ratios[tr.name] = make(map[string]float64)
+		for _, p := range policies() {
+			ratios[tr.name][p.name] = Replay(p.newCache(capacity), tr.trace).HitRatio()
+		}
+	}
+	return ratios
+}
+
+func TestTraces(t *testing.T) {
+	if trace := Loop(7, 3); !slices.Equal(trace, Trace{0, 1, 2, 0, 1, 2, 0}) {
+		t.Errorf("Loop(7, 3) = %v", trace)
+	}
+	zipf := Zipf(10_000, 50, 1.2, 3)
+	counts := make([]int, 50)
+	for _, key := range zipf {
+		counts[key]++
+	}
+	if counts[0] <= counts[1] || counts[1] <= counts[10] {
+		t.Errorf("Zipf trace is not skewed towards low keys: counts %v", counts[:11])
+	}
+	scan := Scan(100, 5, 4, 4)
+	if len(scan) != 100 || slices.Max(scan[:20]) >= 5 {
+		t.Fatalf("Scan(100, 5, 4) = %v", scan)
+	}
+	// Two scan keys, then one working set key
+	if scan[20] != 5 || scan[21] != 6 || scan[22] >= 5 || scan[23] != 7 || scan[24] != 8 || scan[25] >= 5 {
+		t.Errorf("Scan(100, 5, 4) scan phase = %v", scan[20:26])
+	}
+	if r := (Result{Hits: 1, Misses: 3}); r.HitRatio() != 0.25 {
+		t.Errorf("HitRatio() = %v, expected 0.25", r.HitRatio())
+	}
+}
+
+// TestHitRatios checks the textbook behavior of each policy and logs the
+// comparison table; run it with -v to see the table.
+func TestHitRatios(t *testing.T) {
+	ratios := hitRatios()
+
+	var table strings.Builder
+	fmt.Fprintf(&table, "%-6s", "")
+	for _, p := range policies() {
+		fmt.Fprintf(&table, "%8s", p.name)
+	}
+	for _, tr := range traces() {
+		fmt.Fprintf(&table, "\n%-6s", tr.name)
+		for _, p := range policies() {
+			fmt.Fprintf(&table, "%7.1f%%", 100*ratios[tr.name][p.name])
+		}
+	}
+	t.Logf("hit ratios with capacity %d:\n%s", capacity, table.String())
+
+	if ratios["Loop"]["LRU"] != 0 {
+		t.Errorf("LRU hit ratio on a loop larger than the cache = %v, expected 0", ratios["Loop"]["LRU"])
+	}
+	for _, name := range []string{"LFU", "ARC", "2Q"} {
+		if ratios["Scan"][name] <= ratios["Scan"]["LRU"] {
+			t.Errorf("%s hit ratio on the scan trace = %.3f, expected more than LRU's %.3f",
+				name, ratios["Scan"][name], ratios["Scan"]["LRU"])
+		}
+	}
+	for _, p := range policies() {
+		if ratios["Zipf"][p.name] < 0.3 {
+			t.Errorf("%s hit ratio on the Zipf trace = %.3f, expected the hot keys to hit", p.name, ratios["Zipf"][p.name])
+		}
+	}
+}
+
+// BenchmarkReplay replays each trace against each policy, reporting the hit
+// ratio as a percentage next to the time per access.
+func BenchmarkReplay(b *testing.B) {
+	for _, tr := range traces() {
+		for _, p := range policies() {
+			b.Run(tr.name+"/"+p.name, func(b *testing.B) {
+				var result Result
+				for b.Loop() {
+					result = Replay(p.newCache(capacity), tr.trace)
+				}
+				b.ReportMetric(100*result.HitRatio(), "hit%")
+				b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N*len(tr.trace)), "ns/access")
+			})
+		}
+	}
+}
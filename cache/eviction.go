@@ -0,0 +1,44 @@
+package cache
+
+import "fmt"
+
+// EvictionReason tells an eviction callback why an entry left the cache.
+type EvictionReason int
+
+const (
+	// EvictedCapacity means the eviction policy dropped the entry to make room,
+	// in Put or UpdateCapacity.
+	EvictedCapacity EvictionReason = iota
+
+	// EvictedExpired means the entry outlived its time to live.
+	EvictedExpired
+
+	// EvictedDeleted means the entry was removed by Delete or Purge.
+	EvictedDeleted
+
+	// EvictedReplaced means Put stored a new value under the key; the callback
+	// receives the old value, and the key stays cached.
+	EvictedReplaced
+)
+
+func (r EvictionReason) String() string {
+	switch r {
+	case EvictedCapacity:
+		return "Capacity"
+	case EvictedExpired:
+		return "Expired"
+	case EvictedDeleted:
+		return "Deleted"
+	case EvictedReplaced:
+		return "Replaced"
+	default:
+		return fmt.Sprintf("EvictionReason(%d)", int(r))
+	}
+}
+
+// EvictFunc is called with every value that leaves a cache, and why.
+//
+// It runs synchronously, after the cache has finished removing the entry, so it
+// can release resources held by value, such as closing a file. It must not call
+// methods of the cache that fired it.
+type EvictFunc[K comparable, V any] func(key K, value V, reason EvictionReason)
@@ -0,0 +1,107 @@
+// Package nodelist implements the sentinel-delimited doubly linked list of
+// cache.Node that the cache policies keep their entries in.
+package nodelist
+
+import (
+	"iter"
+
+	"github.com/Scanf-s/goods/cache"
+)
+
+// List is a doubly linked list of cache.Node between two sentinel nodes. The
+// policies keep it in recency or insertion order: Front is the oldest node and
+// PushBack adds the newest one.
+type List[K comparable, V any] struct {
+	// head (sentinel node); head.Next is the front node
+	head *cache.Node[K, V]
+
+	// tail (sentinel node); tail.Prev is the back node
+	tail *cache.Node[K, V]
+
+	// size is the number of nodes between the sentinels
+	size int
+}
+
+// New returns an empty List.
+// Time Complexity: O(1)
+func New[K comparable, V any]() *List[K, V] {
+	head := &cache.Node[K, V]{}
+	tail := &cache.Node[K, V]{}
+	head.Next = tail
+	tail.Prev = head
+	return &List[K, V]{head: head, tail: tail}
+}
+
+// Len returns the number of nodes in the list.
+// Time Complexity: O(1)
+func (l *List[K, V]) Len() int {
+	return l.size
+}
+
+// Front returns the oldest node, or nil if the list is empty.
+// Time Complexity: O(1)
+func (l *List[K, V]) Front() *cache.Node[K, V] {
+	if l.size == 0 {
+		return nil
+	}
+	return l.head.Next
+}
+
+// PushBack links node as the newest node. node must not be in a list.
+// Time Complexity: O(1)
+func (l *List[K, V]) PushBack(node *cache.Node[K, V]) {
+	latestNode := l.tail.Prev
+	node.Next = l.tail
+	l.tail.Prev = node
+	latestNode.Next = node
+	node.Prev = latestNode
+	l.size++
+}
+
+// Remove unlinks node, which must be in l.
+// Time Complexity: O(1)
+func (l *List[K, V]) Remove(node *cache.Node[K, V]) {
+	node.Prev.Next = node.Next
+	node.Next.Prev = node.Prev
+	l.size--
+}
+
+// MoveToBack makes node, which must be in l, the newest node.
+// Time Complexity: O(1)
+func (l *List[K, V]) MoveToBack(node *cache.Node[K, V]) {
+	l.Remove(node)
+	l.PushBack(node)
+}
+
+// Clear removes every node.
+// Time Complexity: O(1)
+func (l *List[K, V]) Clear() {
+	l.head.Next = l.tail
+	l.tail.Prev = l.head
+	l.size = 0
+}
+
+// Keys returns the keys of the nodes from the oldest to the newest.
+// Time Complexity: O(n)
+func (l *List[K, V]) Keys() []K {
+	keys := make([]K, 0, l.size)
+	for node := range l.All() {
+		keys = append(keys, node.Key)
+	}
+	return keys
+}
+
+// All returns an iterator over the nodes from the oldest to the newest.
+// The loop body may Remove the node it was given.
+// Time Complexity: O(n) for a full iteration
+func (l *List[K, V]) All() iter.Seq[*cache.Node[K, V]] {
+	return func(yield func(*cache.Node[K, V]) bool) {
+		for node := l.head.Next; node != l.tail; {
+			next := node.Next
+			if !yield(node) {
+				return
+			}
+			node = next
+		}
+	}
+}
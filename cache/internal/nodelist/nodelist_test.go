@@ -0,0 +1,42 @@
+package nodelist
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Scanf-s/goods/cache"
+)
+
+func TestList(t *testing.T) {
+	l := New[int, int]()
+	if l.Front() != nil || l.Len() != 0 {
+		t.Fatal("new list should be empty")
+	}
+	nodes := make([]*cache.Node[int, int], 4)
+	for i := range nodes {
+		nodes[i] = &cache.Node[int, int]{Key: i}
+		l.PushBack(nodes[i])
+	}
+	l.MoveToBack(nodes[1])
+	l.Remove(nodes[2])
+	if got := l.Keys(); !slices.Equal(got, []int{0, 3, 1}) || l.Len() != 3 {
+		t.Fatalf("keys = %v, Len() = %d; want [0 3 1], 3", got, l.Len())
+	}
+	if l.Front() != nodes[0] {
+		t.Fatalf("Front() = %v; want node 0", l.Front().Key)
+	}
+
+	// Removing the current node while iterating is allowed
+	for node := range l.All() {
+		l.Remove(node)
+	}
+	if l.Len() != 0 || l.Front() != nil {
+		t.Fatalf("Len() = %d after removing every node", l.Len())
+	}
+
+	l.PushBack(nodes[2])
+	l.Clear()
+	if l.Len() != 0 || len(l.Keys()) != 0 {
+		t.Fatal("Clear should empty the list")
+	}
+}
@@ -0,0 +1,47 @@
+package lfu_cache
+
+// Age halves the frequency of every key, rounding down but keeping it at least
+// 1, so that keys which were popular once but are no longer used lose their
+// advantage over keys in current use. Keys whose frequencies become equal keep
+// their relative recency order.
+//
+// WithAging calls Age automatically; it can also be called directly, for
+// example from a timer.
+// Time Complexity: O(n)
+func (c *LFUCache[K, V]) Age() {
+	c.sinceAging = 0
+	for b := c.root.next; b != c.root; {
+		frequency := max(b.frequency/2, 1)
+		b.frequency = frequency
+		// Halving keeps the buckets in ascending order, but consecutive buckets
+		// may now share a frequency and must become one
+		next := b.next
+		for next != c.root && max(next.frequency/2, 1) == frequency {
+			after := next.next
+			c.mergeBucket(b, next)
+			next = after
+		}
+		b = next
+	}
+}
+
+// mergeBucket moves every entry of src into dst, keeping both in recency order
+// by their ticks, and unlinks src.
+func (c *LFUCache[K, V]) mergeBucket(dst, src *bucket[K, V]) {
+	at := dst.head.Next
+	for node := src.head.Next; node != src.tail; {
+		next := node.Next
+		e := c.entries[node.Key]
+		for at != dst.tail && c.entries[at.Key].tick < e.tick {
+			at = at.Next
+		}
+		// Link node right before at
+		node.Prev = at.Prev
+		node.Next = at
+		at.Prev.Next = node
+		at.Prev = node
+		e.bucket = dst
+		node = next
+	}
+	unlinkBucket(src)
+}
@@ -0,0 +1,368 @@
+package lfu_cache
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/cache"
+)
+
+// bucket holds the entries that share one access frequency, in the same
+// sentinel-delimited doubly linked pattern as LRUCache: the least recently
+// used entry is right after head and the most recently used one right before tail.
+type bucket[K comparable, V any] struct {
+	// frequency is the access count shared by every entry in the bucket
+	frequency int
+
+	// head (sentinel node); head.Next is the least recently used node
+	head *cache.Node[K, V]
+
+	// tail (sentinel node); tail.Prev is the most recently used node
+	tail *cache.Node[K, V]
+
+	// prev and next link the buckets in ascending frequency order
+	prev, next *bucket[K, V]
+}
+
+// entry is the bookkeeping of one cached key.
+type entry[K comparable, V any] struct {
+	// node holds the key and value, linked into bucket
+	node *cache.Node[K, V]
+
+	// bucket is the bucket of the entry's current frequency
+	bucket *bucket[K, V]
+
+	// tick is the value of LFUCache.ticks at the entry's last use; aging uses
+	// it to keep recency order when it merges buckets
+	tick uint64
+}
+
+// LFUCache is a fixed-capacity cache that evicts the least frequently used key,
+// and among keys used equally often, the least recently used one.
+//
+// It follows the O(1) design of Shah, Mitra and Matani: every frequency in use
+// has a bucket, the buckets form a doubly linked list in ascending frequency
+// order, and each bucket is a doubly linked list of its entries in recency
+// order. A use moves an entry from its bucket to the tail of the next one,
+// creating that bucket if its frequency is not in use yet, and eviction takes
+// the head of the first bucket. No operation has to search for a frequency.
+//
+// Plain LFU never forgets: a key that was popular long ago keeps its count and
+// can stay cached forever after it stops being used. WithAging halves every
+// count periodically, so that old popularity fades.
+type LFUCache[K comparable, V any] struct {
+	// capacity represents the maximum capacity of LFU cache
+	capacity int
+
+	// entries maps every cached key to its entry
+	entries map[K]*entry[K, V]
+
+	// root is the sentinel of the circular bucket list; root.next is the bucket
+	// with the lowest frequency and root.prev the one with the highest
+	root *bucket[K, V]
+
+	// ticks counts uses of the cache, to order entries by recency
+	ticks uint64
+
+	// agingPeriod is the number of uses between agings, or zero for no aging
+	agingPeriod int
+
+	// sinceAging is the number of uses since the last aging
+	sinceAging int
+
+	// onEvict is called with every value that leaves the cache, or nil
+	onEvict cache.EvictFunc[K, V]
+}
+
+// Compile-time check that LFUCache implements the cache.Cache interface.
+var _ cache.Cache[int, int] = (*LFUCache[int, int])(nil)
+
+// Option configures an LFUCache created by NewLFUCache.
+type Option func(*options)
+
+type options struct {
+	agingPeriod int
+}
+
+// WithAging makes the cache call Age after every period uses, counting Get hits
+// and Puts. A period of about the capacity or more keeps Get and Put O(1)
+// amortized. A non-positive period disables aging, which is the default.
+func WithAging(period int) Option {
+	return func(o *options) {
+		o.agingPeriod = period
+	}
+}
+
+// NewLFUCache returns an empty LFUCache holding at most capacity keys.
+// Returns an error wrapping goods.ErrCapacity if capacity is not positive.
+// Time Complexity: O(1)
+func NewLFUCache[K comparable, V any](capacity int, opts ...Option) (*LFUCache[K, V], error) {
+	if capacity <= 0 {
+		return nil, fmt.Errorf("capacity must be positive, got %d: %w", capacity, goods.ErrCapacity)
+	}
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	root := &bucket[K, V]{}
+	root.prev, root.next = root, root
+	return &LFUCache[K, V]{
+		capacity:    capacity,
+		entries:     make(map[K]*entry[K, V], capacity),
+		root:        root,
+		agingPeriod: max(o.agingPeriod, 0),
+	}, nil
+}
+
+// Get returns the value cached under key and counts a use of key.
+// Time Complexity: O(1), or O(1) amortized with aging
+func (c *LFUCache[K, V]) Get(key K) (V, bool) {
+	e := c.entries[key]
+	if e == nil {
+		var defaultValue V
+		return defaultValue, false
+	}
+	value := e.node.Data
+	c.touch(e)
+	return value, true
+}
+
+// Put stores value under key and counts a use of key. A new key starts with a
+// frequency of 1, after evicting the least frequently used key if the cache is full.
+// Time Complexity: O(1), or O(1) amortized with aging
+func (c *LFUCache[K, V]) Put(key K, value V) {
+	if e := c.entries[key]; e != nil {
+		old := e.node.Data
+		e.node.Data = value
+		c.touch(e)
+		c.evicted(key, old, cache.EvictedReplaced)
+		return
+	}
+
+	if len(c.entries) == c.capacity {
+		c.removeEntry(c.entries[c.root.next.head.Next.Key], cache.EvictedCapacity)
+	}
+
+	first := c.root.next
+	if first == c.root || first.frequency != 1 {
+		first = c.insertBucketAfter(c.root, 1)
+	}
+	e := &entry[K, V]{node: &cache.Node[K, V]{Key: key, Data: value}, bucket: first}
+	c.entries[key] = e
+	c.ticks++
+	e.tick = c.ticks
+	appendNode(first, e.node)
+	c.used()
+}
+
+// UpdateCapacity changes the capacity, evicting the least frequently used keys
+// until the cache fits.
+// Returns an error wrapping goods.ErrCapacity if capacity is not positive.
+// Time Complexity: O(k) where k is the number of evicted keys
+func (c *LFUCache[K, V]) UpdateCapacity(capacity int) error {
+	if capacity <= 0 {
+		return fmt.Errorf("capacity must be positive, got %d: %w", capacity, goods.ErrCapacity)
+	}
+	c.capacity = capacity
+	for len(c.entries) > c.capacity {
+		c.removeEntry(c.entries[c.root.next.head.Next.Key], cache.EvictedCapacity)
+	}
+	return nil
+}
+
+// Capacity returns the maximum number of keys the cache holds.
+// Time Complexity: O(1)
+func (c *LFUCache[K, V]) Capacity() int {
+	return c.capacity
+}
+
+// Frequency returns the use count of key, or 0 if key is not cached.
+// Time Complexity: O(1)
+func (c *LFUCache[K, V]) Frequency(key K) int {
+	if e := c.entries[key]; e != nil {
+		return e.bucket.frequency
+	}
+	return 0
+}
+
+// Delete removes key from the cache and reports whether it was cached.
+// Time Complexity: O(1)
+func (c *LFUCache[K, V]) Delete(key K) bool {
+	e := c.entries[key]
+	if e == nil {
+		return false
+	}
+	c.removeEntry(e, cache.EvictedDeleted)
+	return true
+}
+
+// Peek returns the value cached under key without counting a use.
+// Time Complexity: O(1)
+func (c *LFUCache[K, V]) Peek(key K) (V, bool) {
+	if e := c.entries[key]; e != nil {
+		return e.node.Data, true
+	}
+	var defaultValue V
+	return defaultValue, false
+}
+
+// Contains reports whether key is cached, without counting a use.
+// Time Complexity: O(1)
+func (c *LFUCache[K, V]) Contains(key K) bool {
+	_, ok := c.entries[key]
+	return ok
+}
+
+// Len returns the number of cached keys.
+// Time Complexity: O(1)
+func (c *LFUCache[K, V]) Len() int {
+	return len(c.entries)
+}
+
+// Keys returns the cached keys in eviction order: by ascending frequency, and
+// from the least to the most recently used within a frequency.
+// Time Complexity: O(n)
+func (c *LFUCache[K, V]) Keys() []K {
+	keys := make([]K, 0, len(c.entries))
+	for key := range c.All() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// OnEvict sets fn to be called with every entry that leaves the cache, and why:
+// evicted by Put or UpdateCapacity, deleted by Delete or Purge, or replaced by
+// a Put of the same key. A nil fn removes the callback. fn runs after the cache
+// has finished removing the entry and must not call methods of c.
+func (c *LFUCache[K, V]) OnEvict(fn func(key K, value V, reason cache.EvictionReason)) {
+	c.onEvict = fn
+}
+
+// Purge removes every key from the cache, reporting each to the OnEvict
+// callback as deleted. The capacity and aging period are unchanged.
+// Time Complexity: O(1) without an OnEvict callback, O(n) with one
+func (c *LFUCache[K, V]) Purge() {
+	first := c.root.next
+	c.root.prev, c.root.next = c.root, c.root
+	clear(c.entries)
+	c.sinceAging = 0
+	if c.onEvict == nil {
+		return
+	}
+	for b := first; b != c.root; b = b.next {
+		for node := b.head.Next; node != b.tail; node = node.Next {
+			c.onEvict(node.Key, node.Data, cache.EvictedDeleted)
+		}
+	}
+}
+
+// All returns an iterator over the cached key-value pairs in the same order as
+// Keys, without counting uses.
+//
+// The loop body may Delete the key it was given. Any other change to the cache
+// during iteration, including a Get that moves a key to another bucket, may
+// cause keys to be skipped or visited twice.
+// Time Complexity: O(n) for a full iteration
+func (c *LFUCache[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for b := c.root.next; b != c.root; {
+			// Deleting the last key of b unlinks b, but leaves b.next pointing
+			// at its old successor, so the walk can still move on
+			node := b.head.Next
+			for node != b.tail {
+				next := node.Next
+				if !yield(node.Key, node.Data) {
+					return
+				}
+				node = next
+			}
+			b = b.next
+		}
+	}
+}
+
+// touch counts a use of e: it moves e to the tail of the bucket for the next
+// frequency.
+func (c *LFUCache[K, V]) touch(e *entry[K, V]) {
+	current := e.bucket
+	next := current.next
+	if next == c.root || next.frequency != current.frequency+1 {
+		next = c.insertBucketAfter(current, current.frequency+1)
+	}
+	detachNode(e.node)
+	appendNode(next, e.node)
+	e.bucket = next
+	if isEmpty(current) {
+		unlinkBucket(current)
+	}
+	c.ticks++
+	e.tick = c.ticks
+	c.used()
+}
+
+// used counts a use towards the aging period, aging the cache when it ends.
+func (c *LFUCache[K, V]) used() {
+	if c.agingPeriod == 0 {
+		return
+	}
+	c.sinceAging++
+	if c.sinceAging >= c.agingPeriod {
+		c.Age()
+	}
+}
+
+// removeEntry unlinks e, deletes its key and reports it to the OnEvict
+// callback with reason.
+func (c *LFUCache[K, V]) removeEntry(e *entry[K, V], reason cache.EvictionReason) {
+	detachNode(e.node)
+	if isEmpty(e.bucket) {
+		unlinkBucket(e.bucket)
+	}
+	delete(c.entries, e.node.Key)
+	c.evicted(e.node.Key, e.node.Data, reason)
+}
+
+// evicted calls the OnEvict callback, if there is one.
+func (c *LFUCache[K, V]) evicted(key K, value V, reason cache.EvictionReason) {
+	if c.onEvict != nil {
+		c.onEvict(key, value, reason)
+	}
+}
+
+// insertBucketAfter links a new, empty bucket for frequency right after b.
+func (c *LFUCache[K, V]) insertBucketAfter(b *bucket[K, V], frequency int) *bucket[K, V] {
+	head := &cache.Node[K, V]{}
+	tail := &cache.Node[K, V]{}
+	head.Next = tail
+	tail.Prev = head
+	nb := &bucket[K, V]{frequency: frequency, head: head, tail: tail, prev: b, next: b.next}
+	b.next.prev = nb
+	b.next = nb
+	return nb
+}
+
+func unlinkBucket[K comparable, V any](b *bucket[K, V]) {
+	b.prev.next = b.next
+	b.next.prev = b.prev
+}
+
+func isEmpty[K comparable, V any](b *bucket[K, V]) bool {
+	return b.head.Next == b.tail
+}
+
+func detachNode[K comparable, V any](node *cache.Node[K, V]) {
+	prevNode := node.Prev
+	nextNode := node.Next
+	prevNode.Next = nextNode
+	nextNode.Prev = prevNode
+}
+
+// appendNode links node as the most recently used node of b.
+func appendNode[K comparable, V any](b *bucket[K, V], node *cache.Node[K, V]) {
+	latestNode := b.tail.Prev
+	node.Next = b.tail
+	b.tail.Prev = node
+	latestNode.Next = node
+	node.Prev = latestNode
+}
@@ -0,0 +1,215 @@
+package lfu_cache
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/cache"
+	"github.com/Scanf-s/goods/cache/cachetest"
+)
+
+func TestLFUSuite(t *testing.T) {
+	cachetest.RunLFUSuite(t, func(capacity int) cache.Cache[int, int] {
+		c, err := NewLFUCache[int, int](capacity)
+		if err != nil {
+			t.Fatalf("NewLFUCache(%d) failed: %s", capacity, err)
+		}
+		return c
+	})
+}
+
+func TestAgingCache_CacheSuite(t *testing.T) {
+	cachetest.RunCacheSuite(t, func(capacity int) cache.Cache[int, int] {
+		c, err := NewLFUCache[int, int](capacity, WithAging(capacity))
+		if err != nil {
+			t.Fatalf("NewLFUCache(%d) failed: %s", capacity, err)
+		}
+		return c
+	})
+}
+
+func TestCapacityErrors(t *testing.T) {
+	if _, err := NewLFUCache[int, int](0); !errors.Is(err, goods.ErrCapacity) {
+		t.Fatalf("NewLFUCache(0) error = %v, want goods.ErrCapacity", err)
+	}
+	c, _ := NewLFUCache[int, int](1)
+	if err := c.UpdateCapacity(-1); !errors.Is(err, goods.ErrCapacity) {
+		t.Fatalf("UpdateCapacity(-1) error = %v, want goods.ErrCapacity", err)
+	}
+}
+
+// checkBuckets verifies the bucket list invariants: frequencies strictly
+// ascending, no empty bucket, and every entry pointing at its bucket.
+func checkBuckets[K comparable, V any](t *testing.T, c *LFUCache[K, V]) {
+	t.Helper()
+	count, previous := 0, 0
+	for b := c.root.next; b != c.root; b = b.next {
+		if b.frequency <= previous {
+			t.Fatalf("bucket frequency %d follows %d", b.frequency, previous)
+		}
+		if isEmpty(b) {
+			t.Fatalf("empty bucket for frequency %d left in the list", b.frequency)
+		}
+		previous = b.frequency
+		for node := b.head.Next; node != b.tail; node = node.Next {
+			if c.entries[node.Key].bucket != b {
+				t.Fatalf("entry %v does not point at its bucket", node.Key)
+			}
+			count++
+		}
+	}
+	if count != len(c.entries) {
+		t.Fatalf("buckets hold %d nodes, map holds %d entries", count, len(c.entries))
+	}
+}
+
+func TestFrequencyAndBuckets(t *testing.T) {
+	c, _ := NewLFUCache[string, int](4)
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Get("a")
+	c.Get("a")
+	c.Put("b", 3)
+	c.Peek("b")
+	if c.Frequency("a") != 3 || c.Frequency("b") != 2 || c.Frequency("x") != 0 {
+		t.Fatalf("Frequency(a, b, x) = %d, %d, %d; want 3, 2, 0", c.Frequency("a"), c.Frequency("b"), c.Frequency("x"))
+	}
+	checkBuckets(t, c)
+
+	c.Delete("a")
+	checkBuckets(t, c)
+	c.Purge()
+	checkBuckets(t, c)
+	if c.Len() != 0 || c.Capacity() != 4 {
+		t.Fatalf("Len() = %d, Capacity() = %d after Purge; want 0, 4", c.Len(), c.Capacity())
+	}
+}
+
+func TestScanDoesNotEvictHotSet(t *testing.T) {
+	c, _ := NewLFUCache[int, int](4)
+	for range 3 {
+		for k := range 3 {
+			c.Put(k, k)
+			c.Get(k)
+		}
+	}
+	// A scan of keys used once only competes for the one remaining slot
+	for k := 100; k < 200; k++ {
+		c.Put(k, k)
+	}
+	for k := range 3 {
+		if !c.Contains(k) {
+			t.Errorf("hot key %d was evicted by the scan", k)
+		}
+	}
+}
+
+func TestAge(t *testing.T) {
+	c, _ := NewLFUCache[string, int](4)
+	use := func(key string, times int) {
+		for range times {
+			c.Get(key)
+		}
+	}
+	c.Put("a", 0)
+	c.Put("b", 0)
+	c.Put("c", 0)
+	c.Put("d", 0)
+	use("a", 7) // 8
+	use("c", 2) // 3
+	use("b", 1) // 2
+	use("d", 2) // 3
+
+	c.Age()
+	checkBuckets(t, c)
+	expected := map[string]int{"a": 4, "b": 1, "c": 1, "d": 1}
+	for key, frequency := range expected {
+		if c.Frequency(key) != frequency {
+			t.Errorf("Frequency(%s) = %d after aging; want %d", key, c.Frequency(key), frequency)
+		}
+	}
+	// b, c and d merged into one bucket in recency order: c and b were used
+	// before d
+	if keys := c.Keys(); !slices.Equal(keys, []string{"c", "b", "d", "a"}) {
+		t.Errorf("Keys() = %v after aging; want [c b d a]", keys)
+	}
+
+	for range 3 {
+		c.Age()
+	}
+	checkBuckets(t, c)
+	if c.Frequency("a") != 1 {
+		t.Errorf("Frequency(a) = %d after aging 4 times; want 1", c.Frequency("a"))
+	}
+}
+
+func TestWithAging_StaleKeyLeaves(t *testing.T) {
+	c, _ := NewLFUCache[int, int](2, WithAging(4))
+	c.Put(0, 0)
+	for range 100 {
+		c.Get(0) // key 0 becomes very popular, then goes stale
+	}
+
+	// Key 1 is in steady use; every other new key evicts whichever of the
+	// two is least frequently used
+	evicted := false
+	for k := 2; k < 100 && !evicted; k++ {
+		c.Put(1, 1)
+		c.Get(1)
+		c.Put(k, k)
+		evicted = !c.Contains(0)
+	}
+	if !evicted {
+		t.Fatal("with aging, the stale popular key should eventually be evicted")
+	}
+
+	// Without aging it never leaves
+	plain, _ := NewLFUCache[int, int](2)
+	plain.Put(0, 0)
+	for range 100 {
+		plain.Get(0)
+	}
+	for k := 2; k < 100; k++ {
+		plain.Put(1, 1)
+		plain.Get(1)
+		plain.Put(k, k)
+	}
+	if !plain.Contains(0) {
+		t.Fatal("without aging, the popular key should stay cached")
+	}
+}
+
+func TestOnEvict(t *testing.T) {
+	type eviction struct {
+		key    int
+		value  int
+		reason cache.EvictionReason
+	}
+	c, _ := NewLFUCache[int, int](2)
+	var evictions []eviction
+	c.OnEvict(func(key, value int, reason cache.EvictionReason) {
+		evictions = append(evictions, eviction{key, value, reason})
+	})
+
+	c.Put(1, 10)
+	c.Put(2, 20)
+	c.Put(1, 11) // replaced
+	c.Put(3, 30) // evicts 2
+	c.Delete(1)
+	c.Put(4, 40)
+	_ = c.UpdateCapacity(1) // evicts 3
+	c.Purge()               // deletes 4
+
+	expected := []eviction{
+		{1, 10, cache.EvictedReplaced},
+		{2, 20, cache.EvictedCapacity},
+		{1, 11, cache.EvictedDeleted},
+		{3, 30, cache.EvictedCapacity},
+		{4, 40, cache.EvictedDeleted},
+	}
+	if !slices.Equal(evictions, expected) {
+		t.Fatalf("evictions = %v;\nwant %v", evictions, expected)
+	}
+}
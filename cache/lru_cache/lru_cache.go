@@ -2,33 +2,68 @@ package lru_cache
 
 import (
 	"fmt"
+	"iter"
+	"time"
 
+	"github.com/Scanf-s/goods"
 	"github.com/Scanf-s/goods/cache"
+	"github.com/Scanf-s/goods/clock"
+	"github.com/Scanf-s/goods/tree/heap"
 )
 
+// LRUCache is a fixed-capacity cache that evicts the least recently used key.
+//
+// Keys are kept in a hash map for O(1) lookup, and in a doubly linked list
+// between two sentinel nodes in recency order: the least recently used key is
+// right after head and the most recently used one right before tail.
+//
+// Entries may be given a time to live with PutWithTTL or WithDefaultTTL. An
+// expired entry is a miss for every lookup, and is reclaimed by the next Put or
+// Get, or by RemoveExpired; see ttl.go.
+//
+// The fields are unexported because the map and the list must always change
+// together; use Delete or Purge to remove keys.
 type LRUCache[K comparable, V any] struct {
 
-	// Capacity represents the maximum capacity of LRU cache
-	Capacity int
+	// capacity represents the maximum capacity of LRU cache
+	capacity int
 
-	// Storage of the LRU cache (HashMap)
-	Storage map[K]*cache.Node[K, V]
+	// storage of the LRU cache (HashMap)
+	storage map[K]*cache.Node[K, V]
 
-	// Head (sentinel node)
-	Head *cache.Node[K, V]
+	// head (sentinel node); head.Next is the least recently used node
+	head *cache.Node[K, V]
 
-	// Tail (sentinel node)
-	Tail *cache.Node[K, V]
+	// tail (sentinel node); tail.Prev is the most recently used node
+	tail *cache.Node[K, V]
 
-	// Size represents current size of storage
-	Size int
+	// defaultTTL is the time to live given by Put; zero means no expiry
+	defaultTTL time.Duration
+
+	// clock reports the current time for expiry
+	clock clock.Clock
+
+	// expiries orders the entries that have a deadline by expiry time
+	expiries *heap.Heap[expiry[K]]
+
+	// deadlines maps the key of every entry with a deadline to its handle in expiries
+	deadlines map[K]*heap.Handle[expiry[K]]
+
+	// onEvict is called with every value that leaves the cache, or nil
+	onEvict cache.EvictFunc[K, V]
 }
 
-func NewLRUCache[K comparable, V any](capacity int) (*LRUCache[K, V], error) {
+// Compile-time check that LRUCache implements the cache.Cache interface.
+var _ cache.Cache[int, int] = (*LRUCache[int, int])(nil)
+
+// NewLRUCache returns an empty LRUCache holding at most capacity keys.
+// Returns an error wrapping goods.ErrCapacity if capacity is not positive.
+// Time Complexity: O(1)
+func NewLRUCache[K comparable, V any](capacity int, opts ...Option) (*LRUCache[K, V], error) {
 	var defaultKey K
 	var defaultValue V
 	if capacity <= 0 {
-		return nil, fmt.Errorf("capacity must be a positive integer")
+		return nil, fmt.Errorf("capacity must be positive, got %d: %w", capacity, goods.ErrCapacity)
 	}
 	head := &cache.Node[K, V]{Key: defaultKey, Data: defaultValue}
 	tail := &cache.Node[K, V]{Key: defaultKey, Data: defaultValue}
@@ -37,18 +72,30 @@ func NewLRUCache[K comparable, V any](capacity int) (*LRUCache[K, V], error) {
 	tail.Prev = head
 	tail.Next = nil
 
+	o := options{clock: clock.System}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	return &LRUCache[K, V]{
-		Capacity: capacity,
-		Storage:  make(map[K]*cache.Node[K, V], capacity),
-		Head:     head,
-		Tail:     tail,
-		Size:     0,
+		capacity:   capacity,
+		storage:    make(map[K]*cache.Node[K, V], capacity),
+		head:       head,
+		tail:       tail,
+		defaultTTL: max(o.defaultTTL, 0),
+		clock:      o.clock,
+		expiries:   heap.New(compareExpiry[K]),
+		deadlines:  make(map[K]*heap.Handle[expiry[K]]),
 	}, nil
 }
 
+// Get returns the value cached under key and marks key as the most recently used.
+// An expired entry is a miss.
+// Time Complexity: O(1), plus O(log n) per reclaimed expired entry
 func (c *LRUCache[K, V]) Get(key K) (V, bool) {
 	var defaultValue V
-	if node := c.Storage[key]; node != nil {
+	c.RemoveExpired()
+	if node := c.storage[key]; node != nil {
 		detachNode(node)
 		c.lruNodeUpdate(node)
 		return node.Data, true
@@ -57,41 +104,187 @@ func (c *LRUCache[K, V]) Get(key K) (V, bool) {
 	return defaultValue, false
 }
 
+// Put stores value under key and marks key as the most recently used, evicting
+// the least recently used key if the cache is full. The entry expires after the
+// default TTL, if one was set with WithDefaultTTL.
+// Time Complexity: O(1) without TTLs, O(log n) with them
 func (c *LRUCache[K, V]) Put(key K, value V) {
-	if node := c.Storage[key]; node != nil {
+	c.PutWithTTL(key, value, c.defaultTTL)
+}
+
+// put stores value under key like Put, leaving its deadline to the caller.
+func (c *LRUCache[K, V]) put(key K, value V) {
+	if node := c.storage[key]; node != nil {
+		old := node.Data
 		node.Data = value
 		detachNode(node)
 		c.lruNodeUpdate(node)
+		c.evicted(key, old, cache.EvictedReplaced)
 		return
 	}
 
-	if c.Capacity == c.Size {
-		oldestNode := c.Head.Next
-		detachNode(oldestNode)
-		delete(c.Storage, oldestNode.Key)
-		c.Size--
+	if c.capacity == len(c.storage) {
+		c.removeNode(c.head.Next, cache.EvictedCapacity)
 	}
 
 	newNode := &cache.Node[K, V]{Key: key, Data: value}
-	c.Storage[key] = newNode
+	c.storage[key] = newNode
 	c.lruNodeUpdate(newNode)
-	c.Size++
 }
 
+// UpdateCapacity changes the capacity, reclaiming expired entries and then
+// evicting the least recently used keys until the cache fits.
+// Returns an error wrapping goods.ErrCapacity if capacity is not positive.
+// Time Complexity: O(k) where k is the number of evicted keys, plus O(log n)
+// per reclaimed expired entry
 func (c *LRUCache[K, V]) UpdateCapacity(capacity int) error {
 	if capacity <= 0 {
-		return fmt.Errorf("capacity must be positive, got %d", capacity)
+		return fmt.Errorf("capacity must be positive, got %d: %w", capacity, goods.ErrCapacity)
 	}
-	c.Capacity = capacity
-	for c.Size > c.Capacity {
-		oldestNode := c.Head.Next
-		detachNode(oldestNode)
-		delete(c.Storage, oldestNode.Key)
-		c.Size--
+	c.RemoveExpired()
+	c.capacity = capacity
+	for len(c.storage) > c.capacity {
+		c.removeNode(c.head.Next, cache.EvictedCapacity)
 	}
 	return nil
 }
 
+// Capacity returns the maximum number of keys the cache holds.
+// Time Complexity: O(1)
+func (c *LRUCache[K, V]) Capacity() int {
+	return c.capacity
+}
+
+// Delete removes key from the cache and reports whether it was cached. An
+// expired entry that has not been reclaimed yet is reclaimed instead: it is
+// reported to the OnEvict callback as expired, and Delete returns false, the
+// same answer Contains gives for it.
+// Time Complexity: O(1), or O(log n) for an entry with a TTL
+func (c *LRUCache[K, V]) Delete(key K) bool {
+	node := c.storage[key]
+	if node == nil {
+		return false
+	}
+	if c.expired(key, c.now()) {
+		c.removeNode(node, cache.EvictedExpired)
+		return false
+	}
+	c.removeNode(node, cache.EvictedDeleted)
+	return true
+}
+
+// Peek returns the value cached under key without changing its recency.
+// An expired entry is a miss, but Peek does not reclaim it, so it never
+// modifies the cache.
+// Time Complexity: O(1)
+func (c *LRUCache[K, V]) Peek(key K) (V, bool) {
+	if node := c.storage[key]; node != nil && !c.expired(key, c.now()) {
+		return node.Data, true
+	}
+	var defaultValue V
+	return defaultValue, false
+}
+
+// Contains reports whether key is cached and not expired, without changing its
+// recency. Like Peek, it never modifies the cache.
+// Time Complexity: O(1)
+func (c *LRUCache[K, V]) Contains(key K) bool {
+	_, ok := c.Peek(key)
+	return ok
+}
+
+// Len returns the number of cached keys. It includes expired entries that have
+// not been reclaimed yet; call RemoveExpired first for an exact count.
+// Time Complexity: O(1)
+func (c *LRUCache[K, V]) Len() int {
+	return len(c.storage)
+}
+
+// Keys returns the cached keys from the least to the most recently used,
+// skipping expired entries.
+// Time Complexity: O(n)
+func (c *LRUCache[K, V]) Keys() []K {
+	keys := make([]K, 0, len(c.storage))
+	for key := range c.All() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// OnEvict sets fn to be called with every entry that leaves the cache, and why:
+// evicted by Put or UpdateCapacity, expired, deleted by Delete or Purge, or
+// replaced by a Put of the same key. A nil fn removes the callback.
+//
+// fn runs after the cache has finished removing the entry and must not call
+// methods of c. An expired entry is reported when it is reclaimed, not when
+// it expires; see PutWithTTL.
+func (c *LRUCache[K, V]) OnEvict(fn func(key K, value V, reason cache.EvictionReason)) {
+	c.onEvict = fn
+}
+
+// Purge removes every key from the cache, reporting expired entries to the
+// OnEvict callback as expired and the others as deleted. The capacity is
+// unchanged.
+// Time Complexity: O(1) without an OnEvict callback; with one, O(n) plus
+// O(log n) per expired entry
+func (c *LRUCache[K, V]) Purge() {
+	var purged *cache.Node[K, V]
+	if c.onEvict != nil {
+		// Only the callback can tell expired entries from deleted ones
+		c.RemoveExpired()
+	}
+	if c.onEvict != nil && len(c.storage) > 0 {
+		purged = c.head.Next
+		// Cut the purged chain off the tail sentinel so it ends with nil
+		c.tail.Prev.Next = nil
+	}
+	clear(c.storage)
+	clear(c.deadlines)
+	c.expiries.Clear()
+	c.head.Next = c.tail
+	c.tail.Prev = c.head
+
+	for node := purged; node != nil; node = node.Next {
+		c.onEvict(node.Key, node.Data, cache.EvictedDeleted)
+	}
+}
+
+// All returns an iterator over the cached key-value pairs from the least to the
+// most recently used, without changing their recency. Expired entries are skipped.
+//
+// The loop body may Delete the key it was given. Any other change to the cache
+// during iteration, including a Get that moves a key, may cause keys to be
+// skipped or visited twice.
+// Time Complexity: O(n) for a full iteration
+func (c *LRUCache[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		now := c.now()
+		for node := c.head.Next; node != c.tail; {
+			next := node.Next
+			if !c.expired(node.Key, now) && !yield(node.Key, node.Data) {
+				return
+			}
+			node = next
+		}
+	}
+}
+
+// removeNode unlinks node from the recency list, deletes its key and deadline,
+// and reports it to the OnEvict callback with reason.
+func (c *LRUCache[K, V]) removeNode(node *cache.Node[K, V], reason cache.EvictionReason) {
+	detachNode(node)
+	delete(c.storage, node.Key)
+	c.clearDeadline(node.Key)
+	c.evicted(node.Key, node.Data, reason)
+}
+
+// evicted calls the OnEvict callback, if there is one.
+func (c *LRUCache[K, V]) evicted(key K, value V, reason cache.EvictionReason) {
+	if c.onEvict != nil {
+		c.onEvict(key, value, reason)
+	}
+}
+
 func detachNode[K comparable, V any](node *cache.Node[K, V]) {
 	prevNode := node.Prev
 	nextNode := node.Next
@@ -100,9 +293,9 @@ func detachNode[K comparable, V any](node *cache.Node[K, V]) {
 }
 
 func (c *LRUCache[K, V]) lruNodeUpdate(node *cache.Node[K, V]) {
-	latestNode := c.Tail.Prev
-	node.Next = c.Tail
-	c.Tail.Prev = node
+	latestNode := c.tail.Prev
+	node.Next = c.tail
+	c.tail.Prev = node
 	latestNode.Next = node
 	node.Prev = latestNode
 }
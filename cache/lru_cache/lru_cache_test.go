@@ -1,17 +1,28 @@
 package lru_cache
 
-import "testing"
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/cache"
+	"github.com/Scanf-s/goods/cache/cachetest"
+	"github.com/Scanf-s/goods/clock"
+)
 
 func TestEviction(t *testing.T) {
 	c, _ := NewLRUCache[int, int](2)
 	c.Put(1, 1)
-	if len(c.Storage) != 1 {
-		t.Fatalf("storage has %d entries, want 1", len(c.Storage))
+	if c.Len() != 1 {
+		t.Fatalf("storage has %d entries, want 1", c.Len())
 	}
 
 	c.Put(2, 2)
-	if len(c.Storage) != 2 {
-		t.Fatalf("storage has %d entries, want 2", len(c.Storage))
+	if c.Len() != 2 {
+		t.Fatalf("storage has %d entries, want 2", c.Len())
 	}
 
 	c.Put(3, 3)
@@ -21,13 +32,13 @@ func TestEviction(t *testing.T) {
 	if _, ok := c.Get(2); !ok {
 		t.Fatal("key 2 should exist in cache")
 	}
-	if len(c.Storage) != 2 {
-		t.Fatalf("storage has %d entries, want 2", len(c.Storage))
+	if c.Len() != 2 {
+		t.Fatalf("storage has %d entries, want 2", c.Len())
 	}
 
 	c.Put(4, 4)
-	if len(c.Storage) != 2 {
-		t.Fatalf("storage has %d entries, want 2", len(c.Storage))
+	if c.Len() != 2 {
+		t.Fatalf("storage has %d entries, want 2", c.Len())
 	}
 	if _, ok := c.Get(3); ok {
 		t.Fatal("key 3 should have been evicted")
@@ -82,8 +93,8 @@ func TestCapacityOne(t *testing.T) {
 	if v, ok := c.Get(3); !ok || v != 3 {
 		t.Fatalf("Get(3) = %v,%v; want 3,true", v, ok)
 	}
-	if len(c.Storage) != 1 || c.Size != 1 {
-		t.Fatalf("len=%d size=%d; want 1,1", len(c.Storage), c.Size)
+	if c.Len() != 1 {
+		t.Fatalf("Len() = %d; want 1", c.Len())
 	}
 }
 
@@ -92,11 +103,11 @@ func TestStorageBounded(t *testing.T) {
 	for i := 0; i < 1000; i++ {
 		c.Put(i, i)
 	}
-	if len(c.Storage) != 3 {
-		t.Fatalf("storage grew to %d entries; want 3", len(c.Storage))
+	if len(c.storage) != 3 {
+		t.Fatalf("storage grew to %d entries; want 3", len(c.storage))
 	}
-	if c.Size != 3 {
-		t.Fatalf("Size = %d; want 3", c.Size)
+	if len(c.Keys()) != 3 {
+		t.Fatalf("recency list has %d nodes; want 3", len(c.Keys()))
 	}
 }
 
@@ -131,7 +142,209 @@ func TestShrinkCapacityEvicts(t *testing.T) {
 		t.Fatalf("failed to update capacity: %v", err)
 	}
 	c.Put(6, 6)
-	if len(c.Storage) > 2 {
-		t.Fatalf("after shrink to 2, storage has %d entries", len(c.Storage))
+	if c.Len() > 2 {
+		t.Fatalf("after shrink to 2, storage has %d entries", c.Len())
+	}
+}
+
+func TestCapacityErrors(t *testing.T) {
+	if _, err := NewLRUCache[int, int](0); !errors.Is(err, goods.ErrCapacity) {
+		t.Fatalf("NewLRUCache(0) error = %v, want goods.ErrCapacity", err)
+	}
+
+	c, _ := NewLRUCache[int, int](1)
+	if err := c.UpdateCapacity(-1); !errors.Is(err, goods.ErrCapacity) {
+		t.Fatalf("UpdateCapacity(-1) error = %v, want goods.ErrCapacity", err)
+	}
+}
+
+func TestLRUSuite(t *testing.T) {
+	cachetest.RunLRUSuite(t, func(capacity int) cache.Cache[int, int] {
+		c, err := NewLRUCache[int, int](capacity)
+		if err != nil {
+			t.Fatalf("NewLRUCache(%d) failed: %s", capacity, err)
+		}
+		return c
+	})
+}
+
+func TestDeleteKeepsListConsistent(t *testing.T) {
+	c, _ := NewLRUCache[int, int](3)
+	c.Put(1, 1)
+	c.Put(2, 2)
+	c.Put(3, 3)
+	c.Delete(2) // middle
+	c.Delete(1) // least recently used
+	c.Delete(3) // most recently used
+	if c.head.Next != c.tail || c.tail.Prev != c.head {
+		t.Fatal("recency list is not empty after deleting every key")
+	}
+	c.Put(4, 4)
+	if keys := c.Keys(); len(keys) != 1 || keys[0] != 4 {
+		t.Fatalf("Keys() = %v; want [4]", keys)
+	}
+	if c.Capacity() != 3 {
+		t.Fatalf("Capacity() = %d; want 3", c.Capacity())
+	}
+}
+
+func TestAllAllowsDeletingCurrentKey(t *testing.T) {
+	c, _ := NewLRUCache[int, int](4)
+	for i := 1; i <= 4; i++ {
+		c.Put(i, i)
+	}
+	var seen []int
+	for k := range c.All() {
+		seen = append(seen, k)
+		if k%2 == 0 {
+			c.Delete(k)
+		}
+	}
+	if len(seen) != 4 {
+		t.Fatalf("All() yielded %v; want all 4 keys", seen)
+	}
+	if keys := c.Keys(); len(keys) != 2 || keys[0] != 1 || keys[1] != 3 {
+		t.Fatalf("Keys() = %v; want [1 3]", keys)
+	}
+}
+
+type eviction struct {
+	key    int
+	value  int
+	reason cache.EvictionReason
+}
+
+func recordEvictions(c *LRUCache[int, int]) *[]eviction {
+	var evictions []eviction
+	c.OnEvict(func(key, value int, reason cache.EvictionReason) {
+		evictions = append(evictions, eviction{key, value, reason})
+	})
+	return &evictions
+}
+
+func TestOnEvict_Reasons(t *testing.T) {
+	fake := clock.NewFake(time.Unix(0, 0))
+	c, _ := NewLRUCache[int, int](3, WithClock(fake))
+	evictions := recordEvictions(c)
+
+	c.Put(1, 10)
+	c.Put(2, 20)
+	c.Put(3, 30)
+	c.Put(1, 11)                     // replaced
+	c.Put(4, 40)                     // evicts 2
+	c.Delete(3)                      // deleted
+	c.PutWithTTL(5, 50, time.Second) // fills the room freed by 3
+	_ = c.UpdateCapacity(2)          // evicts 1
+	fake.Advance(time.Second)
+	c.Get(4) // reclaims 5
+	c.Put(6, 60)
+	c.Purge() // deletes 4 and 6, least recently used first
+
+	expected := []eviction{
+		{1, 10, cache.EvictedReplaced},
+		{2, 20, cache.EvictedCapacity},
+		{3, 30, cache.EvictedDeleted},
+		{1, 11, cache.EvictedCapacity},
+		{5, 50, cache.EvictedExpired},
+		{4, 40, cache.EvictedDeleted},
+		{6, 60, cache.EvictedDeleted},
+	}
+	if !slices.Equal(*evictions, expected) {
+		t.Fatalf("evictions = %v;\nwant %v", *evictions, expected)
+	}
+}
+
+func TestOnEvict_UpdateCapacityReclaimsExpiredFirst(t *testing.T) {
+	fake := clock.NewFake(time.Unix(0, 0))
+	c, _ := NewLRUCache[int, int](3, WithClock(fake))
+	evictions := recordEvictions(c)
+
+	c.Put(1, 10)
+	c.PutWithTTL(2, 20, time.Second)
+	c.Put(3, 30)
+	fake.Advance(time.Second)
+	_ = c.UpdateCapacity(2) // 2 expired, so the live key 1 stays
+
+	expected := []eviction{{2, 20, cache.EvictedExpired}}
+	if !slices.Equal(*evictions, expected) {
+		t.Fatalf("evictions = %v;\nwant %v", *evictions, expected)
+	}
+	if !slices.Equal(c.Keys(), []int{1, 3}) {
+		t.Fatalf("Keys() = %v; want [1 3]", c.Keys())
+	}
+}
+
+func TestOnEvict_PurgeReportsExpired(t *testing.T) {
+	fake := clock.NewFake(time.Unix(0, 0))
+	c, _ := NewLRUCache[int, int](3, WithClock(fake))
+	evictions := recordEvictions(c)
+
+	c.Put(1, 10)
+	c.PutWithTTL(2, 20, time.Second)
+	fake.Advance(time.Second)
+	c.Purge()
+
+	expected := []eviction{
+		{2, 20, cache.EvictedExpired},
+		{1, 10, cache.EvictedDeleted},
+	}
+	if !slices.Equal(*evictions, expected) {
+		t.Fatalf("evictions = %v;\nwant %v", *evictions, expected)
+	}
+}
+
+func TestOnEvict_EveryValueReportedOnce(t *testing.T) {
+	c, _ := NewLRUCache[int, int](8)
+	evictions := recordEvictions(c)
+
+	// Every value Put is either still cached at the end or was reported once
+	rng := rand.New(rand.NewPCG(21, 22))
+	next := 0
+	for range 5000 {
+		k := rng.IntN(16)
+		switch rng.IntN(10) {
+		case 0:
+			c.Delete(k)
+		case 1:
+			_ = c.UpdateCapacity(1 + rng.IntN(8))
+		default:
+			c.Put(k, next)
+			next++
+		}
+	}
+	seen := make([]int, next)
+	for _, e := range *evictions {
+		seen[e.value]++
+	}
+	for _, v := range c.All() {
+		seen[v]++
+	}
+	for v, n := range seen {
+		if n != 1 {
+			t.Fatalf("value %d accounted for %d times; want exactly once", v, n)
+		}
+	}
+}
+
+func TestOnEvict_Nil(t *testing.T) {
+	c, _ := NewLRUCache[int, int](1)
+	recordEvictions(c)
+	c.OnEvict(nil)
+	c.Put(1, 1)
+	c.Put(2, 2)
+	c.Purge()
+}
+
+func TestEvictionReason_String(t *testing.T) {
+	for reason, expected := range map[cache.EvictionReason]string{
+		cache.EvictedCapacity: "Capacity",
+		cache.EvictedExpired:  "Expired",
+		cache.EvictedDeleted:  "Deleted",
+		cache.EvictedReplaced: "Replaced",
+		42:                    "EvictionReason(42)",
+	} {
+		if reason.String() != expected {
+			t.Errorf("String() = %q; want %q", reason.String(), expected)
+		}
 	}
 }
@@ -0,0 +1,126 @@
+package lru_cache
+
+import (
+	"time"
+
+	"github.com/Scanf-s/goods/cache"
+	"github.com/Scanf-s/goods/clock"
+)
+
+// Option configures an LRUCache created by NewLRUCache.
+type Option func(*options)
+
+type options struct {
+	defaultTTL time.Duration
+	clock      clock.Clock
+}
+
+// WithDefaultTTL makes Put give every entry a time to live of ttl. Without it,
+// or with a non-positive ttl, entries stored by Put never expire.
+func WithDefaultTTL(ttl time.Duration) Option {
+	return func(o *options) {
+		o.defaultTTL = ttl
+	}
+}
+
+// WithClock makes the cache read the current time from c instead of
+// clock.System, so that tests can expire entries with a clock.Fake.
+func WithClock(c clock.Clock) Option {
+	return func(o *options) {
+		o.clock = c
+	}
+}
+
+// expiry is the deadline of one cache entry.
+type expiry[K comparable] struct {
+	key K
+	at  time.Time
+}
+
+func compareExpiry[K comparable](a, b expiry[K]) int {
+	return a.at.Compare(b.at)
+}
+
+// PutWithTTL stores value under key like Put, but the entry expires once ttl
+// has passed, overriding the default TTL. A non-positive ttl means the entry
+// never expires. Storing a key again replaces its deadline.
+//
+// Expiry is lazy: an expired entry is a miss for every lookup straight away,
+// and is reclaimed by the next Put or Get, which remove every expired entry in
+// deadline order, or by RemoveExpired. Until then it still counts towards Len
+// and the capacity. Expired entries are reclaimed before a Put evicts a live one.
+// Time Complexity: O(log n), plus O(log n) per reclaimed expired entry
+func (c *LRUCache[K, V]) PutWithTTL(key K, value V, ttl time.Duration) {
+	c.RemoveExpired()
+	c.put(key, value)
+	if ttl <= 0 {
+		c.clearDeadline(key)
+		return
+	}
+	deadline := expiry[K]{key: key, at: c.clock.Now().Add(ttl)}
+	if handle, ok := c.deadlines[key]; ok {
+		_ = c.expiries.Update(handle, deadline)
+		return
+	}
+	c.deadlines[key] = c.expiries.Push(deadline)
+}
+
+// TTL returns how long the entry under key has left to live. It reports false
+// if key is not cached or has expired, and a zero duration with true if the
+// entry never expires.
+// Time Complexity: O(1)
+func (c *LRUCache[K, V]) TTL(key K) (time.Duration, bool) {
+	if !c.Contains(key) {
+		return 0, false
+	}
+	handle, ok := c.deadlines[key]
+	if !ok {
+		return 0, true
+	}
+	return handle.Value().at.Sub(c.clock.Now()), true
+}
+
+// RemoveExpired removes every expired entry and returns how many it removed.
+// Put and Get call it, so it only needs to be called directly to reclaim
+// memory in a cache that is not being used, such as from a janitor goroutine
+// (see sync.SyncCache.StartJanitor).
+// Time Complexity: O(k log n) where k is the number of expired entries
+func (c *LRUCache[K, V]) RemoveExpired() int {
+	if c.expiries.IsEmpty() {
+		return 0
+	}
+	now := c.clock.Now()
+	removed := 0
+	for {
+		next, err := c.expiries.Peek()
+		if err != nil || next.at.After(now) {
+			return removed
+		}
+		// removeNode also removes the deadline from the heap
+		c.removeNode(c.storage[next.key], cache.EvictedExpired)
+		removed++
+	}
+}
+
+// now returns the current time, or the zero time without reading the clock if
+// no entry has a deadline.
+func (c *LRUCache[K, V]) now() time.Time {
+	if c.expiries.IsEmpty() {
+		return time.Time{}
+	}
+	return c.clock.Now()
+}
+
+// expired reports whether the entry under key has a deadline at or before now.
+func (c *LRUCache[K, V]) expired(key K, now time.Time) bool {
+	handle, ok := c.deadlines[key]
+	return ok && !handle.Value().at.After(now)
+}
+
+// clearDeadline removes the deadline of key, if it has one.
+func (c *LRUCache[K, V]) clearDeadline(key K) {
+	if handle, ok := c.deadlines[key]; ok {
+		_, _ = c.expiries.Remove(handle)
+		delete(c.deadlines, key)
+	}
+}
@@ -0,0 +1,202 @@
+package lru_cache
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Scanf-s/goods/cache"
+	"github.com/Scanf-s/goods/cache/cachetest"
+	"github.com/Scanf-s/goods/clock"
+)
+
+func newTTLCache(t *testing.T, capacity int, opts ...Option) (*LRUCache[string, int], *clock.Fake) {
+	t.Helper()
+	fake := clock.NewFake(time.Unix(0, 0))
+	c, err := NewLRUCache[string, int](capacity, append([]Option{WithClock(fake)}, opts...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c, fake
+}
+
+func TestPutWithTTL_ExpiresOnGet(t *testing.T) {
+	c, fake := newTTLCache(t, 4)
+	c.PutWithTTL("a", 1, time.Minute)
+	c.Put("b", 2) // no default TTL: never expires
+
+	fake.Advance(59 * time.Second)
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Fatalf("Get(a) before expiry = %v,%v; want 1,true", v, ok)
+	}
+
+	fake.Advance(time.Second)
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("Get(a) at its deadline should miss")
+	}
+	if c.Len() != 1 || len(c.deadlines) != 0 || c.expiries.Size() != 0 {
+		t.Fatalf("expired entry not reclaimed: Len()=%d deadlines=%d heap=%d", c.Len(), len(c.deadlines), c.expiries.Size())
+	}
+
+	fake.Advance(time.Hour)
+	if v, ok := c.Get("b"); !ok || v != 2 {
+		t.Fatalf("Get(b) = %v,%v; entries without TTL must not expire", v, ok)
+	}
+}
+
+func TestPutWithTTL_ReadsDoNotReclaim(t *testing.T) {
+	c, fake := newTTLCache(t, 4)
+	c.PutWithTTL("a", 1, time.Second)
+	c.Put("b", 2)
+	fake.Advance(time.Second)
+
+	if _, ok := c.Peek("a"); ok {
+		t.Error("Peek(a) should miss once a has expired")
+	}
+	if c.Contains("a") {
+		t.Error("Contains(a) should be false once a has expired")
+	}
+	if keys := c.Keys(); !slices.Equal(keys, []string{"b"}) {
+		t.Errorf("Keys() = %v; want [b]", keys)
+	}
+	if _, ok := c.TTL("a"); ok {
+		t.Error("TTL(a) should report false once a has expired")
+	}
+	// The reads above must leave the cache untouched
+	if c.Len() != 2 {
+		t.Errorf("Len() = %d; expired entry should stay until reclaimed", c.Len())
+	}
+	if n := c.RemoveExpired(); n != 1 || c.Len() != 1 {
+		t.Errorf("RemoveExpired() = %d, Len() = %d; want 1, 1", n, c.Len())
+	}
+}
+
+func TestPutWithTTL_DeleteOfExpiredEntry(t *testing.T) {
+	fake := clock.NewFake(time.Unix(0, 0))
+	c, _ := NewLRUCache[int, int](4, WithClock(fake))
+	evictions := recordEvictions(c)
+	c.PutWithTTL(1, 10, time.Second)
+	c.PutWithTTL(2, 20, time.Minute)
+	fake.Advance(time.Second)
+
+	if c.Delete(1) {
+		t.Error("Delete(1) reported an expired entry as cached")
+	}
+	if !c.Delete(2) {
+		t.Error("Delete(2) should report a live entry as cached")
+	}
+	expected := []eviction{
+		{1, 10, cache.EvictedExpired},
+		{2, 20, cache.EvictedDeleted},
+	}
+	if !slices.Equal(*evictions, expected) {
+		t.Fatalf("evictions = %v;\nwant %v", *evictions, expected)
+	}
+	if c.Len() != 0 || len(c.deadlines) != 0 {
+		t.Fatalf("Len() = %d, deadlines = %d; want 0, 0", c.Len(), len(c.deadlines))
+	}
+}
+
+func TestWithDefaultTTL(t *testing.T) {
+	c, fake := newTTLCache(t, 4, WithDefaultTTL(time.Minute))
+	c.Put("a", 1)
+	c.PutWithTTL("b", 2, 0) // explicit non-positive TTL: never expires
+	c.PutWithTTL("c", 3, time.Hour)
+
+	if ttl, ok := c.TTL("a"); !ok || ttl != time.Minute {
+		t.Fatalf("TTL(a) = %v,%v; want 1m,true", ttl, ok)
+	}
+	if ttl, ok := c.TTL("b"); !ok || ttl != 0 {
+		t.Fatalf("TTL(b) = %v,%v; want 0,true", ttl, ok)
+	}
+
+	fake.Advance(2 * time.Minute)
+	if n := c.RemoveExpired(); n != 1 {
+		t.Fatalf("RemoveExpired() = %d; want 1", n)
+	}
+	if keys := c.Keys(); !slices.Equal(keys, []string{"b", "c"}) {
+		t.Fatalf("Keys() = %v; want [b c]", keys)
+	}
+}
+
+func TestPutWithTTL_OverwriteReplacesDeadline(t *testing.T) {
+	c, fake := newTTLCache(t, 4)
+	c.PutWithTTL("a", 1, time.Second)
+	c.PutWithTTL("a", 2, time.Minute)
+	fake.Advance(30 * time.Second)
+	if v, ok := c.Get("a"); !ok || v != 2 {
+		t.Fatalf("Get(a) = %v,%v; the later deadline should apply", v, ok)
+	}
+
+	// Storing without TTL removes the deadline
+	c.PutWithTTL("a", 3, 0)
+	fake.Advance(time.Hour)
+	if v, ok := c.Get("a"); !ok || v != 3 {
+		t.Fatalf("Get(a) = %v,%v; want 3,true", v, ok)
+	}
+	if len(c.deadlines) != 0 || c.expiries.Size() != 0 {
+		t.Fatalf("stale deadline left behind: deadlines=%d heap=%d", len(c.deadlines), c.expiries.Size())
+	}
+}
+
+func TestPutWithTTL_ExpiredReclaimedBeforeEviction(t *testing.T) {
+	c, fake := newTTLCache(t, 2)
+	c.Put("live", 1)
+	c.PutWithTTL("short", 2, time.Second)
+	fake.Advance(time.Second)
+
+	// The cache is full, but the expired entry makes room for the new one
+	c.Put("new", 3)
+	if !c.Contains("live") || !c.Contains("new") {
+		t.Fatalf("Keys() = %v; the live entry should not be evicted", c.Keys())
+	}
+}
+
+func TestPutWithTTL_DeleteAndPurgeClearDeadlines(t *testing.T) {
+	c, _ := newTTLCache(t, 4)
+	c.PutWithTTL("a", 1, time.Second)
+	c.PutWithTTL("b", 2, time.Second)
+	c.PutWithTTL("c", 3, time.Second)
+	c.Delete("a")
+	c.UpdateCapacity(1) // evicts b
+	if len(c.deadlines) != 1 || c.expiries.Size() != 1 {
+		t.Fatalf("deadlines=%d heap=%d; want 1,1", len(c.deadlines), c.expiries.Size())
+	}
+	c.Purge()
+	if len(c.deadlines) != 0 || c.expiries.Size() != 0 {
+		t.Fatalf("deadlines=%d heap=%d after Purge; want 0,0", len(c.deadlines), c.expiries.Size())
+	}
+}
+
+func TestPutWithTTL_ExpiryOrder(t *testing.T) {
+	c, fake := newTTLCache(t, 8)
+	ttls := map[string]time.Duration{"a": 5 * time.Second, "b": time.Second, "c": 3 * time.Second, "d": 0}
+	for _, k := range []string{"a", "b", "c", "d"} {
+		c.PutWithTTL(k, 0, ttls[k])
+	}
+	expected := [][]string{
+		{"a", "c", "d"}, // 1s
+		{"a", "c", "d"}, // 2s
+		{"a", "d"},      // 3s
+		{"a", "d"},      // 4s
+		{"d"},           // 5s
+	}
+	for i, want := range expected {
+		fake.Advance(time.Second)
+		c.RemoveExpired()
+		if keys := c.Keys(); !slices.Equal(keys, want) {
+			t.Fatalf("after %ds: Keys() = %v; want %v", i+1, keys, want)
+		}
+	}
+}
+
+func TestTTLCache_LRUSuite(t *testing.T) {
+	// A default TTL that never elapses must not change the LRU behavior
+	cachetest.RunLRUSuite(t, func(capacity int) cache.Cache[int, int] {
+		c, err := NewLRUCache[int, int](capacity, WithClock(clock.NewFake(time.Unix(0, 0))), WithDefaultTTL(time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	})
+}
@@ -0,0 +1,387 @@
+package two_queue_cache
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/cache"
+	"github.com/Scanf-s/goods/cache/internal/nodelist"
+)
+
+// ErrInvalidRatio is returned when a queue size ratio is out of range.
+var ErrInvalidRatio = errors.New("ratio must be between 0 and 1")
+
+// Default sizes of the recent queue and of the ghost queue, relative to the
+// capacity, as recommended by Johnson and Shasha.
+const (
+	DefaultRecentRatio = 0.25
+	DefaultGhostRatio  = 0.5
+)
+
+// entry is the bookkeeping of one key, cached or ghost.
+type entry[K comparable, V any] struct {
+	// node holds the key, and the value while the key is cached
+	node *cache.Node[K, V]
+
+	// list is the list node is in: recent, frequent or ghosts
+	list *nodelist.List[K, V]
+}
+
+// TwoQueueCache is a fixed-capacity cache using the full 2Q policy of Johnson
+// and Shasha, which keeps keys used only once from pushing out keys in steady use.
+//
+// A new key enters recent (A1in in the paper), a FIFO queue limited to a small
+// share of the capacity; hits there do not reorder it. When a key leaves
+// recent, only its key is remembered in ghosts (A1out), another FIFO queue. A
+// key that is Put again while it is a ghost has proven to be reused, and moves
+// into frequent (Am), an LRU list holding the rest of the capacity. A scan
+// therefore only cycles through recent and ghosts and never touches frequent.
+//
+// Get misses never change the cache; a ghost is recognized by the Put that
+// follows a miss, which is how a cache in front of a slower store is filled.
+type TwoQueueCache[K comparable, V any] struct {
+	// capacity represents the maximum number of cached keys
+	capacity int
+
+	// recentRatio and ghostRatio size recent and ghosts relative to capacity
+	recentRatio, ghostRatio float64
+
+	// recentSize is the size above which recent gives up keys first, Kin in the paper
+	recentSize int
+
+	// ghostSize is the maximum number of ghosts, Kout in the paper
+	ghostSize int
+
+	// recent holds the cached keys seen once, in FIFO order
+	recent *nodelist.List[K, V]
+
+	// frequent holds the cached keys that came back from ghosts, in LRU order
+	frequent *nodelist.List[K, V]
+
+	// ghosts holds the keys that left recent, in FIFO order, without values
+	ghosts *nodelist.List[K, V]
+
+	// entries maps every cached or ghost key to its entry
+	entries map[K]*entry[K, V]
+
+	// onEvict is called with every value that leaves the cache, or nil
+	onEvict cache.EvictFunc[K, V]
+}
+
+// Compile-time check that TwoQueueCache implements the cache.Cache interface.
+var _ cache.Cache[int, int] = (*TwoQueueCache[int, int])(nil)
+
+// Option configures a TwoQueueCache created by NewTwoQueueCache.
+type Option func(*options)
+
+type options struct {
+	recentRatio float64
+	ghostRatio  float64
+}
+
+// WithRecentRatio sets the share of the capacity that recent may use before it
+// gives up keys ahead of frequent. The default is DefaultRecentRatio.
+func WithRecentRatio(ratio float64) Option {
+	return func(o *options) {
+		o.recentRatio = ratio
+	}
+}
+
+// WithGhostRatio sets how many ghosts are remembered, relative to the
+// capacity. The default is DefaultGhostRatio.
+func WithGhostRatio(ratio float64) Option {
+	return func(o *options) {
+		o.ghostRatio = ratio
+	}
+}
+
+// NewTwoQueueCache returns an empty TwoQueueCache holding at most capacity keys.
+// Returns an error wrapping goods.ErrCapacity if capacity is not positive, and
+// an error wrapping ErrInvalidRatio if a ratio is not in (0, 1).
+// Time Complexity: O(1)
+func NewTwoQueueCache[K comparable, V any](capacity int, opts ...Option) (*TwoQueueCache[K, V], error) {
+	if capacity <= 0 {
+		return nil, fmt.Errorf("capacity must be positive, got %d: %w", capacity, goods.ErrCapacity)
+	}
+	o := options{recentRatio: DefaultRecentRatio, ghostRatio: DefaultGhostRatio}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	for _, ratio := range []float64{o.recentRatio, o.ghostRatio} {
+		// Written as a negation so that NaN is rejected too
+		if !(ratio > 0 && ratio < 1) {
+			return nil, fmt.Errorf("cannot use ratio %v: %w", ratio, ErrInvalidRatio)
+		}
+	}
+	c := &TwoQueueCache[K, V]{
+		recentRatio: o.recentRatio,
+		ghostRatio:  o.ghostRatio,
+		recent:      nodelist.New[K, V](),
+		frequent:    nodelist.New[K, V](),
+		ghosts:      nodelist.New[K, V](),
+		entries:     make(map[K]*entry[K, V]),
+	}
+	c.setCapacity(capacity)
+	return c, nil
+}
+
+// Get returns the value cached under key. A hit in frequent moves key to its
+// most recently used end; a hit in recent changes nothing. A ghost key is a miss.
+// Time Complexity: O(1)
+func (c *TwoQueueCache[K, V]) Get(key K) (V, bool) {
+	e := c.entries[key]
+	if e == nil || e.list == c.ghosts {
+		var defaultValue V
+		return defaultValue, false
+	}
+	if e.list == c.frequent {
+		c.frequent.MoveToBack(e.node)
+	}
+	return e.node.Data, true
+}
+
+// Put stores value under key. A cached key keeps its place, as with Get. A
+// ghost key comes back into frequent, and a new key goes into recent; either
+// may evict a key to make room.
+// Time Complexity: O(1)
+func (c *TwoQueueCache[K, V]) Put(key K, value V) {
+	e := c.entries[key]
+	switch {
+	case e == nil:
+		c.makeRoom()
+		e = &entry[K, V]{node: &cache.Node[K, V]{Key: key, Data: value}, list: c.recent}
+		c.entries[key] = e
+		c.recent.PushBack(e.node)
+
+	case e.list == c.ghosts:
+		// Take the ghost out first, so that making room cannot forget it
+		c.ghosts.Remove(e.node)
+		c.makeRoom()
+		e.node.Data = value
+		c.frequent.PushBack(e.node)
+		e.list = c.frequent
+
+	default:
+		old := e.node.Data
+		e.node.Data = value
+		if e.list == c.frequent {
+			c.frequent.MoveToBack(e.node)
+		}
+		c.evicted(key, old, cache.EvictedReplaced)
+	}
+}
+
+// UpdateCapacity changes the capacity and resizes recent and ghosts in
+// proportion, evicting keys in the order of Keys until the cache fits and
+// forgetting the oldest ghosts beyond the new bound.
+// Returns an error wrapping goods.ErrCapacity if capacity is not positive.
+// Time Complexity: O(k) where k is the number of evicted or forgotten keys
+func (c *TwoQueueCache[K, V]) UpdateCapacity(capacity int) error {
+	if capacity <= 0 {
+		return fmt.Errorf("capacity must be positive, got %d: %w", capacity, goods.ErrCapacity)
+	}
+	c.capacity = capacity
+	for c.Len() > capacity {
+		c.evictOne()
+	}
+	// Resize the queues only now, so that the evictions follow the order of Keys
+	c.setCapacity(capacity)
+	for c.ghosts.Len() > c.ghostSize {
+		c.dropGhost()
+	}
+	return nil
+}
+
+// Capacity returns the maximum number of keys the cache holds.
+// Time Complexity: O(1)
+func (c *TwoQueueCache[K, V]) Capacity() int {
+	return c.capacity
+}
+
+// Delete removes key from the cache and reports whether it was cached. A ghost
+// key is forgotten, but is not reported as cached.
+// Time Complexity: O(1)
+func (c *TwoQueueCache[K, V]) Delete(key K) bool {
+	e := c.entries[key]
+	if e == nil {
+		return false
+	}
+	e.list.Remove(e.node)
+	delete(c.entries, key)
+	if e.list == c.ghosts {
+		return false
+	}
+	c.evicted(key, e.node.Data, cache.EvictedDeleted)
+	return true
+}
+
+// Peek returns the value cached under key without moving it.
+// Time Complexity: O(1)
+func (c *TwoQueueCache[K, V]) Peek(key K) (V, bool) {
+	if e := c.entries[key]; e != nil && e.list != c.ghosts {
+		return e.node.Data, true
+	}
+	var defaultValue V
+	return defaultValue, false
+}
+
+// Contains reports whether key is cached, without moving it. Ghost keys are
+// not cached.
+// Time Complexity: O(1)
+func (c *TwoQueueCache[K, V]) Contains(key K) bool {
+	e := c.entries[key]
+	return e != nil && e.list != c.ghosts
+}
+
+// Len returns the number of cached keys, not counting ghosts.
+// Time Complexity: O(1)
+func (c *TwoQueueCache[K, V]) Len() int {
+	return c.recent.Len() + c.frequent.Len()
+}
+
+// Keys returns the cached keys in eviction order: the oldest keys of recent
+// beyond its share, then frequent from the least to the most recently used,
+// then the rest of recent from the oldest.
+// Time Complexity: O(n)
+func (c *TwoQueueCache[K, V]) Keys() []K {
+	keys := make([]K, 0, c.Len())
+	for key := range c.All() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// OnEvict sets fn to be called with every entry that leaves the cache, and why:
+// evicted by Put or UpdateCapacity, deleted by Delete or Purge, or replaced by
+// a Put of the same key. A nil fn removes the callback. A key that becomes a
+// ghost is reported when it is evicted; forgetting the ghost later is not
+// reported. fn runs after the cache has finished removing the entry and must
+// not call methods of c.
+func (c *TwoQueueCache[K, V]) OnEvict(fn func(key K, value V, reason cache.EvictionReason)) {
+	c.onEvict = fn
+}
+
+// Purge removes every key from the cache, ghosts included. Cached keys are
+// reported to the OnEvict callback as deleted.
+// Time Complexity: O(1) without an OnEvict callback, O(n) with one
+func (c *TwoQueueCache[K, V]) Purge() {
+	var purged []*cache.Node[K, V]
+	if c.onEvict != nil {
+		for node := range c.nodes() {
+			purged = append(purged, node)
+		}
+	}
+	c.recent.Clear()
+	c.frequent.Clear()
+	c.ghosts.Clear()
+	clear(c.entries)
+	for _, node := range purged {
+		c.onEvict(node.Key, node.Data, cache.EvictedDeleted)
+	}
+}
+
+// All returns an iterator over the cached key-value pairs in the same order as
+// Keys, without moving them.
+//
+// The loop body may Delete the key it was given. Any other change to the cache
+// during iteration may cause keys to be skipped or visited twice.
+// Time Complexity: O(n) for a full iteration
+func (c *TwoQueueCache[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for node := range c.nodes() {
+			if !yield(node.Key, node.Data) {
+				return
+			}
+		}
+	}
+}
+
+// nodes returns an iterator over the cached nodes in the order of Keys.
+func (c *TwoQueueCache[K, V]) nodes() iter.Seq[*cache.Node[K, V]] {
+	return func(yield func(*cache.Node[K, V]) bool) {
+		excess := max(c.recent.Len()-c.recentSize, 0)
+		if c.frequent.Len() == 0 {
+			excess = c.recent.Len()
+		}
+		var rest []*cache.Node[K, V]
+		i := 0
+		for node := range c.recent.All() {
+			if i >= excess {
+				rest = append(rest, node)
+			} else if !yield(node) {
+				return
+			}
+			i++
+		}
+		for node := range c.frequent.All() {
+			if !yield(node) {
+				return
+			}
+		}
+		for _, node := range rest {
+			if !yield(node) {
+				return
+			}
+		}
+	}
+}
+
+// setCapacity sets the capacity and the queue sizes derived from it.
+func (c *TwoQueueCache[K, V]) setCapacity(capacity int) {
+	c.capacity = capacity
+	c.recentSize = max(int(float64(capacity)*c.recentRatio), 1)
+	c.ghostSize = max(int(float64(capacity)*c.ghostRatio), 1)
+}
+
+// makeRoom evicts one key if the cache is full.
+func (c *TwoQueueCache[K, V]) makeRoom() {
+	if c.Len() >= c.capacity {
+		c.evictOne()
+	}
+}
+
+// evictOne is the reclaimfor subroutine of the paper: it moves the oldest key
+// of recent to ghosts if recent is over its share, and evicts the least
+// recently used key of frequent otherwise.
+func (c *TwoQueueCache[K, V]) evictOne() {
+	if c.recent.Len() > c.recentSize || c.frequent.Len() == 0 {
+		e := c.entries[c.recent.Front().Key]
+		value := e.node.Data
+		var defaultValue V
+		// The ghost only remembers the key; release the value
+		e.node.Data = defaultValue
+		c.move(e, c.ghosts)
+		if c.ghosts.Len() > c.ghostSize {
+			c.dropGhost()
+		}
+		c.evicted(e.node.Key, value, cache.EvictedCapacity)
+		return
+	}
+	node := c.frequent.Front()
+	c.frequent.Remove(node)
+	delete(c.entries, node.Key)
+	c.evicted(node.Key, node.Data, cache.EvictedCapacity)
+}
+
+// dropGhost forgets the oldest ghost.
+func (c *TwoQueueCache[K, V]) dropGhost() {
+	node := c.ghosts.Front()
+	c.ghosts.Remove(node)
+	delete(c.entries, node.Key)
+}
+
+// move makes e the newest entry of list.
+func (c *TwoQueueCache[K, V]) move(e *entry[K, V], list *nodelist.List[K, V]) {
+	e.list.Remove(e.node)
+	list.PushBack(e.node)
+	e.list = list
+}
+
+// evicted calls the OnEvict callback, if there is one.
+func (c *TwoQueueCache[K, V]) evicted(key K, value V, reason cache.EvictionReason) {
+	if c.onEvict != nil {
+		c.onEvict(key, value, reason)
+	}
+}
@@ -0,0 +1,120 @@
+package two_queue_cache
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/cache"
+	"github.com/Scanf-s/goods/cache/cachetest"
+	"github.com/Scanf-s/goods/cache/internal/nodelist"
+)
+
+func TestTwoQueueSuite(t *testing.T) {
+	newCache := func(capacity int) cache.Cache[int, int] {
+		c, err := NewTwoQueueCache[int, int](capacity)
+		if err != nil {
+			t.Fatalf("NewTwoQueueCache(%d) failed: %s", capacity, err)
+		}
+		return c
+	}
+	cachetest.RunCacheSuite(t, newCache)
+	cachetest.RunScanResistantSuite(t, newCache)
+}
+
+func TestConstructorErrors(t *testing.T) {
+	if _, err := NewTwoQueueCache[int, int](0); !errors.Is(err, goods.ErrCapacity) {
+		t.Fatalf("NewTwoQueueCache(0) error = %v, want goods.ErrCapacity", err)
+	}
+	for _, opt := range []Option{WithRecentRatio(0), WithRecentRatio(1), WithGhostRatio(-0.5), WithGhostRatio(math.NaN())} {
+		if _, err := NewTwoQueueCache[int, int](4, opt); !errors.Is(err, ErrInvalidRatio) {
+			t.Errorf("NewTwoQueueCache with a bad ratio error = %v, want ErrInvalidRatio", err)
+		}
+	}
+	c, _ := NewTwoQueueCache[int, int](1)
+	if err := c.UpdateCapacity(-1); !errors.Is(err, goods.ErrCapacity) {
+		t.Fatalf("UpdateCapacity(-1) error = %v, want goods.ErrCapacity", err)
+	}
+}
+
+// checkInvariants verifies the queue bounds and that the map and the lists agree.
+func checkInvariants(t *testing.T, c *TwoQueueCache[int, int]) {
+	t.Helper()
+	if c.Len() > c.capacity {
+		t.Fatalf("Len() = %d exceeds capacity %d", c.Len(), c.capacity)
+	}
+	if c.ghosts.Len() > c.ghostSize {
+		t.Fatalf("%d ghosts, more than %d", c.ghosts.Len(), c.ghostSize)
+	}
+	if len(c.entries) != c.Len()+c.ghosts.Len() {
+		t.Fatalf("map holds %d entries, lists hold %d", len(c.entries), c.Len()+c.ghosts.Len())
+	}
+	for _, l := range []*nodelist.List[int, int]{c.recent, c.frequent, c.ghosts} {
+		for node := range l.All() {
+			if e := c.entries[node.Key]; e == nil || e.list != l || e.node != node {
+				t.Fatalf("entry of key %d does not point at its list", node.Key)
+			}
+		}
+	}
+}
+
+func TestInvariants(t *testing.T) {
+	rng := rand.New(rand.NewPCG(71, 72))
+	c, _ := NewTwoQueueCache[int, int](16)
+	for range 20000 {
+		k := rng.IntN(48)
+		switch rng.IntN(20) {
+		case 0:
+			c.Delete(k)
+		case 1:
+			_ = c.UpdateCapacity(1 + rng.IntN(24))
+		case 2, 3, 4, 5, 6, 7, 8:
+			c.Get(k)
+		default:
+			c.Put(k, k)
+		}
+		checkInvariants(t, c)
+	}
+}
+
+func TestGhostPromotesToFrequent(t *testing.T) {
+	c, _ := NewTwoQueueCache[int, int](4) // recent share 1, 2 ghosts
+	for k := range 5 {
+		c.Put(k, k)
+	}
+	if !slices.Equal(c.ghosts.Keys(), []int{0}) {
+		t.Fatalf("ghosts = %v; want [0]", c.ghosts.Keys())
+	}
+	c.Get(1) // a hit in recent does not reorder it
+	if !slices.Equal(c.recent.Keys(), []int{1, 2, 3, 4}) {
+		t.Fatalf("recent = %v; want FIFO order [1 2 3 4]", c.recent.Keys())
+	}
+
+	c.Put(0, 0)
+	if !slices.Equal(c.frequent.Keys(), []int{0}) {
+		t.Fatalf("frequent = %v; a returning ghost belongs in frequent", c.frequent.Keys())
+	}
+	if v, ok := c.Get(0); !ok || v != 0 {
+		t.Fatalf("Get(0) = %v,%v; want 0,true", v, ok)
+	}
+	checkInvariants(t, c)
+}
+
+func TestPurgeForgetsGhosts(t *testing.T) {
+	c, _ := NewTwoQueueCache[int, int](4)
+	for k := range 5 {
+		c.Put(k, k)
+	}
+	c.Purge()
+	if len(c.entries) != 0 || c.Len() != 0 || c.ghosts.Len() != 0 {
+		t.Fatalf("Purge left %d entries and %d ghosts", len(c.entries), c.ghosts.Len())
+	}
+	// 0 was a ghost before Purge; now it is a new key and goes to recent
+	c.Put(0, 0)
+	if !slices.Equal(c.recent.Keys(), []int{0}) || c.frequent.Len() != 0 {
+		t.Fatalf("recent = %v, frequent = %v after Purge; want [0], []", c.recent.Keys(), c.frequent.Keys())
+	}
+}
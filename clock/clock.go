@@ -0,0 +1,118 @@
+// Package clock abstracts the current time so that time-based structures can be
+// tested deterministically.
+//
+// Structures that depend on time accept a Clock and default to System. Tests
+// pass a *Fake instead and move time forward explicitly with Advance.
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock reports the current time and signals when a duration has elapsed.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// After returns a channel that receives the current time once d has elapsed.
+	After(d time.Duration) <-chan time.Time
+}
+
+// System is the Clock backed by time.Now and time.After.
+var System Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// Fake is a Clock that only moves when told to. It is safe for concurrent use.
+type Fake struct {
+	// mu guards every field below
+	mu sync.Mutex
+
+	// now is the time reported by Now
+	now time.Time
+
+	// timers are the channels returned by After that have not fired yet
+	timers []fakeTimer
+}
+
+// fakeTimer is a pending After call on a Fake clock.
+type fakeTimer struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+// Compile-time check that Fake implements the Clock interface.
+var _ Clock = (*Fake)(nil)
+
+// NewFake returns a Fake clock that reports start until it is moved.
+func NewFake(start time.Time) *Fake {
+	return &Fake{now: start}
+}
+
+// Now returns the fake current time.
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// After returns a channel that receives the fake time once the clock has been
+// moved at least d past the current fake time. A non-positive d fires immediately.
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- f.now
+		return ch
+	}
+	f.timers = append(f.timers, fakeTimer{deadline: f.now.Add(d), ch: ch})
+	return ch
+}
+
+// Advance moves the fake time forward by d, firing every timer that became due.
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.setLocked(f.now.Add(d))
+}
+
+// Set moves the fake time to t, firing every timer that became due.
+func (f *Fake) Set(t time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.setLocked(t)
+}
+
+// PendingTimers returns the number of After channels that have not fired yet.
+// Tests use it to wait until a goroutine is blocked on the clock before moving it.
+func (f *Fake) PendingTimers() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.timers)
+}
+
+// setLocked moves the time to t and fires the due timers. f.mu must be held.
+func (f *Fake) setLocked(t time.Time) {
+	f.now = t
+	pending := f.timers[:0]
+	for _, timer := range f.timers {
+		if timer.deadline.After(t) {
+			pending = append(pending, timer)
+			continue
+		}
+		// The channel is buffered and fires only once, so this never blocks
+		timer.ch <- t
+	}
+	clear(f.timers[len(pending):])
+	f.timers = pending
+}
@@ -0,0 +1,62 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFake(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := NewFake(start)
+	if !f.Now().Equal(start) {
+		t.Errorf("Now = %s, expected %s", f.Now(), start)
+	}
+	f.Advance(time.Minute)
+	if expected := start.Add(time.Minute); !f.Now().Equal(expected) {
+		t.Errorf("Now after Advance = %s, expected %s", f.Now(), expected)
+	}
+	f.Set(start)
+	if !f.Now().Equal(start) {
+		t.Errorf("Now after Set = %s, expected %s", f.Now(), start)
+	}
+}
+
+func TestSystem(t *testing.T) {
+	before := time.Now()
+	now := System.Now()
+	if now.Before(before) {
+		t.Errorf("System.Now = %s is before time.Now = %s", now, before)
+	}
+}
+
+func TestFake_After(t *testing.T) {
+	f := NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	select {
+	case <-f.After(0):
+	default:
+		t.Error("After(0) should fire immediately")
+	}
+
+	ch := f.After(10 * time.Second)
+	if f.PendingTimers() != 1 {
+		t.Fatalf("PendingTimers = %d, expected 1", f.PendingTimers())
+	}
+	f.Advance(9 * time.Second)
+	select {
+	case <-ch:
+		t.Fatal("After fired before its deadline")
+	default:
+	}
+	f.Advance(time.Second)
+	select {
+	case now := <-ch:
+		if !now.Equal(f.Now()) {
+			t.Errorf("After sent %s, expected %s", now, f.Now())
+		}
+	default:
+		t.Fatal("After should fire once the deadline is reached")
+	}
+	if f.PendingTimers() != 0 {
+		t.Errorf("PendingTimers = %d, expected 0", f.PendingTimers())
+	}
+}
@@ -0,0 +1,60 @@
+// Package goods holds the errors shared by every data structure in this module.
+//
+// Each package wraps these sentinels with fmt.Errorf("...: %w", ...), so callers
+// can tell failures apart with errors.Is and errors.As instead of matching strings.
+package goods
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrEmpty is returned when an element is requested from an empty collection.
+	ErrEmpty = errors.New("collection is empty")
+
+	// ErrIndexOutOfRange is returned when an index falls outside the valid range.
+	// The concrete error is an *IndexError carrying the index and bounds.
+	ErrIndexOutOfRange = errors.New("index out of range")
+
+	// ErrNotInitialized is returned when a method is called on a nil collection
+	// or a collection that was not created by its constructor.
+	ErrNotInitialized = errors.New("collection is not initialized")
+
+	// ErrCapacity is returned when a capacity argument is not valid.
+	ErrCapacity = errors.New("invalid capacity")
+
+	// ErrFull is returned when an element is added to a bounded collection
+	// that has no room left.
+	ErrFull = errors.New("collection is full")
+
+	// ErrClosed is returned when a collection is used after it was closed.
+	ErrClosed = errors.New("collection is closed")
+)
+
+// IndexError reports an index outside the half-open range [Min, Max).
+// errors.Is(err, ErrIndexOutOfRange) reports true for any *IndexError.
+type IndexError struct {
+	// Index is the index that was requested
+	Index int
+
+	// Min is the smallest valid index (inclusive)
+	Min int
+
+	// Max is the upper bound of the valid indices (exclusive)
+	Max int
+}
+
+// NewIndexError returns an *IndexError for index outside [minIndex, maxIndex).
+func NewIndexError(index, minIndex, maxIndex int) *IndexError {
+	return &IndexError{Index: index, Min: minIndex, Max: maxIndex}
+}
+
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("index %d out of range [%d, %d)", e.Index, e.Min, e.Max)
+}
+
+// Unwrap returns ErrIndexOutOfRange so that errors.Is matches the sentinel.
+func (e *IndexError) Unwrap() error {
+	return ErrIndexOutOfRange
+}
@@ -0,0 +1,33 @@
+package goods
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestIndexError_Is(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", NewIndexError(5, 0, 3))
+
+	if !errors.Is(err, ErrIndexOutOfRange) {
+		t.Error("errors.Is(err, ErrIndexOutOfRange) should be true for a wrapped *IndexError")
+	}
+	if errors.Is(err, ErrEmpty) {
+		t.Error("errors.Is(err, ErrEmpty) should be false for an *IndexError")
+	}
+}
+
+func TestIndexError_As(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", NewIndexError(-1, 0, 4))
+
+	var indexErr *IndexError
+	if !errors.As(err, &indexErr) {
+		t.Fatal("errors.As should extract the *IndexError")
+	}
+	if indexErr.Index != -1 || indexErr.Min != 0 || indexErr.Max != 4 {
+		t.Errorf("IndexError = %+v, expected {Index:-1 Min:0 Max:4}", *indexErr)
+	}
+	if got := indexErr.Error(); got != "index -1 out of range [0, 4)" {
+		t.Errorf("Error() = %q", got)
+	}
+}
@@ -2,6 +2,10 @@ package arraylist
 
 import (
 	"fmt"
+	"iter"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/list"
 )
 
 // ArrayList represents the ArrayList data structure
@@ -14,8 +18,15 @@ type ArrayList[T any] struct {
 
 	// listSize represents the number of elements currently stored in the list
 	listSize int
+
+	// modCount counts structural modifications (insertions and removals).
+	// Iterators compare it against the value captured at start to fail fast.
+	modCount int
 }
 
+// Compile time interface implementation check
+var _ list.List[int] = (*ArrayList[int])(nil)
+
 // New returns an empty ArrayList with the specified initial capacity.
 // Time Complexity: O(1)
 // Space Complexity: O(capacity)
@@ -38,6 +49,7 @@ func (al *ArrayList[T]) Append(element T) error {
 	}
 	al.data[al.listSize] = element
 	al.listSize++
+	al.modCount++
 	return nil
 }
 
@@ -54,17 +66,25 @@ func (al *ArrayList[T]) AppendAll(elements ...T) error {
 	for _, elem := range elements {
 		al.data[al.listSize] = elem
 		al.listSize++
+		al.modCount++
 	}
 	return nil
 }
 
+// Prepend adds an element in front of the list, shifting every element to the right.
+// Time Complexity: O(n)
+// Space Complexity: O(1) average, O(n) when resizing
+func (al *ArrayList[T]) Prepend(element T) error {
+	return al.Add(0, element)
+}
+
 // Add inserts an element at the specified index, shifting later elements to right.
 // Time Complexity: O(n)
 // Space Complexity: O(1) average, O(n) when resizing
 func (al *ArrayList[T]) Add(index int, element T) error {
 	// Allow inserting at index 0 to listSize (inclusive)
 	if index < 0 || index > al.listSize {
-		return fmt.Errorf("index %d out of range [0, %d]", index, al.listSize)
+		return goods.NewIndexError(index, 0, al.listSize+1)
 	}
 
 	if al.listSize >= al.listCapacity {
@@ -81,6 +101,7 @@ func (al *ArrayList[T]) Add(index int, element T) error {
 	// Insert the element at the specified index
 	al.data[index] = element
 	al.listSize++
+	al.modCount++
 	return nil
 }
 
@@ -124,6 +145,38 @@ func (al *ArrayList[T]) Delete(index int) error {
 	var defaultValue T
 	al.data[al.listSize-1] = defaultValue
 	al.listSize--
+	al.modCount++
+
+	// Reduce capacity if the size is less than 1/2 of capacity
+	if al.listSize > 0 && (al.listSize*2) <= al.listCapacity {
+		if err := al.decreaseCapacity(); err != nil {
+			return fmt.Errorf("failed to decrease capacity: %w", err)
+		}
+	}
+	return nil
+}
+
+// DeleteRange removes the elements at indices [from, to), shifting later
+// elements left in a single copy.
+// An invalid range returns a *goods.IndexError for the offending bound.
+// Time Complexity: O(n)
+// Space Complexity: O(1) average, O(n) when resizing
+func (al *ArrayList[T]) DeleteRange(from, to int) error {
+	if from < 0 || from > al.listSize {
+		return goods.NewIndexError(from, 0, al.listSize+1)
+	}
+	if to < from || to > al.listSize {
+		return goods.NewIndexError(to, from, al.listSize+1)
+	}
+	if from == to {
+		return nil
+	}
+
+	copy(al.data[from:], al.data[to:al.listSize])
+	// Clear the vacated slots at the end
+	clear(al.data[al.listSize-(to-from) : al.listSize])
+	al.listSize -= to - from
+	al.modCount++
 
 	// Reduce capacity if the size is less than 1/2 of capacity
 	if al.listSize > 0 && (al.listSize*2) <= al.listCapacity {
@@ -134,6 +187,22 @@ func (al *ArrayList[T]) Delete(index int) error {
 	return nil
 }
 
+// CopyRange copies the elements at indices [from, to) into dst with a single
+// copy and returns how many were copied, which is the smaller of to-from and
+// len(dst). The list is not modified.
+// An invalid range returns a *goods.IndexError for the offending bound.
+// Time Complexity: O(k) where k is the number of copied elements
+// Space Complexity: O(1)
+func (al *ArrayList[T]) CopyRange(dst []T, from, to int) (int, error) {
+	if from < 0 || from > al.listSize {
+		return 0, goods.NewIndexError(from, 0, al.listSize+1)
+	}
+	if to < from || to > al.listSize {
+		return 0, goods.NewIndexError(to, from, al.listSize+1)
+	}
+	return copy(dst, al.data[from:to]), nil
+}
+
 // Size returns the number of elements in the list.
 // Time Complexity: O(1)
 // Space Complexity: O(1)
@@ -152,11 +221,55 @@ func (al *ArrayList[T]) IsEmpty() bool {
 // Time Complexity: O(1)
 // Space Complexity: O(1)
 func (al *ArrayList[T]) Clear() {
+	al.modCount++
 	al.listCapacity = 0
 	al.listSize = 0
 	al.data = nil
 }
 
+// All returns an iterator over index-element pairs from index 0 to Size()-1.
+// Time Complexity: O(n) for a full iteration
+// Space Complexity: O(1)
+func (al *ArrayList[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		expectedModCount := al.modCount
+		for i := 0; i < al.listSize; i++ {
+			if !yield(i, al.data[i]) {
+				return
+			}
+			al.checkModCount(expectedModCount)
+		}
+	}
+}
+
+// Values returns an iterator over the elements from index 0 to Size()-1.
+// Time Complexity: O(n) for a full iteration
+// Space Complexity: O(1)
+func (al *ArrayList[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, element := range al.All() {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over index-element pairs from index Size()-1 down to 0.
+// Time Complexity: O(n) for a full iteration
+// Space Complexity: O(1)
+func (al *ArrayList[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		expectedModCount := al.modCount
+		for i := al.listSize - 1; i >= 0; i-- {
+			if !yield(i, al.data[i]) {
+				return
+			}
+			al.checkModCount(expectedModCount)
+		}
+	}
+}
+
 // increaseCapacity doubles the capacity.
 // Time Complexity: O(n)
 // Space Complexity: O(n)
@@ -187,7 +300,15 @@ func (al *ArrayList[T]) decreaseCapacity() error {
 // checkIndexRange validates that index is within [0, listSize).
 func (al *ArrayList[T]) checkIndexRange(index int) error {
 	if index < 0 || index >= al.listSize {
-		return fmt.Errorf("index %d out of range [0, %d)", index, al.listSize)
+		return goods.NewIndexError(index, 0, al.listSize)
 	}
 	return nil
 }
+
+// checkModCount panics with list.ErrConcurrentModification if the list was
+// structurally modified since an iterator captured expectedModCount.
+func (al *ArrayList[T]) checkModCount(expectedModCount int) {
+	if al.modCount != expectedModCount {
+		panic(list.ErrConcurrentModification)
+	}
+}
@@ -1,6 +1,14 @@
 package arraylist
 
-import "testing"
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/list"
+	"github.com/Scanf-s/goods/list/listtest"
+)
 
 func TestArrayList_New(t *testing.T) {
 	list := New[int](10)
@@ -399,3 +407,210 @@ func TestArrayList_WithStructs(t *testing.T) {
 		t.Errorf("Get(0) = %+v, expected Alice/30", val)
 	}
 }
+
+func TestArrayList_All(t *testing.T) {
+	list := New[int](2)
+	if err := list.AppendAll(10, 20, 30); err != nil {
+		t.Errorf("AppendAll failed: %s", err)
+	}
+
+	visited := 0
+	for i, v := range list.All() {
+		if v != (i+1)*10 {
+			t.Errorf("All yielded (%d, %d), expected (%d, %d)", i, v, i, (i+1)*10)
+		}
+		visited++
+	}
+	if visited != 3 {
+		t.Errorf("All visited %d elements, expected 3", visited)
+	}
+}
+
+func TestArrayList_All_EarlyBreak(t *testing.T) {
+	list := New[int](2)
+	if err := list.AppendAll(1, 2, 3, 4); err != nil {
+		t.Errorf("AppendAll failed: %s", err)
+	}
+
+	visited := 0
+	for _, v := range list.All() {
+		visited++
+		if v == 2 {
+			break
+		}
+	}
+	if visited != 2 {
+		t.Errorf("All visited %d elements before break, expected 2", visited)
+	}
+}
+
+func TestArrayList_Values(t *testing.T) {
+	list := New[int](2)
+	for range list.Values() {
+		t.Error("Values on an empty list should not yield")
+	}
+
+	if err := list.AppendAll(1, 2, 3); err != nil {
+		t.Errorf("AppendAll failed: %s", err)
+	}
+	sum := 0
+	for v := range list.Values() {
+		sum += v
+	}
+	if sum != 6 {
+		t.Errorf("Sum of Values = %d, expected 6", sum)
+	}
+}
+
+func TestArrayList_Backward(t *testing.T) {
+	list := New[int](2)
+	if err := list.AppendAll(10, 20, 30); err != nil {
+		t.Errorf("AppendAll failed: %s", err)
+	}
+
+	expectedIndex := 2
+	for i, v := range list.Backward() {
+		if i != expectedIndex || v != (i+1)*10 {
+			t.Errorf("Backward yielded (%d, %d), expected (%d, %d)", i, v, expectedIndex, (expectedIndex+1)*10)
+		}
+		expectedIndex--
+	}
+	if expectedIndex != -1 {
+		t.Errorf("Backward stopped early at index %d", expectedIndex+1)
+	}
+}
+
+func TestArrayList_Prepend(t *testing.T) {
+	list := New[int](0)
+	for _, v := range []int{3, 2, 1} {
+		if err := list.Prepend(v); err != nil {
+			t.Errorf("Prepend failed: %s", err)
+		}
+	}
+	for i := range 3 {
+		if val, _ := list.Get(i); val != i+1 {
+			t.Errorf("Get(%d) = %d, expected %d", i, val, i+1)
+		}
+	}
+}
+
+func TestArrayList_All_ConcurrentModification(t *testing.T) {
+	l := New[int](2)
+	if err := l.AppendAll(1, 2, 3, 4); err != nil {
+		t.Errorf("AppendAll failed: %s", err)
+	}
+
+	defer func() {
+		r := recover()
+		if r != list.ErrConcurrentModification {
+			t.Errorf("expected panic with ErrConcurrentModification, got %v", r)
+		}
+	}()
+	for i := range l.All() {
+		if i == 1 {
+			_ = l.Delete(i)
+		}
+	}
+}
+
+func TestArrayList_All_SetDuringIteration(t *testing.T) {
+	l := New[int](2)
+	if err := l.AppendAll(1, 2, 3); err != nil {
+		t.Errorf("AppendAll failed: %s", err)
+	}
+
+	// Set is not a structural modification, so iteration must continue.
+	for i, v := range l.All() {
+		if err := l.Set(i, v*10); err != nil {
+			t.Errorf("Set(%d) failed: %s", i, err)
+		}
+	}
+	for i := range 3 {
+		if val, _ := l.Get(i); val != (i+1)*10 {
+			t.Errorf("Get(%d) = %d, expected %d", i, val, (i+1)*10)
+		}
+	}
+}
+
+func TestArrayList_Errors(t *testing.T) {
+	l := New[int](0)
+	if err := l.AppendAll(1, 2, 3); err != nil {
+		t.Errorf("AppendAll failed: %s", err)
+	}
+
+	_, err := l.Get(3)
+	var indexErr *goods.IndexError
+	if !errors.As(err, &indexErr) {
+		t.Fatalf("Get(3) error should be *goods.IndexError, got %v", err)
+	}
+	if indexErr.Index != 3 || indexErr.Min != 0 || indexErr.Max != 3 {
+		t.Errorf("IndexError = %+v, expected {Index:3 Min:0 Max:3}", *indexErr)
+	}
+
+	// Add accepts index Size(), so the exclusive upper bound is Size()+1
+	err = l.Add(5, 0)
+	if !errors.As(err, &indexErr) || indexErr.Max != 4 {
+		t.Errorf("Add(5) error = %v, expected IndexError with Max 4", err)
+	}
+	if !errors.Is(l.Delete(-1), goods.ErrIndexOutOfRange) {
+		t.Error("Delete(-1) error should match goods.ErrIndexOutOfRange")
+	}
+}
+
+func TestArrayList_ListSuite(t *testing.T) {
+	listtest.RunListSuite(t, func() list.List[int] {
+		return New[int](0)
+	})
+}
+
+func TestArrayList_DeleteRange(t *testing.T) {
+	l := New[int](0)
+	_ = l.AppendAll(0, 1, 2, 3, 4, 5)
+	if err := l.DeleteRange(1, 4); err != nil {
+		t.Fatal(err)
+	}
+	if got := slices.Collect(l.Values()); !slices.Equal(got, []int{0, 4, 5}) {
+		t.Errorf("list = %v, expected [0 4 5]", got)
+	}
+	if err := l.DeleteRange(2, 2); err != nil || l.Size() != 3 {
+		t.Errorf("empty DeleteRange = %v with size %d, expected nil with size 3", err, l.Size())
+	}
+
+	var indexErr *goods.IndexError
+	if err := l.DeleteRange(-1, 1); !errors.As(err, &indexErr) || indexErr.Index != -1 {
+		t.Errorf("DeleteRange(-1, 1) error = %v, expected an IndexError for -1", err)
+	}
+	if err := l.DeleteRange(2, 4); !errors.As(err, &indexErr) || indexErr.Index != 4 {
+		t.Errorf("DeleteRange(2, 4) error = %v, expected an IndexError for 4", err)
+	}
+	if err := l.DeleteRange(0, 3); err != nil || !l.IsEmpty() {
+		t.Errorf("DeleteRange(0, 3) = %v with size %d, expected an empty list", err, l.Size())
+	}
+}
+
+func TestArrayList_CopyRange(t *testing.T) {
+	l := New[int](0)
+	_ = l.AppendAll(0, 1, 2, 3, 4, 5)
+	dst := make([]int, 3)
+	if n, err := l.CopyRange(dst, 1, 5); err != nil || n != 3 || !slices.Equal(dst, []int{1, 2, 3}) {
+		t.Errorf("CopyRange(dst, 1, 5) = %d (err %v) with dst %v, expected 3 with [1 2 3]", n, err, dst)
+	}
+	dst = make([]int, 4)
+	if n, err := l.CopyRange(dst, 4, 6); err != nil || n != 2 || !slices.Equal(dst[:n], []int{4, 5}) {
+		t.Errorf("CopyRange(dst, 4, 6) = %d (err %v) with dst %v, expected 2 with [4 5]", n, err, dst)
+	}
+	if n, err := l.CopyRange(dst, 6, 6); err != nil || n != 0 {
+		t.Errorf("empty CopyRange = %d (err %v), expected 0", n, err)
+	}
+	if l.Size() != 6 {
+		t.Errorf("CopyRange must not modify the list, size = %d", l.Size())
+	}
+
+	var indexErr *goods.IndexError
+	if _, err := l.CopyRange(dst, -1, 1); !errors.As(err, &indexErr) || indexErr.Index != -1 {
+		t.Errorf("CopyRange(dst, -1, 1) error = %v, expected an IndexError for -1", err)
+	}
+	if _, err := l.CopyRange(dst, 2, 7); !errors.As(err, &indexErr) || indexErr.Index != 7 {
+		t.Errorf("CopyRange(dst, 2, 7) error = %v, expected an IndexError for 7", err)
+	}
+}
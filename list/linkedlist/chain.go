@@ -0,0 +1,37 @@
+package linkedlist
+
+import "github.com/Scanf-s/goods/list"
+
+// newChain links a new node for every element of data and returns the first and
+// last nodes, or nil for empty data. With linkPrev the Prev pointers are set too.
+// Time Complexity: O(k) where k is len(data)
+// Space Complexity: O(k)
+func newChain[T any](data []T, linkPrev bool) (first, last *list.Node[T]) {
+	for _, element := range data {
+		node := list.NewNode(element)
+		if first == nil {
+			first = node
+		} else {
+			last.Next = node
+			if linkPrev {
+				node.Prev = last
+			}
+		}
+		last = node
+	}
+	return first, last
+}
+
+// copyChain copies the data of len(dst) nodes starting at node into dst and
+// returns the last node copied. The chain must hold at least len(dst) nodes.
+// Time Complexity: O(k) where k is len(dst)
+// Space Complexity: O(1)
+func copyChain[T any](dst []T, node *list.Node[T]) *list.Node[T] {
+	for i := range dst {
+		dst[i] = node.Data
+		if i < len(dst)-1 {
+			node = node.Next
+		}
+	}
+	return node
+}
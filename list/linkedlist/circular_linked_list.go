@@ -2,7 +2,9 @@ package linkedlist
 
 import (
 	"fmt"
+	"iter"
 
+	"github.com/Scanf-s/goods"
 	"github.com/Scanf-s/goods/list"
 )
 
@@ -17,6 +19,10 @@ type CircularLinkedList[T any] struct {
 
 	// nodeCount represents the number of nodes currently in the list
 	nodeCount int
+
+	// modCount counts structural modifications (insertions and removals).
+	// Iterators compare it against the value captured at start to fail fast.
+	modCount int
 }
 
 // Compile time interface implementation check
@@ -39,6 +45,7 @@ func (cl *CircularLinkedList[T]) Append(data T) error {
 		cl.tail = node
 		node.Next = cl.head
 		cl.nodeCount++
+		cl.modCount++
 		return nil
 	}
 
@@ -46,18 +53,28 @@ func (cl *CircularLinkedList[T]) Append(data T) error {
 	cl.tail = node
 	node.Next = cl.head
 	cl.nodeCount++
+	cl.modCount++
 	return nil
 }
 
-// AppendAll adds multiple elements to the end of the list.
+// AppendAll adds multiple elements to the end of the list, keeping the ring closed.
+// The new nodes are linked into a chain first, then spliced in after the tail at once.
 // Time Complexity: O(k) where k is number of elements
 // Space Complexity: O(k)
 func (cl *CircularLinkedList[T]) AppendAll(data ...T) error {
-	for _, element := range data {
-		if err := cl.Append(element); err != nil {
-			return err
-		}
+	first, last := newChain(data, false)
+	if first == nil {
+		return nil
 	}
+	if cl.head == nil {
+		cl.head = first
+	} else {
+		cl.tail.Next = first
+	}
+	cl.tail = last
+	cl.tail.Next = cl.head
+	cl.nodeCount += len(data)
+	cl.modCount++
 	return nil
 }
 
@@ -72,6 +89,7 @@ func (cl *CircularLinkedList[T]) Prepend(data T) error {
 		cl.tail = newNode
 		newNode.Next = newNode
 		cl.nodeCount++
+		cl.modCount++
 		return nil
 	}
 
@@ -79,6 +97,7 @@ func (cl *CircularLinkedList[T]) Prepend(data T) error {
 	cl.tail.Next = newNode
 	cl.head = newNode
 	cl.nodeCount++
+	cl.modCount++
 	return nil
 }
 
@@ -88,7 +107,7 @@ func (cl *CircularLinkedList[T]) Prepend(data T) error {
 // Space Complexity: O(1)
 func (cl *CircularLinkedList[T]) Add(index int, data T) error {
 	if index < 0 || index > cl.nodeCount {
-		return fmt.Errorf("index %d out of range [0, %d]", index, cl.nodeCount)
+		return goods.NewIndexError(index, 0, cl.nodeCount+1)
 	}
 
 	if index == 0 {
@@ -107,6 +126,7 @@ func (cl *CircularLinkedList[T]) Add(index int, data T) error {
 	node.Next = currentNode.Next
 	currentNode.Next = node
 	cl.nodeCount++
+	cl.modCount++
 	return nil
 }
 
@@ -159,6 +179,7 @@ func (cl *CircularLinkedList[T]) Delete(index int) error {
 		cl.head = cl.head.Next
 		cl.tail.Next = cl.head
 		cl.nodeCount--
+		cl.modCount++
 		return nil
 	}
 
@@ -171,6 +192,7 @@ func (cl *CircularLinkedList[T]) Delete(index int) error {
 		cl.tail = currentNode
 	}
 	cl.nodeCount--
+	cl.modCount++
 	return nil
 }
 
@@ -192,6 +214,7 @@ func (cl *CircularLinkedList[T]) IsEmpty() bool {
 // Time Complexity: O(1)
 // Space Complexity: O(1)
 func (cl *CircularLinkedList[T]) Clear() {
+	cl.modCount++
 	cl.head = nil
 	cl.tail = nil
 	cl.nodeCount = 0
@@ -200,7 +223,7 @@ func (cl *CircularLinkedList[T]) Clear() {
 func (cl *CircularLinkedList[T]) Head() (T, error) {
 	var element T
 	if cl.head == nil {
-		return element, fmt.Errorf("the list is empty")
+		return element, fmt.Errorf("cannot get head element: %w", goods.ErrEmpty)
 	}
 	return cl.head.Data, nil
 }
@@ -208,12 +231,13 @@ func (cl *CircularLinkedList[T]) Head() (T, error) {
 func (cl *CircularLinkedList[T]) PopHead() (T, error) {
 	var element T
 	if cl.head == nil {
-		return element, fmt.Errorf("the list is empty. Cannot pop the head")
+		return element, fmt.Errorf("cannot pop head element: %w", goods.ErrEmpty)
 	}
 	element = cl.head.Data
 	cl.head = cl.head.Next
 	cl.tail.Next = cl.head
 	cl.nodeCount--
+	cl.modCount++
 	if cl.nodeCount <= 0 {
 		cl.head = nil
 		cl.tail = nil
@@ -222,10 +246,73 @@ func (cl *CircularLinkedList[T]) PopHead() (T, error) {
 	return element, nil
 }
 
+// PopHeadInto removes up to len(dst) elements from the head, copies them into
+// dst in order and returns how many were removed. The removed nodes are cut out
+// of the ring in one step.
+// Time Complexity: O(k) where k is the number of elements removed
+// Space Complexity: O(1)
+func (cl *CircularLinkedList[T]) PopHeadInto(dst []T) int {
+	n := min(len(dst), cl.nodeCount)
+	if n == 0 {
+		return 0
+	}
+	last := copyChain(dst[:n], cl.head)
+	if n == cl.nodeCount {
+		cl.head = nil
+		cl.tail = nil
+	} else {
+		cl.head = last.Next
+		cl.tail.Next = cl.head
+	}
+	last.Next = nil
+	cl.nodeCount -= n
+	cl.modCount++
+	return n
+}
+
+// All returns an iterator over index-element pairs from head to tail.
+// Time Complexity: O(n) for a full iteration
+// Space Complexity: O(1)
+func (cl *CircularLinkedList[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		expectedModCount := cl.modCount
+		// The ring has no nil terminator, so stop after visiting nodeCount nodes
+		node := cl.head
+		for index := range cl.nodeCount {
+			if !yield(index, node.Data) {
+				return
+			}
+			cl.checkModCount(expectedModCount)
+			node = node.Next
+		}
+	}
+}
+
+// Values returns an iterator over the elements from head to tail.
+// Time Complexity: O(n) for a full iteration
+// Space Complexity: O(1)
+func (cl *CircularLinkedList[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, element := range cl.All() {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
 // checkIndexRange validates that index is within [0, nodeCount).
 func (cl *CircularLinkedList[T]) checkIndexRange(index int) error {
 	if index < 0 || index >= cl.nodeCount {
-		return fmt.Errorf("index %d out of range [0, %d)", index, cl.nodeCount)
+		return goods.NewIndexError(index, 0, cl.nodeCount)
 	}
 	return nil
 }
+
+// checkModCount panics with list.ErrConcurrentModification if the list was
+// structurally modified since an iterator captured expectedModCount.
+func (cl *CircularLinkedList[T]) checkModCount(expectedModCount int) {
+	if cl.modCount != expectedModCount {
+		panic(list.ErrConcurrentModification)
+	}
+}
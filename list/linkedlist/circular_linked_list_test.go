@@ -1,6 +1,14 @@
 package linkedlist
 
-import "testing"
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/list"
+	"github.com/Scanf-s/goods/list/listtest"
+)
 
 func TestCircularLinkedList_New(t *testing.T) {
 	list := NewCircularLinkedList[int]()
@@ -702,3 +710,147 @@ func TestCircularLinkedList_PopHead_SingleElement(t *testing.T) {
 		t.Error("PopHead on the now-empty list should return an error")
 	}
 }
+
+func TestCircularLinkedList_All(t *testing.T) {
+	list := NewCircularLinkedList[int]()
+	if err := list.AppendAll(10, 20, 30); err != nil {
+		t.Errorf("AppendAll failed: %s", err)
+	}
+
+	visited := 0
+	for i, v := range list.All() {
+		if v != (i+1)*10 {
+			t.Errorf("All yielded (%d, %d), expected (%d, %d)", i, v, i, (i+1)*10)
+		}
+		visited++
+	}
+	if visited != 3 {
+		t.Errorf("All visited %d elements, expected 3", visited)
+	}
+}
+
+func TestCircularLinkedList_All_EarlyBreak(t *testing.T) {
+	list := NewCircularLinkedList[int]()
+	if err := list.AppendAll(1, 2, 3, 4); err != nil {
+		t.Errorf("AppendAll failed: %s", err)
+	}
+
+	visited := 0
+	for _, v := range list.All() {
+		visited++
+		if v == 2 {
+			break
+		}
+	}
+	if visited != 2 {
+		t.Errorf("All visited %d elements before break, expected 2", visited)
+	}
+}
+
+func TestCircularLinkedList_Values(t *testing.T) {
+	list := NewCircularLinkedList[int]()
+	for range list.Values() {
+		t.Error("Values on an empty list should not yield")
+	}
+
+	if err := list.AppendAll(1, 2, 3); err != nil {
+		t.Errorf("AppendAll failed: %s", err)
+	}
+	sum := 0
+	for v := range list.Values() {
+		sum += v
+	}
+	if sum != 6 {
+		t.Errorf("Sum of Values = %d, expected 6", sum)
+	}
+}
+
+func TestCircularLinkedList_All_ConcurrentModification(t *testing.T) {
+	l := NewCircularLinkedList[int]()
+	if err := l.AppendAll(1, 2, 3, 4); err != nil {
+		t.Errorf("AppendAll failed: %s", err)
+	}
+
+	defer func() {
+		r := recover()
+		if r != list.ErrConcurrentModification {
+			t.Errorf("expected panic with ErrConcurrentModification, got %v", r)
+		}
+	}()
+	for i := range l.All() {
+		if i == 1 {
+			_ = l.Delete(i)
+		}
+	}
+}
+
+func TestCircularLinkedList_All_SetDuringIteration(t *testing.T) {
+	l := NewCircularLinkedList[int]()
+	if err := l.AppendAll(1, 2, 3); err != nil {
+		t.Errorf("AppendAll failed: %s", err)
+	}
+
+	// Set is not a structural modification, so iteration must continue.
+	for i, v := range l.All() {
+		if err := l.Set(i, v*10); err != nil {
+			t.Errorf("Set(%d) failed: %s", i, err)
+		}
+	}
+	for i := range 3 {
+		if val, _ := l.Get(i); val != (i+1)*10 {
+			t.Errorf("Get(%d) = %d, expected %d", i, val, (i+1)*10)
+		}
+	}
+}
+
+func TestCircularLinkedList_Errors(t *testing.T) {
+	l := NewCircularLinkedList[int]()
+
+	if _, err := l.Head(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Head on empty list error = %v, expected goods.ErrEmpty", err)
+	}
+	if _, err := l.PopHead(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("PopHead on empty list error = %v, expected goods.ErrEmpty", err)
+	}
+	if _, err := l.Get(0); !errors.Is(err, goods.ErrIndexOutOfRange) {
+		t.Errorf("Get(0) on empty list error = %v, expected goods.ErrIndexOutOfRange", err)
+	}
+
+	var indexErr *goods.IndexError
+	if err := l.Add(2, 1); !errors.As(err, &indexErr) || indexErr.Index != 2 || indexErr.Max != 1 {
+		t.Errorf("Add(2) error = %v, expected IndexError{Index:2 Max:1}", err)
+	}
+}
+
+func TestCircularLinkedList_ListSuite(t *testing.T) {
+	listtest.RunListSuite(t, func() list.List[int] {
+		return NewCircularLinkedList[int]()
+	})
+}
+
+func TestCircularLinkedList_AppendAll_Splice(t *testing.T) {
+	l := NewCircularLinkedList[int]()
+	_ = l.Append(0)
+	_ = l.AppendAll(1, 2)
+	if got := slices.Collect(l.Values()); !slices.Equal(got, []int{0, 1, 2}) {
+		t.Errorf("list = %v, expected [0 1 2]", got)
+	}
+	if l.tail.Next != l.head {
+		t.Error("tail.Next should point back to head after AppendAll")
+	}
+}
+
+func TestCircularLinkedList_PopHeadInto(t *testing.T) {
+	l := NewCircularLinkedList[int]()
+	_ = l.AppendAll(0, 1, 2, 3)
+	dst := make([]int, 2)
+	if n := l.PopHeadInto(dst); n != 2 || !slices.Equal(dst, []int{0, 1}) {
+		t.Errorf("PopHeadInto = %v (n = %d), expected [0 1]", dst, n)
+	}
+	if l.tail.Next != l.head || l.head.Data != 2 {
+		t.Error("the ring should be closed around the remaining elements")
+	}
+	if n := l.PopHeadInto(make([]int, 5)); n != 2 || !l.IsEmpty() || l.head != nil || l.tail != nil {
+		t.Errorf("PopHeadInto = %d, expected 2 and an empty ring", n)
+	}
+}
@@ -2,7 +2,9 @@ package linkedlist
 
 import (
 	"fmt"
+	"iter"
 
+	"github.com/Scanf-s/goods"
 	"github.com/Scanf-s/goods/list"
 )
 
@@ -17,6 +19,10 @@ type DoublyLinkedList[T any] struct {
 
 	// nodeCount represents the number of nodes currently in the list
 	nodeCount int
+
+	// modCount counts structural modifications (insertions and removals).
+	// Iterators compare it against the value captured at start to fail fast.
+	modCount int
 }
 
 // Compile time interface implementation check
@@ -37,6 +43,7 @@ func (dl *DoublyLinkedList[T]) Append(data T) error {
 		dl.head = list.NewNode(data)
 		dl.tail = dl.head
 		dl.nodeCount++
+		dl.modCount++
 		return nil
 	}
 
@@ -45,18 +52,28 @@ func (dl *DoublyLinkedList[T]) Append(data T) error {
 	newNode.Prev = dl.tail
 	dl.tail = newNode
 	dl.nodeCount++
+	dl.modCount++
 	return nil
 }
 
 // AppendAll adds multiple elements to the end of the list.
+// The new nodes are linked into a chain first, then spliced onto the tail at once.
 // Time Complexity: O(k) where k is number of elements
 // Space Complexity: O(k)
 func (dl *DoublyLinkedList[T]) AppendAll(data ...T) error {
-	for _, element := range data {
-		if err := dl.Append(element); err != nil {
-			return err
-		}
+	first, last := newChain(data, true)
+	if first == nil {
+		return nil
 	}
+	if dl.head == nil {
+		dl.head = first
+	} else {
+		dl.tail.Next = first
+		first.Prev = dl.tail
+	}
+	dl.tail = last
+	dl.nodeCount += len(data)
+	dl.modCount++
 	return nil
 }
 
@@ -68,6 +85,7 @@ func (dl *DoublyLinkedList[T]) Prepend(data T) error {
 		dl.head = list.NewNode(data)
 		dl.tail = dl.head
 		dl.nodeCount++
+		dl.modCount++
 		return nil
 	}
 
@@ -76,6 +94,7 @@ func (dl *DoublyLinkedList[T]) Prepend(data T) error {
 	dl.head.Prev = newNode
 	dl.head = newNode
 	dl.nodeCount++
+	dl.modCount++
 	return nil
 }
 
@@ -85,7 +104,7 @@ func (dl *DoublyLinkedList[T]) Prepend(data T) error {
 // Space Complexity: O(1)
 func (dl *DoublyLinkedList[T]) Add(index int, data T) error {
 	if index < 0 || index > dl.nodeCount {
-		return fmt.Errorf("index %d out of range [0, %d]", index, dl.nodeCount)
+		return goods.NewIndexError(index, 0, dl.nodeCount+1)
 	}
 
 	// If index refers to the head of the list
@@ -108,6 +127,7 @@ func (dl *DoublyLinkedList[T]) Add(index int, data T) error {
 	currentNode.Next = newNode
 	newNode.Prev = currentNode
 	dl.nodeCount++
+	dl.modCount++
 	return nil
 }
 
@@ -146,7 +166,7 @@ func (dl *DoublyLinkedList[T]) Get(index int) (T, error) {
 func (dl *DoublyLinkedList[T]) Head() (T, error) {
 	var element T
 	if dl.head == nil {
-		return element, fmt.Errorf("list is empty")
+		return element, fmt.Errorf("cannot get head element: %w", goods.ErrEmpty)
 	}
 	return dl.head.Data, nil
 }
@@ -154,25 +174,50 @@ func (dl *DoublyLinkedList[T]) Head() (T, error) {
 func (dl *DoublyLinkedList[T]) PopHead() (T, error) {
 	var element T
 	if dl.head == nil {
-		return element, fmt.Errorf("list is empty")
+		return element, fmt.Errorf("cannot pop head element: %w", goods.ErrEmpty)
 	}
 	element = dl.head.Data
 	if dl.nodeCount == 1 {
 		dl.head = nil
 		dl.tail = nil
 		dl.nodeCount--
+		dl.modCount++
 	} else {
 		dl.head = dl.head.Next
 		dl.head.Prev = nil
 		dl.nodeCount--
+		dl.modCount++
 	}
 	return element, nil
 }
 
+// PopHeadInto removes up to len(dst) elements from the head, copies them into
+// dst in order and returns how many were removed. The removed nodes are cut off
+// the list in one step.
+// Time Complexity: O(k) where k is the number of elements removed
+// Space Complexity: O(1)
+func (dl *DoublyLinkedList[T]) PopHeadInto(dst []T) int {
+	n := min(len(dst), dl.nodeCount)
+	if n == 0 {
+		return 0
+	}
+	last := copyChain(dst[:n], dl.head)
+	dl.head = last.Next
+	last.Next = nil
+	if dl.head == nil {
+		dl.tail = nil
+	} else {
+		dl.head.Prev = nil
+	}
+	dl.nodeCount -= n
+	dl.modCount++
+	return n
+}
+
 func (dl *DoublyLinkedList[T]) Tail() (T, error) {
 	var element T
 	if dl.tail == nil {
-		return element, fmt.Errorf("list is empty")
+		return element, fmt.Errorf("cannot get tail element: %w", goods.ErrEmpty)
 	}
 	return dl.tail.Data, nil
 }
@@ -180,17 +225,19 @@ func (dl *DoublyLinkedList[T]) Tail() (T, error) {
 func (dl *DoublyLinkedList[T]) PopTail() (T, error) {
 	var element T
 	if dl.tail == nil {
-		return element, fmt.Errorf("list is empty")
+		return element, fmt.Errorf("cannot pop tail element: %w", goods.ErrEmpty)
 	}
 	element = dl.tail.Data
 	if dl.nodeCount == 1 {
 		dl.head = nil
 		dl.tail = nil
 		dl.nodeCount--
+		dl.modCount++
 	} else {
 		dl.tail = dl.tail.Prev
 		dl.tail.Next = nil
 		dl.nodeCount--
+		dl.modCount++
 	}
 	return element, nil
 }
@@ -208,10 +255,12 @@ func (dl *DoublyLinkedList[T]) Delete(index int) error {
 		if dl.head == nil {
 			dl.tail = dl.head
 			dl.nodeCount--
+			dl.modCount++
 			return nil
 		}
 		dl.head.Prev = nil
 		dl.nodeCount--
+		dl.modCount++
 		return nil
 	}
 
@@ -226,6 +275,7 @@ func (dl *DoublyLinkedList[T]) Delete(index int) error {
 		currentNode.Next.Prev = currentNode
 	}
 	dl.nodeCount--
+	dl.modCount++
 	return nil
 }
 
@@ -247,15 +297,71 @@ func (dl *DoublyLinkedList[T]) IsEmpty() bool {
 // Time Complexity: O(1)
 // Space Complexity: O(1)
 func (dl *DoublyLinkedList[T]) Clear() {
+	dl.modCount++
 	dl.head = nil
 	dl.tail = nil
 	dl.nodeCount = 0
 }
 
+// All returns an iterator over index-element pairs from head to tail.
+// Time Complexity: O(n) for a full iteration
+// Space Complexity: O(1)
+func (dl *DoublyLinkedList[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		expectedModCount := dl.modCount
+		index := 0
+		for node := dl.head; node != nil; node = node.Next {
+			if !yield(index, node.Data) {
+				return
+			}
+			dl.checkModCount(expectedModCount)
+			index++
+		}
+	}
+}
+
+// Values returns an iterator over the elements from head to tail.
+// Time Complexity: O(n) for a full iteration
+// Space Complexity: O(1)
+func (dl *DoublyLinkedList[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, element := range dl.All() {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over index-element pairs from tail to head.
+// Time Complexity: O(n) for a full iteration
+// Space Complexity: O(1)
+func (dl *DoublyLinkedList[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		expectedModCount := dl.modCount
+		index := dl.nodeCount - 1
+		for node := dl.tail; node != nil; node = node.Prev {
+			if !yield(index, node.Data) {
+				return
+			}
+			dl.checkModCount(expectedModCount)
+			index--
+		}
+	}
+}
+
 // checkIndexRange validates that index is within [0, nodeCount).
 func (dl *DoublyLinkedList[T]) checkIndexRange(index int) error {
 	if index < 0 || index >= dl.nodeCount {
-		return fmt.Errorf("index %d out of range [0, %d)", index, dl.nodeCount)
+		return goods.NewIndexError(index, 0, dl.nodeCount)
 	}
 	return nil
 }
+
+// checkModCount panics with list.ErrConcurrentModification if the list was
+// structurally modified since an iterator captured expectedModCount.
+func (dl *DoublyLinkedList[T]) checkModCount(expectedModCount int) {
+	if dl.modCount != expectedModCount {
+		panic(list.ErrConcurrentModification)
+	}
+}
@@ -1,6 +1,14 @@
 package linkedlist
 
-import "testing"
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/list"
+	"github.com/Scanf-s/goods/list/listtest"
+)
 
 func TestDoublyLinkedList_New(t *testing.T) {
 	list := NewDoublyLinkedList[int]()
@@ -826,3 +834,171 @@ func TestDoublyLinkedList_PopHead_PopTail_Mixed(t *testing.T) {
 		t.Errorf("Tail = %d, expected 3", tl)
 	}
 }
+
+func TestDoublyLinkedList_All(t *testing.T) {
+	list := NewDoublyLinkedList[int]()
+	if err := list.AppendAll(10, 20, 30); err != nil {
+		t.Errorf("AppendAll failed: %s", err)
+	}
+
+	visited := 0
+	for i, v := range list.All() {
+		if v != (i+1)*10 {
+			t.Errorf("All yielded (%d, %d), expected (%d, %d)", i, v, i, (i+1)*10)
+		}
+		visited++
+	}
+	if visited != 3 {
+		t.Errorf("All visited %d elements, expected 3", visited)
+	}
+}
+
+func TestDoublyLinkedList_All_EarlyBreak(t *testing.T) {
+	list := NewDoublyLinkedList[int]()
+	if err := list.AppendAll(1, 2, 3, 4); err != nil {
+		t.Errorf("AppendAll failed: %s", err)
+	}
+
+	visited := 0
+	for _, v := range list.All() {
+		visited++
+		if v == 2 {
+			break
+		}
+	}
+	if visited != 2 {
+		t.Errorf("All visited %d elements before break, expected 2", visited)
+	}
+}
+
+func TestDoublyLinkedList_Values(t *testing.T) {
+	list := NewDoublyLinkedList[int]()
+	for range list.Values() {
+		t.Error("Values on an empty list should not yield")
+	}
+
+	if err := list.AppendAll(1, 2, 3); err != nil {
+		t.Errorf("AppendAll failed: %s", err)
+	}
+	sum := 0
+	for v := range list.Values() {
+		sum += v
+	}
+	if sum != 6 {
+		t.Errorf("Sum of Values = %d, expected 6", sum)
+	}
+}
+
+func TestDoublyLinkedList_Backward(t *testing.T) {
+	list := NewDoublyLinkedList[int]()
+	if err := list.AppendAll(10, 20, 30); err != nil {
+		t.Errorf("AppendAll failed: %s", err)
+	}
+
+	expectedIndex := 2
+	for i, v := range list.Backward() {
+		if i != expectedIndex || v != (i+1)*10 {
+			t.Errorf("Backward yielded (%d, %d), expected (%d, %d)", i, v, expectedIndex, (expectedIndex+1)*10)
+		}
+		expectedIndex--
+	}
+	if expectedIndex != -1 {
+		t.Errorf("Backward stopped early at index %d", expectedIndex+1)
+	}
+}
+
+func TestDoublyLinkedList_All_ConcurrentModification(t *testing.T) {
+	l := NewDoublyLinkedList[int]()
+	if err := l.AppendAll(1, 2, 3, 4); err != nil {
+		t.Errorf("AppendAll failed: %s", err)
+	}
+
+	defer func() {
+		r := recover()
+		if r != list.ErrConcurrentModification {
+			t.Errorf("expected panic with ErrConcurrentModification, got %v", r)
+		}
+	}()
+	for i := range l.All() {
+		if i == 1 {
+			_ = l.Delete(i)
+		}
+	}
+}
+
+func TestDoublyLinkedList_All_SetDuringIteration(t *testing.T) {
+	l := NewDoublyLinkedList[int]()
+	if err := l.AppendAll(1, 2, 3); err != nil {
+		t.Errorf("AppendAll failed: %s", err)
+	}
+
+	// Set is not a structural modification, so iteration must continue.
+	for i, v := range l.All() {
+		if err := l.Set(i, v*10); err != nil {
+			t.Errorf("Set(%d) failed: %s", i, err)
+		}
+	}
+	for i := range 3 {
+		if val, _ := l.Get(i); val != (i+1)*10 {
+			t.Errorf("Get(%d) = %d, expected %d", i, val, (i+1)*10)
+		}
+	}
+}
+
+func TestDoublyLinkedList_Errors(t *testing.T) {
+	l := NewDoublyLinkedList[int]()
+
+	if _, err := l.Head(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Head on empty list error = %v, expected goods.ErrEmpty", err)
+	}
+	if _, err := l.PopHead(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("PopHead on empty list error = %v, expected goods.ErrEmpty", err)
+	}
+	if _, err := l.Get(0); !errors.Is(err, goods.ErrIndexOutOfRange) {
+		t.Errorf("Get(0) on empty list error = %v, expected goods.ErrIndexOutOfRange", err)
+	}
+
+	var indexErr *goods.IndexError
+	if err := l.Add(2, 1); !errors.As(err, &indexErr) || indexErr.Index != 2 || indexErr.Max != 1 {
+		t.Errorf("Add(2) error = %v, expected IndexError{Index:2 Max:1}", err)
+	}
+}
+
+func TestDoublyLinkedList_ListSuite(t *testing.T) {
+	listtest.RunListSuite(t, func() list.List[int] {
+		return NewDoublyLinkedList[int]()
+	})
+}
+
+func TestDoublyLinkedList_AppendAll_Splice(t *testing.T) {
+	l := NewDoublyLinkedList[int]()
+	_ = l.Append(0)
+	_ = l.AppendAll(1, 2, 3)
+	// Walking backward checks the Prev pointers of the spliced chain
+	var backward []int
+	for _, v := range l.Backward() {
+		backward = append(backward, v)
+	}
+	if !slices.Equal(backward, []int{3, 2, 1, 0}) {
+		t.Errorf("backward = %v, expected [3 2 1 0]", backward)
+	}
+}
+
+func TestDoublyLinkedList_PopHeadInto(t *testing.T) {
+	l := NewDoublyLinkedList[int]()
+	_ = l.AppendAll(0, 1, 2, 3)
+	dst := make([]int, 3)
+	if n := l.PopHeadInto(dst); n != 3 || !slices.Equal(dst, []int{0, 1, 2}) {
+		t.Errorf("PopHeadInto = %v (n = %d), expected [0 1 2]", dst, n)
+	}
+	var backward []int
+	for _, v := range l.Backward() {
+		backward = append(backward, v)
+	}
+	if !slices.Equal(backward, []int{3}) {
+		t.Errorf("backward = %v, expected [3]", backward)
+	}
+	if n := l.PopHeadInto(dst); n != 1 || !l.IsEmpty() {
+		t.Errorf("PopHeadInto = %d with size %d, expected 1 with size 0", n, l.Size())
+	}
+}
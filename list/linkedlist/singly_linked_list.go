@@ -2,6 +2,9 @@ package linkedlist
 
 import (
 	"fmt"
+	"iter"
+
+	"github.com/Scanf-s/goods"
 	"github.com/Scanf-s/goods/list"
 )
 
@@ -15,6 +18,10 @@ type SinglyLinkedList[T any] struct {
 
 	// nodeCount represents the number of nodes currently in the list
 	nodeCount int
+
+	// modCount counts structural modifications (insertions and removals).
+	// Iterators compare it against the value captured at start to fail fast.
+	modCount int
 }
 
 // Compile time interface implementation check
@@ -35,12 +42,14 @@ func (sl *SinglyLinkedList[T]) Append(data T) error {
 		sl.head = list.NewNode(data)
 		sl.tail = sl.head
 		sl.nodeCount++
+		sl.modCount++
 		return nil
 	}
 
 	sl.tail.Next = list.NewNode(data)
 	sl.tail = sl.tail.Next
 	sl.nodeCount++
+	sl.modCount++
 	return nil
 }
 
@@ -52,6 +61,7 @@ func (sl *SinglyLinkedList[T]) Prepend(data T) error {
 		sl.head = list.NewNode(data)
 		sl.tail = sl.head
 		sl.nodeCount++
+		sl.modCount++
 		return nil
 	}
 
@@ -59,18 +69,27 @@ func (sl *SinglyLinkedList[T]) Prepend(data T) error {
 	newNode.Next = sl.head
 	sl.head = newNode
 	sl.nodeCount++
+	sl.modCount++
 	return nil
 }
 
 // AppendAll adds multiple elements to the end of the list.
+// The new nodes are linked into a chain first, then spliced onto the tail at once.
 // Time Complexity: O(k) where k is number of elements
 // Space Complexity: O(k)
 func (sl *SinglyLinkedList[T]) AppendAll(data ...T) error {
-	for _, element := range data {
-		if err := sl.Append(element); err != nil {
-			return err
-		}
+	first, last := newChain(data, false)
+	if first == nil {
+		return nil
 	}
+	if sl.head == nil {
+		sl.head = first
+	} else {
+		sl.tail.Next = first
+	}
+	sl.tail = last
+	sl.nodeCount += len(data)
+	sl.modCount++
 	return nil
 }
 
@@ -79,7 +98,7 @@ func (sl *SinglyLinkedList[T]) AppendAll(data ...T) error {
 // Space Complexity: O(1)
 func (sl *SinglyLinkedList[T]) Add(index int, data T) error {
 	if index < 0 || index > sl.nodeCount {
-		return fmt.Errorf("index %d out of range [0, %d]", index, sl.nodeCount)
+		return goods.NewIndexError(index, 0, sl.nodeCount+1)
 	}
 
 	// If index refers to the head of the sll
@@ -101,6 +120,7 @@ func (sl *SinglyLinkedList[T]) Add(index int, data T) error {
 	node.Next = currentNode.Next
 	currentNode.Next = node
 	sl.nodeCount++
+	sl.modCount++
 	return nil
 }
 
@@ -144,7 +164,7 @@ func (sl *SinglyLinkedList[T]) Get(index int) (T, error) {
 func (sl *SinglyLinkedList[T]) Head() (T, error) {
 	var data T
 	if sl.head == nil {
-		return data, fmt.Errorf("cannot get head element from empty list")
+		return data, fmt.Errorf("cannot get head element: %w", goods.ErrEmpty)
 	}
 	return sl.head.Data, nil
 }
@@ -156,7 +176,7 @@ func (sl *SinglyLinkedList[T]) Head() (T, error) {
 func (sl *SinglyLinkedList[T]) PopHead() (T, error) {
 	var data T
 	if sl.head == nil {
-		return data, fmt.Errorf("cannot pop head element from empty list")
+		return data, fmt.Errorf("cannot pop head element: %w", goods.ErrEmpty)
 	}
 
 	head := sl.head
@@ -167,9 +187,31 @@ func (sl *SinglyLinkedList[T]) PopHead() (T, error) {
 	head.Next = nil
 	data = head.Data
 	sl.nodeCount--
+	sl.modCount++
 	return data, nil
 }
 
+// PopHeadInto removes up to len(dst) elements from the head, copies them into
+// dst in order and returns how many were removed. The removed nodes are cut off
+// the list in one step.
+// Time Complexity: O(k) where k is the number of elements removed
+// Space Complexity: O(1)
+func (sl *SinglyLinkedList[T]) PopHeadInto(dst []T) int {
+	n := min(len(dst), sl.nodeCount)
+	if n == 0 {
+		return 0
+	}
+	last := copyChain(dst[:n], sl.head)
+	sl.head = last.Next
+	last.Next = nil
+	if sl.head == nil {
+		sl.tail = nil
+	}
+	sl.nodeCount -= n
+	sl.modCount++
+	return n
+}
+
 // Delete removes the element at the specified index.
 // Time Complexity: O(n)
 // Space Complexity: O(1)
@@ -184,6 +226,7 @@ func (sl *SinglyLinkedList[T]) Delete(index int) error {
 			sl.tail = sl.head
 		}
 		sl.nodeCount--
+		sl.modCount++
 		return nil
 	}
 
@@ -196,6 +239,7 @@ func (sl *SinglyLinkedList[T]) Delete(index int) error {
 		sl.tail = currentNode
 	}
 	sl.nodeCount--
+	sl.modCount++
 	return nil
 }
 
@@ -217,15 +261,54 @@ func (sl *SinglyLinkedList[T]) IsEmpty() bool {
 // Time Complexity: O(1)
 // Space Complexity: O(1)
 func (sl *SinglyLinkedList[T]) Clear() {
+	sl.modCount++
 	sl.nodeCount = 0
 	sl.tail = nil
 	sl.head = nil
 }
 
+// All returns an iterator over index-element pairs from head to tail.
+// Time Complexity: O(n) for a full iteration
+// Space Complexity: O(1)
+func (sl *SinglyLinkedList[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		expectedModCount := sl.modCount
+		index := 0
+		for node := sl.head; node != nil; node = node.Next {
+			if !yield(index, node.Data) {
+				return
+			}
+			sl.checkModCount(expectedModCount)
+			index++
+		}
+	}
+}
+
+// Values returns an iterator over the elements from head to tail.
+// Time Complexity: O(n) for a full iteration
+// Space Complexity: O(1)
+func (sl *SinglyLinkedList[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, element := range sl.All() {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
 // checkIndexRange validates that index is within [0, nodeCount).
 func (sl *SinglyLinkedList[T]) checkIndexRange(index int) error {
 	if index < 0 || index >= sl.nodeCount {
-		return fmt.Errorf("index %d out of range [0, %d)", index, sl.nodeCount)
+		return goods.NewIndexError(index, 0, sl.nodeCount)
 	}
 	return nil
 }
+
+// checkModCount panics with list.ErrConcurrentModification if the list was
+// structurally modified since an iterator captured expectedModCount.
+func (sl *SinglyLinkedList[T]) checkModCount(expectedModCount int) {
+	if sl.modCount != expectedModCount {
+		panic(list.ErrConcurrentModification)
+	}
+}
@@ -1,6 +1,14 @@
 package linkedlist
 
-import "testing"
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/list"
+	"github.com/Scanf-s/goods/list/listtest"
+)
 
 func TestSinglyLinkedList_New(t *testing.T) {
 	list := NewSinglyLinkedList[int]()
@@ -709,3 +717,161 @@ func TestSinglyLinkedList_WithStructs(t *testing.T) {
 		t.Errorf("Get(0) = %+v, expected Alice/30", val)
 	}
 }
+
+func TestSinglyLinkedList_All(t *testing.T) {
+	list := NewSinglyLinkedList[int]()
+	if err := list.AppendAll(10, 20, 30); err != nil {
+		t.Errorf("AppendAll failed: %s", err)
+	}
+
+	visited := 0
+	for i, v := range list.All() {
+		if v != (i+1)*10 {
+			t.Errorf("All yielded (%d, %d), expected (%d, %d)", i, v, i, (i+1)*10)
+		}
+		visited++
+	}
+	if visited != 3 {
+		t.Errorf("All visited %d elements, expected 3", visited)
+	}
+}
+
+func TestSinglyLinkedList_All_EarlyBreak(t *testing.T) {
+	list := NewSinglyLinkedList[int]()
+	if err := list.AppendAll(1, 2, 3, 4); err != nil {
+		t.Errorf("AppendAll failed: %s", err)
+	}
+
+	visited := 0
+	for _, v := range list.All() {
+		visited++
+		if v == 2 {
+			break
+		}
+	}
+	if visited != 2 {
+		t.Errorf("All visited %d elements before break, expected 2", visited)
+	}
+}
+
+func TestSinglyLinkedList_Values(t *testing.T) {
+	list := NewSinglyLinkedList[int]()
+	for range list.Values() {
+		t.Error("Values on an empty list should not yield")
+	}
+
+	if err := list.AppendAll(1, 2, 3); err != nil {
+		t.Errorf("AppendAll failed: %s", err)
+	}
+	sum := 0
+	for v := range list.Values() {
+		sum += v
+	}
+	if sum != 6 {
+		t.Errorf("Sum of Values = %d, expected 6", sum)
+	}
+}
+
+func TestSinglyLinkedList_All_ConcurrentModification(t *testing.T) {
+	l := NewSinglyLinkedList[int]()
+	if err := l.AppendAll(1, 2, 3, 4); err != nil {
+		t.Errorf("AppendAll failed: %s", err)
+	}
+
+	defer func() {
+		r := recover()
+		if r != list.ErrConcurrentModification {
+			t.Errorf("expected panic with ErrConcurrentModification, got %v", r)
+		}
+	}()
+	for i := range l.All() {
+		if i == 1 {
+			_ = l.Delete(i)
+		}
+	}
+}
+
+func TestSinglyLinkedList_All_SetDuringIteration(t *testing.T) {
+	l := NewSinglyLinkedList[int]()
+	if err := l.AppendAll(1, 2, 3); err != nil {
+		t.Errorf("AppendAll failed: %s", err)
+	}
+
+	// Set is not a structural modification, so iteration must continue.
+	for i, v := range l.All() {
+		if err := l.Set(i, v*10); err != nil {
+			t.Errorf("Set(%d) failed: %s", i, err)
+		}
+	}
+	for i := range 3 {
+		if val, _ := l.Get(i); val != (i+1)*10 {
+			t.Errorf("Get(%d) = %d, expected %d", i, val, (i+1)*10)
+		}
+	}
+}
+
+func TestSinglyLinkedList_Errors(t *testing.T) {
+	l := NewSinglyLinkedList[int]()
+
+	if _, err := l.Head(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Head on empty list error = %v, expected goods.ErrEmpty", err)
+	}
+	if _, err := l.PopHead(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("PopHead on empty list error = %v, expected goods.ErrEmpty", err)
+	}
+	if _, err := l.Get(0); !errors.Is(err, goods.ErrIndexOutOfRange) {
+		t.Errorf("Get(0) on empty list error = %v, expected goods.ErrIndexOutOfRange", err)
+	}
+
+	var indexErr *goods.IndexError
+	if err := l.Add(2, 1); !errors.As(err, &indexErr) || indexErr.Index != 2 || indexErr.Max != 1 {
+		t.Errorf("Add(2) error = %v, expected IndexError{Index:2 Max:1}", err)
+	}
+}
+
+func TestSinglyLinkedList_ListSuite(t *testing.T) {
+	listtest.RunListSuite(t, func() list.List[int] {
+		return NewSinglyLinkedList[int]()
+	})
+}
+
+func TestSinglyLinkedList_AppendAll_Splice(t *testing.T) {
+	l := NewSinglyLinkedList[int]()
+	_ = l.AppendAll()
+	if !l.IsEmpty() {
+		t.Error("AppendAll with no elements should leave the list empty")
+	}
+	_ = l.Append(0)
+	_ = l.AppendAll(1, 2, 3)
+	_ = l.Append(4)
+	if got := slices.Collect(l.Values()); !slices.Equal(got, []int{0, 1, 2, 3, 4}) {
+		t.Errorf("list = %v, expected [0 1 2 3 4]", got)
+	}
+	if tail := l.tail.Data; tail != 4 {
+		t.Errorf("Tail = %d, expected 4", tail)
+	}
+}
+
+func TestSinglyLinkedList_PopHeadInto(t *testing.T) {
+	l := NewSinglyLinkedList[int]()
+	_ = l.AppendAll(0, 1, 2, 3, 4)
+	dst := make([]int, 2)
+	if n := l.PopHeadInto(dst); n != 2 || !slices.Equal(dst, []int{0, 1}) {
+		t.Errorf("PopHeadInto = %v (n = %d), expected [0 1]", dst, n)
+	}
+	if head, _ := l.Head(); head != 2 || l.Size() != 3 {
+		t.Errorf("Head = %d, Size = %d, expected 2 and 3", head, l.Size())
+	}
+	dst = make([]int, 10)
+	if n := l.PopHeadInto(dst); n != 3 || !slices.Equal(dst[:n], []int{2, 3, 4}) {
+		t.Errorf("PopHeadInto = %v (n = %d), expected [2 3 4]", dst[:n], n)
+	}
+	if !l.IsEmpty() {
+		t.Errorf("list should be empty, size = %d", l.Size())
+	}
+	// The list stays usable after being drained
+	_ = l.Append(5)
+	if tail := l.tail.Data; tail != 5 {
+		t.Errorf("Tail after reuse = %d, expected 5", tail)
+	}
+}
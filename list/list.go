@@ -1,5 +1,14 @@
 package list
 
+import (
+	"errors"
+	"iter"
+)
+
+// ErrConcurrentModification is the panic value raised by a list iterator when the
+// list is structurally modified (element added or removed) while it is being iterated.
+var ErrConcurrentModification = errors.New("list was modified during iteration")
+
 type (
 	// Node represents a single node in the linked list
 	Node[T any] struct {
@@ -26,12 +35,14 @@ type (
 
 		// Add inserts an element at the specific index in the list
 		// It pushes all elements after the specific index to the right
+		// An index outside [0, Size()] returns a *goods.IndexError
 		Add(index int, element T) error
 
 		// Set replaces the element at the specific index in the list with newElement
 		Set(index int, newElement T) error
 
 		// Get returns the element at the specific index in the list
+		// An index outside [0, Size()) returns a *goods.IndexError
 		Get(index int) (T, error)
 
 		// Delete removes the element at the specific index in the list
@@ -45,6 +56,14 @@ type (
 
 		// Clear removes all elements from the list
 		Clear()
+
+		// All returns an iterator over index-element pairs from head to tail.
+		// The iterator is fail-fast: it panics with ErrConcurrentModification if the
+		// list is structurally modified (Add, Delete, Clear, ...) during iteration.
+		All() iter.Seq2[int, T]
+
+		// Values returns an iterator over the elements from head to tail.
+		Values() iter.Seq[T]
 	}
 )
 
@@ -0,0 +1,243 @@
+// Package listtest provides a conformance suite for list.List implementations.
+//
+// Call RunListSuite from an ordinary test to validate any implementation:
+//
+//	func TestMyList(t *testing.T) {
+//		listtest.RunListSuite(t, func() list.List[int] { return NewMyList[int]() })
+//	}
+package listtest
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/list"
+)
+
+// RunListSuite checks that the lists returned by newList honor the list.List
+// contract: positional insertion and removal, index bounds reported as
+// *goods.IndexError, iterators that agree with Get, and Clear. newList must
+// return a new, empty list on every call.
+func RunListSuite(t *testing.T, newList func() list.List[int]) {
+	t.Helper()
+
+	t.Run("Empty", func(t *testing.T) { testEmpty(t, newList()) })
+	t.Run("AppendPrepend", func(t *testing.T) { testAppendPrepend(t, newList()) })
+	t.Run("AddSetDelete", func(t *testing.T) { testAddSetDelete(t, newList()) })
+	t.Run("IndexBounds", func(t *testing.T) { testIndexBounds(t, newList()) })
+	t.Run("Iterators", func(t *testing.T) { testIterators(t, newList()) })
+	t.Run("Clear", func(t *testing.T) { testClear(t, newList()) })
+	t.Run("RandomizedModel", func(t *testing.T) { testRandomizedModel(t, newList()) })
+}
+
+// contents reads every element through Get, independently of the iterators.
+func contents(t *testing.T, l list.List[int]) []int {
+	t.Helper()
+	result := make([]int, 0, l.Size())
+	for i := range l.Size() {
+		val, err := l.Get(i)
+		if err != nil {
+			t.Fatalf("Get(%d) failed: %s", i, err)
+		}
+		result = append(result, val)
+	}
+	return result
+}
+
+func expectContents(t *testing.T, l list.List[int], expected []int) {
+	t.Helper()
+	if l.Size() != len(expected) {
+		t.Fatalf("Size = %d, expected %d", l.Size(), len(expected))
+	}
+	if got := contents(t, l); !slices.Equal(got, expected) {
+		t.Fatalf("list = %v, expected %v", got, expected)
+	}
+}
+
+func testEmpty(t *testing.T, l list.List[int]) {
+	if !l.IsEmpty() || l.Size() != 0 {
+		t.Errorf("a new list should be empty, size = %d", l.Size())
+	}
+	if _, err := l.Get(0); !errors.Is(err, goods.ErrIndexOutOfRange) {
+		t.Errorf("Get(0) on empty list error = %v, expected goods.ErrIndexOutOfRange", err)
+	}
+	if err := l.Delete(0); !errors.Is(err, goods.ErrIndexOutOfRange) {
+		t.Errorf("Delete(0) on empty list error = %v, expected goods.ErrIndexOutOfRange", err)
+	}
+	for range l.All() {
+		t.Error("All on an empty list should not yield")
+	}
+}
+
+func testAppendPrepend(t *testing.T, l list.List[int]) {
+	if err := l.Append(2); err != nil {
+		t.Fatalf("Append failed: %s", err)
+	}
+	if err := l.Prepend(1); err != nil {
+		t.Fatalf("Prepend failed: %s", err)
+	}
+	if err := l.AppendAll(3, 4, 5); err != nil {
+		t.Fatalf("AppendAll failed: %s", err)
+	}
+	if err := l.AppendAll(); err != nil {
+		t.Fatalf("AppendAll with no elements failed: %s", err)
+	}
+	expectContents(t, l, []int{1, 2, 3, 4, 5})
+}
+
+func testAddSetDelete(t *testing.T, l list.List[int]) {
+	// Add at the head, the tail and the middle
+	for _, step := range []struct{ index, value int }{{0, 2}, {0, 0}, {2, 4}, {1, 1}, {3, 3}} {
+		if err := l.Add(step.index, step.value); err != nil {
+			t.Fatalf("Add(%d, %d) failed: %s", step.index, step.value, err)
+		}
+	}
+	expectContents(t, l, []int{0, 1, 2, 3, 4})
+
+	if err := l.Set(2, 20); err != nil {
+		t.Fatalf("Set(2) failed: %s", err)
+	}
+	expectContents(t, l, []int{0, 1, 20, 3, 4})
+
+	// Delete the middle, the tail and the head
+	for _, index := range []int{2, 3, 0} {
+		if err := l.Delete(index); err != nil {
+			t.Fatalf("Delete(%d) failed: %s", index, err)
+		}
+	}
+	expectContents(t, l, []int{1, 3})
+
+	// The list must still accept appends after its tail was deleted
+	if err := l.Append(5); err != nil {
+		t.Fatalf("Append after Delete failed: %s", err)
+	}
+	expectContents(t, l, []int{1, 3, 5})
+}
+
+func testIndexBounds(t *testing.T, l list.List[int]) {
+	if err := l.AppendAll(1, 2, 3); err != nil {
+		t.Fatalf("AppendAll failed: %s", err)
+	}
+
+	var indexErr *goods.IndexError
+	for _, index := range []int{-1, 3, 100} {
+		if _, err := l.Get(index); !errors.As(err, &indexErr) || indexErr.Index != index || indexErr.Max != 3 {
+			t.Errorf("Get(%d) error = %v, expected IndexError{Index:%d Max:3}", index, err, index)
+		}
+		if err := l.Set(index, 0); !errors.Is(err, goods.ErrIndexOutOfRange) {
+			t.Errorf("Set(%d) error = %v, expected goods.ErrIndexOutOfRange", index, err)
+		}
+		if err := l.Delete(index); !errors.Is(err, goods.ErrIndexOutOfRange) {
+			t.Errorf("Delete(%d) error = %v, expected goods.ErrIndexOutOfRange", index, err)
+		}
+	}
+	// Add accepts [0, Size()], so only -1 and Size()+1 are rejected
+	for _, index := range []int{-1, 4} {
+		if err := l.Add(index, 0); !errors.As(err, &indexErr) || indexErr.Max != 4 {
+			t.Errorf("Add(%d) error = %v, expected IndexError{Max:4}", index, err)
+		}
+	}
+	expectContents(t, l, []int{1, 2, 3})
+}
+
+func testIterators(t *testing.T, l list.List[int]) {
+	if err := l.AppendAll(10, 20, 30, 40); err != nil {
+		t.Fatalf("AppendAll failed: %s", err)
+	}
+	expected := contents(t, l)
+
+	var fromAll []int
+	for i, v := range l.All() {
+		if i != len(fromAll) {
+			t.Errorf("All yielded index %d, expected %d", i, len(fromAll))
+		}
+		fromAll = append(fromAll, v)
+	}
+	if !slices.Equal(fromAll, expected) {
+		t.Errorf("All = %v, expected %v", fromAll, expected)
+	}
+	if fromValues := slices.Collect(l.Values()); !slices.Equal(fromValues, expected) {
+		t.Errorf("Values = %v, expected %v", fromValues, expected)
+	}
+
+	visited := 0
+	for range l.Values() {
+		visited++
+		if visited == 2 {
+			break
+		}
+	}
+	if visited != 2 {
+		t.Errorf("Values visited %d elements before break, expected 2", visited)
+	}
+}
+
+func testClear(t *testing.T, l list.List[int]) {
+	if err := l.AppendAll(1, 2, 3); err != nil {
+		t.Fatalf("AppendAll failed: %s", err)
+	}
+	l.Clear()
+	if !l.IsEmpty() || l.Size() != 0 {
+		t.Fatalf("list should be empty after Clear, size = %d", l.Size())
+	}
+	if err := l.AppendAll(4, 5); err != nil {
+		t.Fatalf("AppendAll after Clear failed: %s", err)
+	}
+	expectContents(t, l, []int{4, 5})
+}
+
+// testRandomizedModel applies a random mix of operations to l and to a plain
+// slice, comparing the full contents after every step.
+func testRandomizedModel(t *testing.T, l list.List[int]) {
+	rng := rand.New(rand.NewPCG(3, 4))
+	var model []int
+	for step := range 500 {
+		v := rng.IntN(1000)
+		switch op := rng.IntN(6); op {
+		case 0:
+			if err := l.Append(v); err != nil {
+				t.Fatalf("step %d: Append failed: %s", step, err)
+			}
+			model = append(model, v)
+		case 1:
+			if err := l.Prepend(v); err != nil {
+				t.Fatalf("step %d: Prepend failed: %s", step, err)
+			}
+			model = slices.Insert(model, 0, v)
+		case 2:
+			index := rng.IntN(len(model) + 1)
+			if err := l.Add(index, v); err != nil {
+				t.Fatalf("step %d: Add(%d) failed: %s", step, index, err)
+			}
+			model = slices.Insert(model, index, v)
+		case 3, 4:
+			if len(model) == 0 {
+				continue
+			}
+			index := rng.IntN(len(model))
+			if op == 3 {
+				if err := l.Delete(index); err != nil {
+					t.Fatalf("step %d: Delete(%d) failed: %s", step, index, err)
+				}
+				model = slices.Delete(model, index, index+1)
+			} else {
+				if err := l.Set(index, v); err != nil {
+					t.Fatalf("step %d: Set(%d) failed: %s", step, index, err)
+				}
+				model[index] = v
+			}
+		case 5:
+			if rng.IntN(20) == 0 {
+				l.Clear()
+				model = model[:0]
+			}
+		}
+		if got := slices.Collect(l.Values()); !slices.Equal(got, model) {
+			t.Fatalf("step %d: list = %v, expected %v", step, got, model)
+		}
+	}
+	expectContents(t, l, model)
+}
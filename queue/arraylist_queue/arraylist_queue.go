@@ -2,6 +2,8 @@ package arraylistqueue
 
 import (
 	"fmt"
+
+	"github.com/Scanf-s/goods"
 	"github.com/Scanf-s/goods/list/arraylist"
 	"github.com/Scanf-s/goods/queue"
 )
@@ -11,14 +13,14 @@ import (
 // Because it reuses ArrayList (a positional list anchored at index 0), removing
 // the front element means deleting index 0, which shifts every remaining element
 // left. That makes Poll O(n): the queue inherits the cost model of its substrate.
-// For an O(1) array-backed queue, see CircularQueue, which uses a ring buffer with
-// head/tail indices over a raw slice instead of a list.
+// For an O(1) array-backed queue, see ringqueue.RingQueue, which uses a ring buffer
+// with head/tail indices over a raw slice instead of a list.
 type ArrayListQueue[T any] struct {
 	list *arraylist.ArrayList[T]
 }
 
-// Compile-time check that ArrayListQueue implements the queue.Queue interface.
-var _ queue.Queue[int] = (*ArrayListQueue[int])(nil)
+// Compile-time check that ArrayListQueue implements the queue.BatchQueue interface.
+var _ queue.BatchQueue[int] = (*ArrayListQueue[int])(nil)
 
 // NewArrayListQueue initializes the queue that uses array(dynamic) list for its base data storage.
 // The size argument is an initial capacity hint forwarded to the backing ArrayList.
@@ -32,10 +34,10 @@ func NewArrayListQueue[T any](size int) *ArrayListQueue[T] {
 // Time Complexity: O(1) amortized (O(n) when the backing array resizes)
 func (alq *ArrayListQueue[T]) Offer(value T) error {
 	if alq == nil {
-		return fmt.Errorf("queue is nil")
+		return fmt.Errorf("queue is nil: %w", goods.ErrNotInitialized)
 	}
 	if err := alq.list.Append(value); err != nil {
-		return fmt.Errorf("failed to offer an element into the queue")
+		return fmt.Errorf("failed to offer an element into the queue: %w", err)
 	}
 	return nil
 }
@@ -46,11 +48,11 @@ func (alq *ArrayListQueue[T]) Offer(value T) error {
 func (alq *ArrayListQueue[T]) Peek() (T, error) {
 	var defaultVal T
 	if alq == nil {
-		return defaultVal, fmt.Errorf("queue is nil")
+		return defaultVal, fmt.Errorf("queue is nil: %w", goods.ErrNotInitialized)
 	}
 	value, err := alq.list.Get(0)
 	if err != nil {
-		return defaultVal, fmt.Errorf("cannot peek front element from the queue")
+		return defaultVal, fmt.Errorf("cannot peek front element from the queue: %w", goods.ErrEmpty)
 	}
 	return value, nil
 }
@@ -64,19 +66,63 @@ func (alq *ArrayListQueue[T]) Peek() (T, error) {
 func (alq *ArrayListQueue[T]) Poll() (T, error) {
 	var defaultVal T
 	if alq == nil {
-		return defaultVal, fmt.Errorf("queue is nil")
+		return defaultVal, fmt.Errorf("queue is nil: %w", goods.ErrNotInitialized)
 	}
 
 	value, err := alq.list.Get(0)
 	if err != nil {
-		return defaultVal, fmt.Errorf("cannot poll front element from the queue")
+		return defaultVal, fmt.Errorf("cannot poll front element from the queue: %w", goods.ErrEmpty)
 	}
 	if err := alq.list.Delete(0); err != nil {
-		return defaultVal, fmt.Errorf("failed to remove front element from the queue")
+		return defaultVal, fmt.Errorf("failed to remove front element from the queue: %w", err)
 	}
 	return value, nil
 }
 
+// OfferAll adds the elements to the back of the queue in order, growing the
+// backing ArrayList at most once per doubling.
+// Time Complexity: O(k) amortized where k is the number of elements
+func (alq *ArrayListQueue[T]) OfferAll(elements ...T) error {
+	if alq == nil {
+		return fmt.Errorf("queue is nil: %w", goods.ErrNotInitialized)
+	}
+	if err := alq.list.AppendAll(elements...); err != nil {
+		return fmt.Errorf("failed to offer elements into the queue: %w", err)
+	}
+	return nil
+}
+
+// PollN removes up to n elements from the front and returns them in FIFO order.
+// It returns nil if the queue is empty or n is not positive.
+// Time Complexity: O(n) for the single shift of the remaining elements
+func (alq *ArrayListQueue[T]) PollN(n int) []T {
+	if alq == nil || n <= 0 || alq.list.IsEmpty() {
+		return nil
+	}
+	result := make([]T, min(n, alq.list.Size()))
+	alq.DrainTo(result)
+	return result
+}
+
+// DrainTo removes up to len(dst) elements from the front, copies them into dst
+// in FIFO order and returns how many were copied. The elements are copied out
+// in bulk, and unlike repeated Poll calls the remaining elements are shifted
+// left only once.
+// Time Complexity: O(n)
+func (alq *ArrayListQueue[T]) DrainTo(dst []T) int {
+	if alq == nil {
+		return 0
+	}
+	n := min(len(dst), alq.list.Size())
+	if n == 0 {
+		return 0
+	}
+	// The range is always valid, so neither call can fail here
+	_, _ = alq.list.CopyRange(dst, 0, n)
+	_ = alq.list.DeleteRange(0, n)
+	return n
+}
+
 // Size returns the number of elements in the queue.
 // Time Complexity: O(1)
 func (alq *ArrayListQueue[T]) Size() int {
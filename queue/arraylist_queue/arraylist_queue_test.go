@@ -1,6 +1,13 @@
 package arraylistqueue
 
-import "testing"
+import (
+	"errors"
+	"testing"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/queue"
+	"github.com/Scanf-s/goods/queue/queuetest"
+)
 
 func TestArrayListQueue_New(t *testing.T) {
 	queue := NewArrayListQueue[int](0)
@@ -111,3 +118,34 @@ func TestArrayListQueue_WithStrings(t *testing.T) {
 		t.Errorf("Poll = %q, expected \"b\"", val)
 	}
 }
+
+func TestArrayListQueue_Errors(t *testing.T) {
+	q := NewArrayListQueue[int](0)
+
+	if _, err := q.Peek(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Peek on empty queue error = %v, expected goods.ErrEmpty", err)
+	}
+	if _, err := q.Poll(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Poll on empty queue error = %v, expected goods.ErrEmpty", err)
+	}
+
+	var nilQueue *ArrayListQueue[int]
+	if err := nilQueue.Offer(1); !errors.Is(err, goods.ErrNotInitialized) {
+		t.Errorf("Offer on uninitialized queue error = %v, expected goods.ErrNotInitialized", err)
+	}
+	if _, err := nilQueue.Poll(); !errors.Is(err, goods.ErrNotInitialized) {
+		t.Errorf("Poll on uninitialized queue error = %v, expected goods.ErrNotInitialized", err)
+	}
+}
+
+func TestArrayListQueue_QueueSuite(t *testing.T) {
+	queuetest.RunQueueSuite(t, func() queue.Queue[int] {
+		return NewArrayListQueue[int](0)
+	})
+}
+
+func TestArrayListQueue_BatchQueueSuite(t *testing.T) {
+	queuetest.RunBatchQueueSuite(t, func() queue.BatchQueue[int] {
+		return NewArrayListQueue[int](0)
+	})
+}
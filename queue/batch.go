@@ -0,0 +1,54 @@
+package queue
+
+// OfferAll adds the elements to the back of q in order, in one call if q is a
+// BatchQueue. Otherwise it offers them one by one and stops at the first error,
+// leaving the elements before it queued.
+func OfferAll[T any](q Queue[T], elements ...T) error {
+	if bq, ok := q.(BatchQueue[T]); ok {
+		return bq.OfferAll(elements...)
+	}
+	for _, element := range elements {
+		if err := q.Offer(element); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PollN removes up to n elements from the front of q and returns them in FIFO
+// order, in one call if q is a BatchQueue. It returns nil if q is empty or n is
+// not positive.
+func PollN[T any](q Queue[T], n int) []T {
+	if bq, ok := q.(BatchQueue[T]); ok {
+		return bq.PollN(n)
+	}
+	if n <= 0 || q.IsEmpty() {
+		return nil
+	}
+	result := make([]T, 0, min(n, q.Size()))
+	for range n {
+		element, err := q.Poll()
+		if err != nil {
+			break
+		}
+		result = append(result, element)
+	}
+	return result
+}
+
+// DrainTo removes up to len(dst) elements from the front of q, copies them into
+// dst in FIFO order and returns how many were copied, in one call if q is a
+// BatchQueue.
+func DrainTo[T any](q Queue[T], dst []T) int {
+	if bq, ok := q.(BatchQueue[T]); ok {
+		return bq.DrainTo(dst)
+	}
+	for i := range dst {
+		element, err := q.Poll()
+		if err != nil {
+			return i
+		}
+		dst[i] = element
+	}
+	return len(dst)
+}
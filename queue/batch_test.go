@@ -0,0 +1,39 @@
+package queue_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Scanf-s/goods/queue"
+	priorityqueue "github.com/Scanf-s/goods/queue/priority_queue"
+	ringqueue "github.com/Scanf-s/goods/queue/ring_queue"
+)
+
+func TestBatchHelpers(t *testing.T) {
+	testCases := []struct {
+		name string
+		q    queue.Queue[int]
+	}{
+		// RingQueue implements BatchQueue; PriorityQueue only Queue, so the
+		// helpers fall back to Offer and Poll for it
+		{"BatchQueue", ringqueue.NewRingQueue[int](0)},
+		{"Fallback", priorityqueue.NewPriorityQueue(func(a, b int) int { return a - b })},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := queue.OfferAll(tc.q, 0, 1, 2, 3, 4); err != nil {
+				t.Fatal(err)
+			}
+			if got := queue.PollN(tc.q, 2); !slices.Equal(got, []int{0, 1}) {
+				t.Errorf("PollN(2) = %v, expected [0 1]", got)
+			}
+			dst := make([]int, 5)
+			if n := queue.DrainTo(tc.q, dst); n != 3 || !slices.Equal(dst[:n], []int{2, 3, 4}) {
+				t.Errorf("DrainTo = %v (n = %d), expected [2 3 4]", dst[:n], n)
+			}
+			if got := queue.PollN(tc.q, 1); got != nil {
+				t.Errorf("PollN on empty queue = %v, expected nil", got)
+			}
+		})
+	}
+}
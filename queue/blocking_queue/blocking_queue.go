@@ -0,0 +1,308 @@
+// Package blockingqueue implements a bounded FIFO queue that is safe for
+// concurrent use and can block producers and consumers.
+package blockingqueue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/queue"
+	ringqueue "github.com/Scanf-s/goods/queue/ring_queue"
+)
+
+// BlockingQueue is a fixed-capacity FIFO queue guarded by a mutex and backed by
+// a ringqueue.RingQueue.
+//
+// Put blocks while the queue is full and Take blocks while it is empty; both
+// give up when their context is cancelled. A sync.Cond cannot be combined with a
+// context, so waiters instead block on a signal channel that is closed (and
+// replaced) whenever the state they are waiting for may have changed.
+//
+// After Close, no more elements can be added, but the elements already queued
+// can still be taken. Take returns goods.ErrClosed once the queue is both closed
+// and empty.
+type BlockingQueue[T any] struct {
+	// mu guards every field below
+	mu sync.Mutex
+
+	// queue stores the elements; it is never allowed to grow past capacity
+	queue *ringqueue.RingQueue[T]
+
+	// capacity is the maximum number of elements the queue holds
+	capacity int
+
+	// closed is set by Close
+	closed bool
+
+	// notEmpty is closed and replaced each time an element is added or the queue is closed
+	notEmpty chan struct{}
+
+	// notFull is closed and replaced each time an element is removed or the queue is closed
+	notFull chan struct{}
+}
+
+// Compile-time check that BlockingQueue implements the queue.BatchQueue interface.
+var _ queue.BatchQueue[int] = (*BlockingQueue[int])(nil)
+
+// NewBlockingQueue returns an empty BlockingQueue holding at most capacity elements.
+// Returns an error wrapping goods.ErrCapacity if capacity is not positive.
+// Time Complexity: O(capacity)
+func NewBlockingQueue[T any](capacity int) (*BlockingQueue[T], error) {
+	if capacity <= 0 {
+		return nil, fmt.Errorf("capacity must be positive, got %d: %w", capacity, goods.ErrCapacity)
+	}
+	return &BlockingQueue[T]{
+		queue:    ringqueue.NewRingQueue[T](capacity),
+		capacity: capacity,
+		notEmpty: make(chan struct{}),
+		notFull:  make(chan struct{}),
+	}, nil
+}
+
+// Put adds an element to the back of the queue, waiting for space if it is full.
+// Returns an error wrapping goods.ErrClosed if the queue is closed before the
+// element is added, or ctx.Err() if ctx is done first.
+func (bq *BlockingQueue[T]) Put(ctx context.Context, value T) error {
+	if bq == nil || bq.queue == nil {
+		return fmt.Errorf("please call NewBlockingQueue() first: %w", goods.ErrNotInitialized)
+	}
+	for {
+		bq.mu.Lock()
+		if bq.closed {
+			bq.mu.Unlock()
+			return fmt.Errorf("cannot put element: %w", goods.ErrClosed)
+		}
+		if bq.queue.Size() < bq.capacity {
+			bq.offerLocked(value)
+			bq.mu.Unlock()
+			return nil
+		}
+		wait := bq.notFull
+		bq.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-wait:
+		}
+	}
+}
+
+// Take removes and returns the front element, waiting for one if the queue is empty.
+// Returns an error wrapping goods.ErrClosed if the queue is closed and empty,
+// or ctx.Err() if ctx is done first.
+func (bq *BlockingQueue[T]) Take(ctx context.Context) (T, error) {
+	var element T
+	if bq == nil || bq.queue == nil {
+		return element, fmt.Errorf("please call NewBlockingQueue() first: %w", goods.ErrNotInitialized)
+	}
+	for {
+		bq.mu.Lock()
+		if !bq.queue.IsEmpty() {
+			element = bq.pollLocked()
+			bq.mu.Unlock()
+			return element, nil
+		}
+		if bq.closed {
+			bq.mu.Unlock()
+			return element, fmt.Errorf("cannot take element: %w", goods.ErrClosed)
+		}
+		wait := bq.notEmpty
+		bq.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return element, ctx.Err()
+		case <-wait:
+		}
+	}
+}
+
+// TryOffer adds an element if there is room, without blocking.
+// It reports whether the element was added.
+func (bq *BlockingQueue[T]) TryOffer(value T) bool {
+	return bq.Offer(value) == nil
+}
+
+// TryPoll removes and returns the front element if there is one, without blocking.
+// The boolean reports whether an element was returned.
+func (bq *BlockingQueue[T]) TryPoll() (T, bool) {
+	element, err := bq.Poll()
+	return element, err == nil
+}
+
+// Offer adds an element without blocking.
+// Returns an error wrapping goods.ErrFull if the queue is full, or
+// goods.ErrClosed if it is closed.
+func (bq *BlockingQueue[T]) Offer(value T) error {
+	if bq == nil || bq.queue == nil {
+		return fmt.Errorf("please call NewBlockingQueue() first: %w", goods.ErrNotInitialized)
+	}
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	if bq.closed {
+		return fmt.Errorf("cannot offer element: %w", goods.ErrClosed)
+	}
+	if bq.queue.Size() >= bq.capacity {
+		return fmt.Errorf("cannot offer element: %w", goods.ErrFull)
+	}
+	bq.offerLocked(value)
+	return nil
+}
+
+// Poll removes and returns the front element without blocking.
+// Returns an error wrapping goods.ErrEmpty if the queue is empty.
+func (bq *BlockingQueue[T]) Poll() (T, error) {
+	var element T
+	if bq == nil || bq.queue == nil {
+		return element, fmt.Errorf("please call NewBlockingQueue() first: %w", goods.ErrNotInitialized)
+	}
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	if bq.queue.IsEmpty() {
+		return element, fmt.Errorf("cannot poll front element from the queue: %w", goods.ErrEmpty)
+	}
+	return bq.pollLocked(), nil
+}
+
+// Peek returns the front element without removing it or blocking.
+// Returns an error wrapping goods.ErrEmpty if the queue is empty.
+func (bq *BlockingQueue[T]) Peek() (T, error) {
+	var element T
+	if bq == nil || bq.queue == nil {
+		return element, fmt.Errorf("please call NewBlockingQueue() first: %w", goods.ErrNotInitialized)
+	}
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	return bq.queue.Peek()
+}
+
+// OfferAll adds the elements to the back of the queue without blocking, either
+// all of them or, if there is not enough room, none.
+// Returns an error wrapping goods.ErrFull if the elements do not all fit, or
+// goods.ErrClosed if the queue is closed.
+// Time Complexity: O(k) where k is the number of elements
+func (bq *BlockingQueue[T]) OfferAll(elements ...T) error {
+	if bq == nil || bq.queue == nil {
+		return fmt.Errorf("please call NewBlockingQueue() first: %w", goods.ErrNotInitialized)
+	}
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	if bq.closed {
+		return fmt.Errorf("cannot offer elements: %w", goods.ErrClosed)
+	}
+	if free := bq.capacity - bq.queue.Size(); len(elements) > free {
+		return fmt.Errorf("cannot offer %d elements with room for %d: %w", len(elements), free, goods.ErrFull)
+	}
+	if len(elements) == 0 {
+		return nil
+	}
+	// The ring queue never has to grow because the elements fit in capacity
+	_ = bq.queue.OfferAll(elements...)
+	signal(&bq.notEmpty)
+	return nil
+}
+
+// PollN removes up to n elements from the front without blocking and returns
+// them in FIFO order. It returns nil if the queue is empty or n is not positive.
+// Time Complexity: O(k) where k is the number of elements returned
+func (bq *BlockingQueue[T]) PollN(n int) []T {
+	if bq == nil || bq.queue == nil {
+		return nil
+	}
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	result := bq.queue.PollN(n)
+	if len(result) > 0 {
+		signal(&bq.notFull)
+	}
+	return result
+}
+
+// DrainTo removes up to len(dst) elements from the front without blocking,
+// copies them into dst in FIFO order and returns how many were copied.
+// Time Complexity: O(k) where k is the number of elements copied
+func (bq *BlockingQueue[T]) DrainTo(dst []T) int {
+	if bq == nil || bq.queue == nil {
+		return 0
+	}
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	n := bq.queue.DrainTo(dst)
+	if n > 0 {
+		signal(&bq.notFull)
+	}
+	return n
+}
+
+// Close stops the queue from accepting elements and wakes every blocked caller.
+// Elements already queued can still be taken. Calling Close more than once is a no-op.
+func (bq *BlockingQueue[T]) Close() {
+	if bq == nil || bq.queue == nil {
+		return
+	}
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	if bq.closed {
+		return
+	}
+	bq.closed = true
+	signal(&bq.notEmpty)
+	signal(&bq.notFull)
+}
+
+// IsClosed reports whether Close was called.
+func (bq *BlockingQueue[T]) IsClosed() bool {
+	if bq == nil || bq.queue == nil {
+		return false
+	}
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	return bq.closed
+}
+
+// Size returns the number of elements in the queue.
+func (bq *BlockingQueue[T]) Size() int {
+	if bq == nil || bq.queue == nil {
+		return 0
+	}
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	return bq.queue.Size()
+}
+
+// IsEmpty returns true if the queue has no elements.
+func (bq *BlockingQueue[T]) IsEmpty() bool {
+	return bq.Size() == 0
+}
+
+// Capacity returns the maximum number of elements the queue holds.
+func (bq *BlockingQueue[T]) Capacity() int {
+	if bq == nil {
+		return 0
+	}
+	return bq.capacity
+}
+
+// offerLocked appends value and wakes waiting takers. bq.mu must be held.
+func (bq *BlockingQueue[T]) offerLocked(value T) {
+	// The ring queue never has to grow because Size() < capacity here
+	_ = bq.queue.Offer(value)
+	signal(&bq.notEmpty)
+}
+
+// pollLocked removes the front element and wakes waiting putters. bq.mu must be held.
+func (bq *BlockingQueue[T]) pollLocked() T {
+	element, _ := bq.queue.Poll()
+	signal(&bq.notFull)
+	return element
+}
+
+// signal wakes every goroutine waiting on *ch by closing it, then installs a
+// fresh channel for the next round of waiters.
+func signal(ch *chan struct{}) {
+	close(*ch)
+	*ch = make(chan struct{})
+}
@@ -0,0 +1,222 @@
+package blockingqueue
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/queue"
+	"github.com/Scanf-s/goods/queue/queuetest"
+)
+
+func TestBlockingQueue_QueueSuite(t *testing.T) {
+	queuetest.RunQueueSuite(t, func() queue.Queue[int] {
+		bq, err := NewBlockingQueue[int](4096)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return bq
+	})
+}
+
+func TestBlockingQueue_BatchQueueSuite(t *testing.T) {
+	queuetest.RunBatchQueueSuite(t, func() queue.BatchQueue[int] {
+		bq, err := NewBlockingQueue[int](4096)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return bq
+	})
+}
+
+func TestBlockingQueue_InvalidCapacity(t *testing.T) {
+	if _, err := NewBlockingQueue[int](0); !errors.Is(err, goods.ErrCapacity) {
+		t.Errorf("NewBlockingQueue(0) error = %v, expected goods.ErrCapacity", err)
+	}
+}
+
+func TestBlockingQueue_TryOfferTryPoll(t *testing.T) {
+	bq, _ := NewBlockingQueue[int](2)
+	if !bq.TryOffer(1) || !bq.TryOffer(2) {
+		t.Fatal("TryOffer should succeed while there is room")
+	}
+	if bq.TryOffer(3) {
+		t.Error("TryOffer should fail on a full queue")
+	}
+	if err := bq.Offer(3); !errors.Is(err, goods.ErrFull) {
+		t.Errorf("Offer on full queue error = %v, expected goods.ErrFull", err)
+	}
+	if v, ok := bq.TryPoll(); !ok || v != 1 {
+		t.Errorf("TryPoll = (%d, %v), expected (1, true)", v, ok)
+	}
+	_, _ = bq.TryPoll()
+	if _, ok := bq.TryPoll(); ok {
+		t.Error("TryPoll should fail on an empty queue")
+	}
+}
+
+func TestBlockingQueue_PutBlocksUntilSpace(t *testing.T) {
+	bq, _ := NewBlockingQueue[int](1)
+	_ = bq.Put(context.Background(), 1)
+
+	done := make(chan error)
+	go func() { done <- bq.Put(context.Background(), 2) }()
+
+	select {
+	case <-done:
+		t.Fatal("Put on a full queue should block")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	if v, err := bq.Take(context.Background()); err != nil || v != 1 {
+		t.Fatalf("Take = %d (err %v), expected 1", v, err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("blocked Put failed: %s", err)
+	}
+	if v, _ := bq.Peek(); v != 2 {
+		t.Errorf("Peek = %d, expected 2", v)
+	}
+}
+
+func TestBlockingQueue_ContextCancellation(t *testing.T) {
+	bq, _ := NewBlockingQueue[int](1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := bq.Take(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Take on empty queue error = %v, expected context.DeadlineExceeded", err)
+	}
+
+	_ = bq.Put(context.Background(), 1)
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if err := bq.Put(ctx, 2); !errors.Is(err, context.Canceled) {
+		t.Errorf("Put on full queue error = %v, expected context.Canceled", err)
+	}
+	if bq.Size() != 1 {
+		t.Errorf("Size = %d, expected 1", bq.Size())
+	}
+}
+
+func TestBlockingQueue_Close(t *testing.T) {
+	bq, _ := NewBlockingQueue[int](4)
+	_ = bq.Put(context.Background(), 1)
+	_ = bq.Put(context.Background(), 2)
+
+	// A taker blocked on another queue must wake up on Close
+	empty, _ := NewBlockingQueue[int](1)
+	done := make(chan error)
+	go func() {
+		_, err := empty.Take(context.Background())
+		done <- err
+	}()
+	empty.Close()
+	if err := <-done; !errors.Is(err, goods.ErrClosed) {
+		t.Errorf("blocked Take after Close error = %v, expected goods.ErrClosed", err)
+	}
+
+	bq.Close()
+	bq.Close()
+	if !bq.IsClosed() {
+		t.Error("IsClosed should be true after Close")
+	}
+	if err := bq.Put(context.Background(), 3); !errors.Is(err, goods.ErrClosed) {
+		t.Errorf("Put after Close error = %v, expected goods.ErrClosed", err)
+	}
+	if bq.TryOffer(3) {
+		t.Error("TryOffer after Close should fail")
+	}
+
+	// Remaining elements drain before Take reports the close
+	for _, expected := range []int{1, 2} {
+		if v, err := bq.Take(context.Background()); err != nil || v != expected {
+			t.Errorf("Take after Close = %d (err %v), expected %d", v, err, expected)
+		}
+	}
+	if _, err := bq.Take(context.Background()); !errors.Is(err, goods.ErrClosed) {
+		t.Errorf("Take on closed empty queue error = %v, expected goods.ErrClosed", err)
+	}
+}
+
+func TestBlockingQueue_Batch(t *testing.T) {
+	bq, _ := NewBlockingQueue[int](8)
+	if err := bq.OfferAll(0, 1, 2, 3, 4); err != nil {
+		t.Fatal(err)
+	}
+	// OfferAll is all-or-nothing: 4 more elements do not fit in the 3 free slots
+	if err := bq.OfferAll(5, 6, 7, 8); !errors.Is(err, goods.ErrFull) {
+		t.Errorf("OfferAll past capacity error = %v, expected goods.ErrFull", err)
+	}
+	if bq.Size() != 5 {
+		t.Errorf("a rejected OfferAll must not add elements, size = %d", bq.Size())
+	}
+	if got := bq.PollN(2); !slices.Equal(got, []int{0, 1}) {
+		t.Errorf("PollN(2) = %v, expected [0 1]", got)
+	}
+	dst := make([]int, 5)
+	if n := bq.DrainTo(dst); n != 3 || !slices.Equal(dst[:n], []int{2, 3, 4}) {
+		t.Errorf("DrainTo = %v (n = %d), expected [2 3 4]", dst[:n], n)
+	}
+	if got := bq.PollN(3); got != nil {
+		t.Errorf("PollN on empty queue = %v, expected nil", got)
+	}
+	bq.Close()
+	if err := bq.OfferAll(1); !errors.Is(err, goods.ErrClosed) {
+		t.Errorf("OfferAll on closed queue error = %v, expected goods.ErrClosed", err)
+	}
+}
+
+func TestBlockingQueue_ProducersConsumers(t *testing.T) {
+	const producers, perProducer = 4, 500
+	bq, _ := NewBlockingQueue[int](8)
+	ctx := context.Background()
+
+	var producerWG sync.WaitGroup
+	for p := range producers {
+		producerWG.Go(func() {
+			for i := range perProducer {
+				if err := bq.Put(ctx, p*perProducer+i); err != nil {
+					t.Errorf("Put failed: %s", err)
+					return
+				}
+			}
+		})
+	}
+
+	results := make(chan []int, 3)
+	for range 3 {
+		go func() {
+			var got []int
+			for {
+				v, err := bq.Take(ctx)
+				if err != nil {
+					results <- got
+					return
+				}
+				got = append(got, v)
+			}
+		}()
+	}
+
+	producerWG.Wait()
+	bq.Close()
+
+	var all []int
+	for range 3 {
+		all = append(all, <-results...)
+	}
+	slices.Sort(all)
+	if len(all) != producers*perProducer {
+		t.Fatalf("consumed %d elements, expected %d", len(all), producers*perProducer)
+	}
+	for i, v := range all {
+		if v != i {
+			t.Fatalf("element %d missing or duplicated", i)
+		}
+	}
+}
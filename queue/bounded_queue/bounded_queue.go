@@ -0,0 +1,244 @@
+// Package boundedqueue limits the capacity of any queue.Queue and decides what
+// happens to elements offered while it is full.
+package boundedqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/queue"
+)
+
+// ErrInvalidPolicy is returned when an OverflowPolicy is not one of the
+// defined policies.
+var ErrInvalidPolicy = errors.New("unknown overflow policy")
+
+// OverflowPolicy decides what Offer does when the queue is full.
+type OverflowPolicy int
+
+const (
+	// Reject makes Offer return an error wrapping goods.ErrFull.
+	Reject OverflowPolicy = iota
+
+	// DropOldest polls the front element to make room for the new one.
+	DropOldest
+
+	// DropNewest discards the new element; Offer returns nil.
+	DropNewest
+
+	// Block makes Offer wait until another goroutine polls an element.
+	Block
+)
+
+func (p OverflowPolicy) String() string {
+	switch p {
+	case Reject:
+		return "Reject"
+	case DropOldest:
+		return "DropOldest"
+	case DropNewest:
+		return "DropNewest"
+	case Block:
+		return "Block"
+	default:
+		return fmt.Sprintf("OverflowPolicy(%d)", int(p))
+	}
+}
+
+// BoundedQueue wraps a queue.Queue, keeping it at no more than capacity
+// elements and applying an OverflowPolicy to elements offered while it is full.
+//
+// It is safe for concurrent use; every call to the wrapped queue is made under
+// a mutex, so the wrapped queue must not be used directly afterwards. With the
+// Block policy, Offer waits on a signal channel that Poll closes and replaces,
+// the same way as blockingqueue.BlockingQueue.
+//
+// Dropped and Rejected count the elements lost to backpressure, and can be
+// read at any time without taking the lock.
+type BoundedQueue[T any] struct {
+	// mu guards queue and notFull
+	mu sync.Mutex
+
+	// queue stores the elements
+	queue queue.Queue[T]
+
+	// capacity is the maximum number of elements kept in queue
+	capacity int
+
+	// policy is applied when an element is offered while the queue is full
+	policy OverflowPolicy
+
+	// notFull is closed and replaced each time an element is removed
+	notFull chan struct{}
+
+	// dropped counts elements discarded by DropOldest or DropNewest
+	dropped atomic.Uint64
+
+	// rejected counts Offer calls that returned goods.ErrFull
+	rejected atomic.Uint64
+}
+
+// Compile-time check that BoundedQueue implements the queue.Queue interface.
+var _ queue.Queue[int] = (*BoundedQueue[int])(nil)
+
+// NewBoundedQueue returns a BoundedQueue that stores its elements in q.
+// Returns an error wrapping goods.ErrCapacity if capacity is not positive,
+// goods.ErrNotInitialized if q is nil, or ErrInvalidPolicy if policy is unknown.
+// Time Complexity: O(1)
+func NewBoundedQueue[T any](q queue.Queue[T], capacity int, policy OverflowPolicy) (*BoundedQueue[T], error) {
+	if capacity <= 0 {
+		return nil, fmt.Errorf("capacity must be positive, got %d: %w", capacity, goods.ErrCapacity)
+	}
+	if q == nil {
+		return nil, fmt.Errorf("the wrapped queue is nil: %w", goods.ErrNotInitialized)
+	}
+	if policy < Reject || policy > Block {
+		return nil, fmt.Errorf("cannot use policy %s: %w", policy, ErrInvalidPolicy)
+	}
+	return &BoundedQueue[T]{
+		queue:    q,
+		capacity: capacity,
+		policy:   policy,
+		notFull:  make(chan struct{}),
+	}, nil
+}
+
+// Offer adds an element to the back of the queue, applying the overflow policy
+// if the queue is full. With the Block policy it waits without a deadline; use
+// OfferContext to be able to give up.
+// Time Complexity: the wrapped queue's Offer, plus its Poll for DropOldest
+func (bq *BoundedQueue[T]) Offer(value T) error {
+	return bq.OfferContext(context.Background(), value)
+}
+
+// OfferContext is Offer with a context that bounds how long the Block policy
+// waits. Returns ctx.Err() if ctx is done before there is room.
+// The other policies never wait and ignore ctx.
+func (bq *BoundedQueue[T]) OfferContext(ctx context.Context, value T) error {
+	if bq == nil || bq.queue == nil {
+		return fmt.Errorf("please call NewBoundedQueue() first: %w", goods.ErrNotInitialized)
+	}
+	for {
+		bq.mu.Lock()
+		if bq.queue.Size() < bq.capacity {
+			err := bq.queue.Offer(value)
+			bq.mu.Unlock()
+			return err
+		}
+
+		switch bq.policy {
+		case Reject:
+			bq.mu.Unlock()
+			bq.rejected.Add(1)
+			return fmt.Errorf("cannot offer element: %w", goods.ErrFull)
+		case DropNewest:
+			bq.mu.Unlock()
+			bq.dropped.Add(1)
+			return nil
+		case DropOldest:
+			// The wrapped queue may already hold more than capacity elements
+			for bq.queue.Size() >= bq.capacity {
+				if _, err := bq.queue.Poll(); err != nil {
+					bq.mu.Unlock()
+					return fmt.Errorf("cannot drop the oldest element: %w", err)
+				}
+				bq.dropped.Add(1)
+			}
+			err := bq.queue.Offer(value)
+			bq.mu.Unlock()
+			return err
+		}
+
+		// Block
+		wait := bq.notFull
+		bq.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-wait:
+		}
+	}
+}
+
+// Poll removes and returns the front element.
+// Returns an error wrapping goods.ErrEmpty if the queue is empty.
+func (bq *BoundedQueue[T]) Poll() (T, error) {
+	var element T
+	if bq == nil || bq.queue == nil {
+		return element, fmt.Errorf("please call NewBoundedQueue() first: %w", goods.ErrNotInitialized)
+	}
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	element, err := bq.queue.Poll()
+	if err != nil {
+		return element, err
+	}
+	close(bq.notFull)
+	bq.notFull = make(chan struct{})
+	return element, nil
+}
+
+// Peek returns the front element without removing it.
+// Returns an error wrapping goods.ErrEmpty if the queue is empty.
+func (bq *BoundedQueue[T]) Peek() (T, error) {
+	var element T
+	if bq == nil || bq.queue == nil {
+		return element, fmt.Errorf("please call NewBoundedQueue() first: %w", goods.ErrNotInitialized)
+	}
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	return bq.queue.Peek()
+}
+
+// Size returns the number of elements in the queue.
+func (bq *BoundedQueue[T]) Size() int {
+	if bq == nil || bq.queue == nil {
+		return 0
+	}
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	return bq.queue.Size()
+}
+
+// IsEmpty returns true if the queue has no elements.
+func (bq *BoundedQueue[T]) IsEmpty() bool {
+	return bq.Size() == 0
+}
+
+// Capacity returns the maximum number of elements the queue holds.
+func (bq *BoundedQueue[T]) Capacity() int {
+	if bq == nil {
+		return 0
+	}
+	return bq.capacity
+}
+
+// Policy returns the overflow policy of the queue.
+func (bq *BoundedQueue[T]) Policy() OverflowPolicy {
+	if bq == nil {
+		return Reject
+	}
+	return bq.policy
+}
+
+// Dropped returns the number of elements discarded by the DropOldest or
+// DropNewest policy since the queue was created.
+func (bq *BoundedQueue[T]) Dropped() uint64 {
+	if bq == nil {
+		return 0
+	}
+	return bq.dropped.Load()
+}
+
+// Rejected returns the number of Offer calls that failed with goods.ErrFull
+// under the Reject policy since the queue was created.
+func (bq *BoundedQueue[T]) Rejected() uint64 {
+	if bq == nil {
+		return 0
+	}
+	return bq.rejected.Load()
+}
@@ -0,0 +1,133 @@
+package boundedqueue
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/queue"
+	linkedlistqueue "github.com/Scanf-s/goods/queue/linkedlist_queue"
+	"github.com/Scanf-s/goods/queue/queuetest"
+	ringqueue "github.com/Scanf-s/goods/queue/ring_queue"
+)
+
+func newBounded(t *testing.T, capacity int, policy OverflowPolicy) *BoundedQueue[int] {
+	t.Helper()
+	bq, err := NewBoundedQueue[int](ringqueue.NewRingQueue[int](capacity), capacity, policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bq
+}
+
+func TestBoundedQueue_QueueSuite(t *testing.T) {
+	for _, policy := range []OverflowPolicy{Reject, DropOldest, DropNewest, Block} {
+		t.Run(policy.String(), func(t *testing.T) {
+			queuetest.RunQueueSuite(t, func() queue.Queue[int] {
+				bq, err := NewBoundedQueue[int](linkedlistqueue.NewLinkedListQueue[int](), 4096, policy)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return bq
+			})
+		})
+	}
+}
+
+func TestBoundedQueue_Reject(t *testing.T) {
+	bq := newBounded(t, 2, Reject)
+	_ = bq.Offer(1)
+	_ = bq.Offer(2)
+	if err := bq.Offer(3); !errors.Is(err, goods.ErrFull) {
+		t.Errorf("Offer on full queue error = %v, expected goods.ErrFull", err)
+	}
+	if bq.Rejected() != 1 || bq.Dropped() != 0 {
+		t.Errorf("Rejected = %d, Dropped = %d, expected 1 and 0", bq.Rejected(), bq.Dropped())
+	}
+	if v, _ := bq.Poll(); v != 1 {
+		t.Errorf("Poll = %d, expected 1", v)
+	}
+}
+
+func TestBoundedQueue_DropOldest(t *testing.T) {
+	bq := newBounded(t, 3, DropOldest)
+	for i := range 5 {
+		if err := bq.Offer(i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if bq.Dropped() != 2 || bq.Size() != 3 {
+		t.Errorf("Dropped = %d, Size = %d, expected 2 and 3", bq.Dropped(), bq.Size())
+	}
+	for _, expected := range []int{2, 3, 4} {
+		if v, err := bq.Poll(); err != nil || v != expected {
+			t.Errorf("Poll = %d (err %v), expected %d", v, err, expected)
+		}
+	}
+}
+
+func TestBoundedQueue_DropNewest(t *testing.T) {
+	bq := newBounded(t, 3, DropNewest)
+	for i := range 5 {
+		if err := bq.Offer(i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if bq.Dropped() != 2 || bq.Size() != 3 {
+		t.Errorf("Dropped = %d, Size = %d, expected 2 and 3", bq.Dropped(), bq.Size())
+	}
+	for _, expected := range []int{0, 1, 2} {
+		if v, err := bq.Poll(); err != nil || v != expected {
+			t.Errorf("Poll = %d (err %v), expected %d", v, err, expected)
+		}
+	}
+}
+
+func TestBoundedQueue_Block(t *testing.T) {
+	bq := newBounded(t, 1, Block)
+	_ = bq.Offer(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := bq.OfferContext(ctx, 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("OfferContext on full queue error = %v, expected context.DeadlineExceeded", err)
+	}
+
+	done := make(chan error)
+	go func() { done <- bq.Offer(3) }()
+	select {
+	case err := <-done:
+		t.Fatalf("Offer returned %v while the queue was full", err)
+	case <-time.After(10 * time.Millisecond):
+	}
+	if v, _ := bq.Poll(); v != 1 {
+		t.Errorf("Poll = %d, expected 1", v)
+	}
+	if err := <-done; err != nil {
+		t.Errorf("blocked Offer error = %v, expected nil", err)
+	}
+	if v, _ := bq.Poll(); v != 3 {
+		t.Errorf("Poll = %d, expected 3", v)
+	}
+	if bq.Dropped() != 0 || bq.Rejected() != 0 {
+		t.Errorf("Block must not drop elements, Dropped = %d, Rejected = %d", bq.Dropped(), bq.Rejected())
+	}
+}
+
+func TestNewBoundedQueue_Errors(t *testing.T) {
+	if _, err := NewBoundedQueue[int](ringqueue.NewRingQueue[int](0), 0, Reject); !errors.Is(err, goods.ErrCapacity) {
+		t.Errorf("capacity 0 error = %v, expected goods.ErrCapacity", err)
+	}
+	if _, err := NewBoundedQueue[int](nil, 1, Reject); !errors.Is(err, goods.ErrNotInitialized) {
+		t.Errorf("nil queue error = %v, expected goods.ErrNotInitialized", err)
+	}
+	if _, err := NewBoundedQueue[int](ringqueue.NewRingQueue[int](0), 1, OverflowPolicy(42)); !errors.Is(err, ErrInvalidPolicy) {
+		t.Errorf("unknown policy error = %v, expected ErrInvalidPolicy", err)
+	}
+	var bq *BoundedQueue[int]
+	if err := bq.Offer(1); !errors.Is(err, goods.ErrNotInitialized) {
+		t.Errorf("Offer on nil queue error = %v, expected goods.ErrNotInitialized", err)
+	}
+}
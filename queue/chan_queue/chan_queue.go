@@ -0,0 +1,161 @@
+// Package chanqueue connects queues to channels, for use in goroutine pipelines.
+package chanqueue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/queue"
+	ringqueue "github.com/Scanf-s/goods/queue/ring_queue"
+)
+
+// ChanQueue is a queue.Queue fed by a channel. It is safe for concurrent use.
+//
+// It starts no goroutine: every method first moves the elements that can be
+// received from the channel without blocking into a ring buffer, and then works
+// on the buffer. Elements sent on the channel therefore come out in the order
+// they were sent, and before any element offered directly after they were sent.
+type ChanQueue[T any] struct {
+	// mu guards every field below
+	mu sync.Mutex
+
+	// ch is the channel elements are received from; nil once it is closed
+	ch <-chan T
+
+	// buffer holds the received and offered elements, oldest first
+	buffer *ringqueue.RingQueue[T]
+}
+
+// Compile-time check that ChanQueue implements the queue.Queue interface.
+var _ queue.Queue[int] = (*ChanQueue[int])(nil)
+
+// FromChan returns a queue that receives its elements from ch. After ch is
+// closed and its elements are polled, the queue stays usable through Offer.
+// Time Complexity: O(1)
+func FromChan[T any](ch <-chan T) *ChanQueue[T] {
+	return &ChanQueue[T]{
+		ch:     ch,
+		buffer: ringqueue.NewRingQueue[T](0, ringqueue.WithShrink()),
+	}
+}
+
+// Offer adds an element to the back of the queue, after every element already
+// waiting on the channel.
+func (cq *ChanQueue[T]) Offer(element T) error {
+	if cq == nil || cq.buffer == nil {
+		return fmt.Errorf("please call FromChan() first: %w", goods.ErrNotInitialized)
+	}
+	cq.mu.Lock()
+	defer cq.mu.Unlock()
+	cq.receive()
+	return cq.buffer.Offer(element)
+}
+
+// Peek returns the front element without removing it.
+// Returns an error wrapping goods.ErrEmpty if the queue is empty and nothing can
+// be received from the channel without blocking.
+func (cq *ChanQueue[T]) Peek() (T, error) {
+	var element T
+	if cq == nil || cq.buffer == nil {
+		return element, fmt.Errorf("please call FromChan() first: %w", goods.ErrNotInitialized)
+	}
+	cq.mu.Lock()
+	defer cq.mu.Unlock()
+	cq.receive()
+	return cq.buffer.Peek()
+}
+
+// Poll removes and returns the front element.
+// Returns an error wrapping goods.ErrEmpty if the queue is empty and nothing can
+// be received from the channel without blocking.
+func (cq *ChanQueue[T]) Poll() (T, error) {
+	var element T
+	if cq == nil || cq.buffer == nil {
+		return element, fmt.Errorf("please call FromChan() first: %w", goods.ErrNotInitialized)
+	}
+	cq.mu.Lock()
+	defer cq.mu.Unlock()
+	cq.receive()
+	return cq.buffer.Poll()
+}
+
+// Size returns the number of elements in the queue, including those waiting
+// in the channel buffer.
+func (cq *ChanQueue[T]) Size() int {
+	if cq == nil || cq.buffer == nil {
+		return 0
+	}
+	cq.mu.Lock()
+	defer cq.mu.Unlock()
+	cq.receive()
+	return cq.buffer.Size()
+}
+
+// IsEmpty returns true if the queue has no elements.
+func (cq *ChanQueue[T]) IsEmpty() bool {
+	return cq.Size() == 0
+}
+
+// IsClosed reports whether the channel was closed and every element sent on it
+// has been received into the queue.
+func (cq *ChanQueue[T]) IsClosed() bool {
+	if cq == nil || cq.buffer == nil {
+		return false
+	}
+	cq.mu.Lock()
+	defer cq.mu.Unlock()
+	cq.receive()
+	return cq.ch == nil
+}
+
+// receive moves the elements that can be received without blocking into the
+// buffer. It receives at most cap(ch)+1 elements per call, so a fast sender
+// cannot keep it busy forever. cq.mu must be held.
+func (cq *ChanQueue[T]) receive() {
+	if cq.ch == nil {
+		return
+	}
+	for range cap(cq.ch) + 1 {
+		select {
+		case element, ok := <-cq.ch:
+			if !ok {
+				cq.ch = nil
+				return
+			}
+			_ = cq.buffer.Offer(element)
+		default:
+			return
+		}
+	}
+}
+
+// ToChan starts a goroutine that sends the elements of q to the returned
+// channel in FIFO order, and closes the channel once q is empty or ctx is done.
+//
+// Each element is peeked, sent, and only polled once the send succeeded, so an
+// element is never lost to cancellation: whatever was not delivered is still in
+// q. For the same reason ToChan must be the only consumer of q while it runs. q
+// must be safe for concurrent use if other goroutines keep offering to it.
+func ToChan[T any](ctx context.Context, q queue.Queue[T]) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for {
+			element, err := q.Peek()
+			if err != nil {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case out <- element:
+			}
+			if _, err := q.Poll(); err != nil {
+				return
+			}
+		}
+	}()
+	return out
+}
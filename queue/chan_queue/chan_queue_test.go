@@ -0,0 +1,186 @@
+package chanqueue
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/queue"
+	linkedlistqueue "github.com/Scanf-s/goods/queue/linkedlist_queue"
+	"github.com/Scanf-s/goods/queue/queuetest"
+)
+
+func TestChanQueue_QueueSuite(t *testing.T) {
+	queuetest.RunQueueSuite(t, func() queue.Queue[int] {
+		return FromChan(make(chan int))
+	})
+}
+
+func TestFromChan(t *testing.T) {
+	ch := make(chan int, 4)
+	q := FromChan(ch)
+	ch <- 1
+	ch <- 2
+	// Elements already sent come before an element offered afterwards
+	_ = q.Offer(3)
+	ch <- 4
+	if q.Size() != 4 {
+		t.Errorf("Size = %d, expected 4", q.Size())
+	}
+	close(ch)
+
+	for _, expected := range []int{1, 2, 3, 4} {
+		if v, err := q.Poll(); err != nil || v != expected {
+			t.Errorf("Poll = %d (err %v), expected %d", v, err, expected)
+		}
+	}
+	if _, err := q.Poll(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Poll on drained queue error = %v, expected goods.ErrEmpty", err)
+	}
+	if !q.IsClosed() {
+		t.Error("IsClosed should be true once the channel is closed and drained")
+	}
+}
+
+func TestFromChan_UnbufferedSender(t *testing.T) {
+	ch := make(chan int)
+	q := FromChan(ch)
+	go func() { ch <- 7 }()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if v, err := q.Poll(); err == nil {
+			if v != 7 {
+				t.Errorf("Poll = %d, expected 7", v)
+			}
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("the element sent on the unbuffered channel never arrived")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestToChan(t *testing.T) {
+	q := linkedlistqueue.NewLinkedListQueue[int]()
+	_ = q.OfferAll(1, 2, 3)
+	var got []int
+	for v := range ToChan(context.Background(), q) {
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("received %v, expected [1 2 3]", got)
+	}
+	if !q.IsEmpty() {
+		t.Errorf("queue should be drained, size = %d", q.Size())
+	}
+}
+
+func TestToChan_CancelKeepsUndelivered(t *testing.T) {
+	q := linkedlistqueue.NewLinkedListQueue[int]()
+	_ = q.OfferAll(1, 2, 3)
+	ctx, cancel := context.WithCancel(context.Background())
+	out := ToChan(ctx, q)
+	if v := <-out; v != 1 {
+		t.Fatalf("first element = %d, expected 1", v)
+	}
+	cancel()
+	received := []int{1}
+	// A send that was already waiting may still win against the cancellation,
+	// but whatever was not received must still be in the queue, in order
+	for v := range out {
+		received = append(received, v)
+	}
+	for !q.IsEmpty() {
+		v, _ := q.Poll()
+		received = append(received, v)
+	}
+	if !slices.Equal(received, []int{1, 2, 3}) {
+		t.Errorf("received then remaining = %v, expected [1 2 3]", received)
+	}
+}
+
+func TestUnbounded(t *testing.T) {
+	u := NewUnbounded[int](context.Background())
+	// Sends never wait for a receiver
+	for i := range 1000 {
+		u.In() <- i
+	}
+	close(u.In())
+
+	i := 0
+	for v := range u.Out() {
+		if v != i {
+			t.Fatalf("received %d, expected %d", v, i)
+		}
+		i++
+	}
+	if i != 1000 {
+		t.Errorf("received %d elements, expected 1000", i)
+	}
+	if u.Len() != 0 {
+		t.Errorf("Len = %d, expected 0", u.Len())
+	}
+}
+
+func TestUnbounded_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	u := NewUnbounded[int](ctx)
+	u.In() <- 1
+	u.In() <- 2
+	cancel()
+	// Out is closed soon after the cancellation, even though elements are buffered
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-u.Out():
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatal("Out was not closed after the context was cancelled")
+		}
+	}
+}
+
+func TestUnbounded_ConcurrentProducers(t *testing.T) {
+	const producers, perProducer = 4, 1000
+	u := NewUnbounded[int](context.Background())
+	var wg sync.WaitGroup
+	for p := range producers {
+		wg.Go(func() {
+			for i := range perProducer {
+				u.In() <- p*perProducer + i
+			}
+		})
+	}
+	go func() {
+		wg.Wait()
+		close(u.In())
+	}()
+
+	seen := make([]bool, producers*perProducer)
+	last := []int{-1, -1, -1, -1}
+	for v := range u.Out() {
+		if seen[v] {
+			t.Fatalf("element %d received twice", v)
+		}
+		seen[v] = true
+		// Each producer's elements keep their order
+		p := v / perProducer
+		if v <= last[p] {
+			t.Fatalf("producer %d: received %d after %d", p, v, last[p])
+		}
+		last[p] = v
+	}
+	for v, ok := range seen {
+		if !ok {
+			t.Fatalf("element %d was never received", v)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package chanqueue
+
+import (
+	"context"
+	"sync/atomic"
+
+	ringqueue "github.com/Scanf-s/goods/queue/ring_queue"
+)
+
+// Unbounded is a channel with an unlimited buffer: sends on In never block for
+// long, and the elements come out of Out in the order they were sent.
+//
+// A goroutine moves elements from In to Out through a ringqueue.RingQueue that
+// grows as needed and shrinks again as it empties.
+//
+// Closing In lets the goroutine deliver every buffered element, then closes
+// Out. Cancelling the context passed to NewUnbounded stops the goroutine and
+// closes Out immediately, dropping the buffered elements; In must not be used
+// after that, since nothing receives from it any more.
+type Unbounded[T any] struct {
+	in  chan T
+	out chan T
+
+	// buffered is the number of elements received from In and not yet sent on Out
+	buffered atomic.Int64
+}
+
+// NewUnbounded returns an Unbounded channel and starts its goroutine, which
+// runs until In is closed and drained or ctx is done.
+func NewUnbounded[T any](ctx context.Context) *Unbounded[T] {
+	u := &Unbounded[T]{
+		in:  make(chan T),
+		out: make(chan T),
+	}
+	go u.run(ctx)
+	return u
+}
+
+// In returns the channel to send elements on. Close it when done sending.
+func (u *Unbounded[T]) In() chan<- T {
+	return u.in
+}
+
+// Out returns the channel to receive elements from. It is closed once In is
+// closed and every element was delivered, or once the context is done.
+func (u *Unbounded[T]) Out() <-chan T {
+	return u.out
+}
+
+// Len returns the number of elements waiting in the buffer.
+func (u *Unbounded[T]) Len() int {
+	return int(u.buffered.Load())
+}
+
+func (u *Unbounded[T]) run(ctx context.Context) {
+	defer close(u.out)
+	buffer := ringqueue.NewRingQueue[T](0, ringqueue.WithShrink())
+	in := u.in
+	for in != nil || !buffer.IsEmpty() {
+		// A nil channel blocks forever, which disables the matching select case
+		var out chan T
+		var front T
+		if !buffer.IsEmpty() {
+			out = u.out
+			front, _ = buffer.Peek()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case element, ok := <-in:
+			if !ok {
+				in = nil
+				continue
+			}
+			_ = buffer.Offer(element)
+			u.buffered.Add(1)
+		case out <- front:
+			_, _ = buffer.Poll()
+			u.buffered.Add(-1)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package circular_queue
 import (
 	"fmt"
 
+	"github.com/Scanf-s/goods"
 	"github.com/Scanf-s/goods/list/linkedlist"
 	"github.com/Scanf-s/goods/queue"
 )
@@ -20,39 +21,87 @@ func NewCircularQueue[T any]() *CircularQueue[T] {
 
 func (c CircularQueue[T]) Offer(element T) error {
 	if c.list == nil {
-		return fmt.Errorf("you didn't initialize the circular queue. please call NewCircularQueue() first")
+		return fmt.Errorf("please call NewCircularQueue() first: %w", goods.ErrNotInitialized)
 	}
 	err := c.list.Append(element)
 	if err != nil {
-		return fmt.Errorf("failed to append item into circular queue")
+		return fmt.Errorf("failed to append item into circular queue: %w", err)
 	}
 	return nil
 }
 
 func (c CircularQueue[T]) Peek() (T, error) {
 	var element T
+	if c.list == nil {
+		return element, fmt.Errorf("please call NewCircularQueue() first: %w", goods.ErrNotInitialized)
+	}
 	val, err := c.list.Head()
 	if err != nil {
-		return element, fmt.Errorf("failed to peek item from circular queue. The list is empty")
+		return element, fmt.Errorf("failed to peek item from circular queue: %w", err)
 	}
 	return val, nil
 }
 
 func (c CircularQueue[T]) Poll() (T, error) {
 	var element T
+	if c.list == nil {
+		return element, fmt.Errorf("please call NewCircularQueue() first: %w", goods.ErrNotInitialized)
+	}
 	val, err := c.list.PopHead()
 	if err != nil {
-		return element, fmt.Errorf("failed to poll item from circular queue. The list is empty")
+		return element, fmt.Errorf("failed to poll item from circular queue: %w", err)
 	}
 	return val, nil
 }
 
+// OfferAll adds the elements to the back of the queue in order, splicing them
+// into the ring as one chain.
+// Time Complexity: O(k) where k is the number of elements
+func (c CircularQueue[T]) OfferAll(elements ...T) error {
+	if c.list == nil {
+		return fmt.Errorf("please call NewCircularQueue() first: %w", goods.ErrNotInitialized)
+	}
+	if err := c.list.AppendAll(elements...); err != nil {
+		return fmt.Errorf("failed to append items into circular queue: %w", err)
+	}
+	return nil
+}
+
+// PollN removes up to n elements from the front and returns them in FIFO order.
+// It returns nil if the queue is empty or n is not positive.
+// Time Complexity: O(k) where k is the number of elements returned
+func (c CircularQueue[T]) PollN(n int) []T {
+	if c.list == nil || n <= 0 || c.list.IsEmpty() {
+		return nil
+	}
+	result := make([]T, min(n, c.list.Size()))
+	c.list.PopHeadInto(result)
+	return result
+}
+
+// DrainTo removes up to len(dst) elements from the front, copies them into dst
+// in FIFO order and returns how many were copied. The removed nodes are cut out
+// of the ring in one step.
+// Time Complexity: O(k) where k is the number of elements copied
+func (c CircularQueue[T]) DrainTo(dst []T) int {
+	if c.list == nil {
+		return 0
+	}
+	return c.list.PopHeadInto(dst)
+}
+
 func (c CircularQueue[T]) Size() int {
+	if c.list == nil {
+		return 0
+	}
 	return c.list.Size()
 }
 
 func (c CircularQueue[T]) IsEmpty() bool {
+	if c.list == nil {
+		return true
+	}
 	return c.list.IsEmpty()
 }
 
-var _ queue.Queue[int] = (*CircularQueue[int])(nil)
+var _ queue.BatchQueue[int] = (*CircularQueue[int])(nil)
@@ -1,6 +1,13 @@
 package circular_queue
 
-import "testing"
+import (
+	"errors"
+	"testing"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/queue"
+	"github.com/Scanf-s/goods/queue/queuetest"
+)
 
 func TestCircularQueue_New(t *testing.T) {
 	queue := NewCircularQueue[int]()
@@ -161,3 +168,34 @@ func TestCircularQueue_WithStrings(t *testing.T) {
 		}
 	}
 }
+
+func TestCircularQueue_Errors(t *testing.T) {
+	q := NewCircularQueue[int]()
+
+	if _, err := q.Peek(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Peek on empty queue error = %v, expected goods.ErrEmpty", err)
+	}
+	if _, err := q.Poll(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Poll on empty queue error = %v, expected goods.ErrEmpty", err)
+	}
+
+	var nilQueue CircularQueue[int]
+	if err := nilQueue.Offer(1); !errors.Is(err, goods.ErrNotInitialized) {
+		t.Errorf("Offer on uninitialized queue error = %v, expected goods.ErrNotInitialized", err)
+	}
+	if _, err := nilQueue.Poll(); !errors.Is(err, goods.ErrNotInitialized) {
+		t.Errorf("Poll on uninitialized queue error = %v, expected goods.ErrNotInitialized", err)
+	}
+}
+
+func TestCircularQueue_QueueSuite(t *testing.T) {
+	queuetest.RunQueueSuite(t, func() queue.Queue[int] {
+		return NewCircularQueue[int]()
+	})
+}
+
+func TestCircularQueue_BatchQueueSuite(t *testing.T) {
+	queuetest.RunBatchQueueSuite(t, func() queue.BatchQueue[int] {
+		return NewCircularQueue[int]()
+	})
+}
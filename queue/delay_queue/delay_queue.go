@@ -0,0 +1,248 @@
+// Package delayqueue implements a queue whose elements become available only
+// once their scheduled time has come.
+package delayqueue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/clock"
+	"github.com/Scanf-s/goods/queue"
+	"github.com/Scanf-s/goods/tree/heap"
+)
+
+// delayed is an element together with the time it becomes due.
+type delayed[T any] struct {
+	value T
+
+	// readyAt is the earliest time the element can be polled
+	readyAt time.Time
+
+	// seq keeps elements with the same readyAt in offer order
+	seq uint64
+}
+
+func compareDelayed[T any](a, b delayed[T]) int {
+	if c := a.readyAt.Compare(b.readyAt); c != 0 {
+		return c
+	}
+	if a.seq < b.seq {
+		return -1
+	}
+	if a.seq > b.seq {
+		return 1
+	}
+	return 0
+}
+
+// DelayQueue is a queue of scheduled elements backed by a min-heap on their
+// ready times, safe for concurrent use.
+//
+// Poll only returns an element once its ready time has passed; elements that
+// are due leave in ready-time order, and elements with the same ready time in
+// offer order. Take blocks until the earliest element is due, waking up early
+// if an element with an earlier ready time is offered in the meantime.
+//
+// Time is read from a clock.Clock, so tests can drive the queue with a
+// clock.Fake instead of waiting for real time to pass.
+type DelayQueue[T any] struct {
+	// mu guards every field below
+	mu sync.Mutex
+
+	// heap orders the elements by ready time
+	heap *heap.Heap[delayed[T]]
+
+	// nextSeq is the sequence number given to the next offered element
+	nextSeq uint64
+
+	// clock reports the current time and runs the timers of Take
+	clock clock.Clock
+
+	// changed is closed and replaced each time an element is added, so that
+	// Take can recompute how long to wait
+	changed chan struct{}
+}
+
+// Compile-time check that DelayQueue implements the queue.Queue interface.
+var _ queue.Queue[int] = (*DelayQueue[int])(nil)
+
+// Option configures a DelayQueue created by NewDelayQueue.
+type Option func(*options)
+
+type options struct {
+	clock clock.Clock
+}
+
+// WithClock makes the queue read the current time from c instead of clock.System.
+func WithClock(c clock.Clock) Option {
+	return func(o *options) {
+		o.clock = c
+	}
+}
+
+// NewDelayQueue returns an empty DelayQueue.
+// Time Complexity: O(1)
+func NewDelayQueue[T any](opts ...Option) *DelayQueue[T] {
+	o := options{clock: clock.System}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &DelayQueue[T]{
+		heap:    heap.New(compareDelayed[T]),
+		clock:   o.clock,
+		changed: make(chan struct{}),
+	}
+}
+
+// Offer adds an element that is due immediately.
+// Time Complexity: O(log n)
+func (dq *DelayQueue[T]) Offer(value T) error {
+	if dq == nil || dq.heap == nil {
+		return fmt.Errorf("please call NewDelayQueue() first: %w", goods.ErrNotInitialized)
+	}
+	return dq.OfferAt(value, dq.clock.Now())
+}
+
+// OfferAfter adds an element that becomes due once delay has elapsed.
+// Time Complexity: O(log n)
+func (dq *DelayQueue[T]) OfferAfter(value T, delay time.Duration) error {
+	if dq == nil || dq.heap == nil {
+		return fmt.Errorf("please call NewDelayQueue() first: %w", goods.ErrNotInitialized)
+	}
+	return dq.OfferAt(value, dq.clock.Now().Add(delay))
+}
+
+// OfferAt adds an element that becomes due at readyAt.
+// Time Complexity: O(log n)
+func (dq *DelayQueue[T]) OfferAt(value T, readyAt time.Time) error {
+	if dq == nil || dq.heap == nil {
+		return fmt.Errorf("please call NewDelayQueue() first: %w", goods.ErrNotInitialized)
+	}
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+	dq.heap.Push(delayed[T]{value: value, readyAt: readyAt, seq: dq.nextSeq})
+	dq.nextSeq++
+	close(dq.changed)
+	dq.changed = make(chan struct{})
+	return nil
+}
+
+// Poll removes and returns the earliest element if it is due, without blocking.
+// Returns an error wrapping goods.ErrEmpty if the queue is empty or no element is due yet.
+// Time Complexity: O(log n)
+func (dq *DelayQueue[T]) Poll() (T, error) {
+	var element T
+	if dq == nil || dq.heap == nil {
+		return element, fmt.Errorf("please call NewDelayQueue() first: %w", goods.ErrNotInitialized)
+	}
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+	head, err := dq.heap.Peek()
+	if err != nil {
+		return element, fmt.Errorf("cannot poll element from the queue: %w", goods.ErrEmpty)
+	}
+	if head.readyAt.After(dq.clock.Now()) {
+		return element, fmt.Errorf("no element is due yet: %w", goods.ErrEmpty)
+	}
+	_, _ = dq.heap.Pop()
+	return head.value, nil
+}
+
+// Take removes and returns the earliest element, waiting until it is due.
+// Returns ctx.Err() if ctx is done first.
+func (dq *DelayQueue[T]) Take(ctx context.Context) (T, error) {
+	var element T
+	if dq == nil || dq.heap == nil {
+		return element, fmt.Errorf("please call NewDelayQueue() first: %w", goods.ErrNotInitialized)
+	}
+	// The timer fires at timerAt. It is kept across wake-ups and only replaced
+	// when the earliest ready time changes, so that an Offer of a later element
+	// does not leave an abandoned timer behind.
+	var (
+		timer   <-chan time.Time
+		timerAt time.Time
+	)
+	for {
+		dq.mu.Lock()
+		if head, err := dq.heap.Peek(); err == nil {
+			delay := head.readyAt.Sub(dq.clock.Now())
+			if delay <= 0 {
+				_, _ = dq.heap.Pop()
+				dq.mu.Unlock()
+				return head.value, nil
+			}
+			if timer == nil || !timerAt.Equal(head.readyAt) {
+				timer = dq.clock.After(delay)
+				timerAt = head.readyAt
+			}
+		}
+		changed := dq.changed
+		dq.mu.Unlock()
+
+		// A nil timer blocks forever, so an empty queue waits for an Offer only
+		select {
+		case <-ctx.Done():
+			return element, ctx.Err()
+		case <-changed:
+		case <-timer:
+			timer = nil
+		}
+	}
+}
+
+// Peek returns the element the next Poll would return, without removing it.
+// Returns an error wrapping goods.ErrEmpty if the queue is empty or no element
+// is due yet; NextReadyAt tells when the earliest element becomes due.
+// Time Complexity: O(1)
+func (dq *DelayQueue[T]) Peek() (T, error) {
+	var element T
+	if dq == nil || dq.heap == nil {
+		return element, fmt.Errorf("please call NewDelayQueue() first: %w", goods.ErrNotInitialized)
+	}
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+	head, err := dq.heap.Peek()
+	if err != nil {
+		return element, fmt.Errorf("cannot peek element from the queue: %w", goods.ErrEmpty)
+	}
+	if head.readyAt.After(dq.clock.Now()) {
+		return element, fmt.Errorf("no element is due yet: %w", goods.ErrEmpty)
+	}
+	return head.value, nil
+}
+
+// NextReadyAt returns the ready time of the earliest element.
+// Returns an error wrapping goods.ErrEmpty if the queue is empty.
+// Time Complexity: O(1)
+func (dq *DelayQueue[T]) NextReadyAt() (time.Time, error) {
+	if dq == nil || dq.heap == nil {
+		return time.Time{}, fmt.Errorf("please call NewDelayQueue() first: %w", goods.ErrNotInitialized)
+	}
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+	head, err := dq.heap.Peek()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("cannot get the next ready time: %w", goods.ErrEmpty)
+	}
+	return head.readyAt, nil
+}
+
+// Size returns the number of elements in the queue, due or not.
+// Time Complexity: O(1)
+func (dq *DelayQueue[T]) Size() int {
+	if dq == nil || dq.heap == nil {
+		return 0
+	}
+	dq.mu.Lock()
+	defer dq.mu.Unlock()
+	return dq.heap.Size()
+}
+
+// IsEmpty returns true if the queue has no elements, due or not.
+// Time Complexity: O(1)
+func (dq *DelayQueue[T]) IsEmpty() bool {
+	return dq.Size() == 0
+}
@@ -0,0 +1,193 @@
+package delayqueue
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/clock"
+	"github.com/Scanf-s/goods/queue"
+	"github.com/Scanf-s/goods/queue/queuetest"
+)
+
+var epoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func TestDelayQueue_QueueSuite(t *testing.T) {
+	queuetest.RunQueueSuite(t, func() queue.Queue[int] {
+		return NewDelayQueue[int](WithClock(clock.NewFake(epoch)))
+	})
+}
+
+func TestDelayQueue_PollOnlyDue(t *testing.T) {
+	fake := clock.NewFake(epoch)
+	dq := NewDelayQueue[string](WithClock(fake))
+	_ = dq.OfferAfter("late", 3*time.Second)
+	_ = dq.OfferAfter("early", time.Second)
+	_ = dq.OfferAt("same-early", epoch.Add(time.Second))
+
+	if _, err := dq.Poll(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Poll before any element is due error = %v, expected goods.ErrEmpty", err)
+	}
+	if _, err := dq.Peek(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Peek before any element is due error = %v, expected goods.ErrEmpty", err)
+	}
+	if at, err := dq.NextReadyAt(); err != nil || !at.Equal(epoch.Add(time.Second)) {
+		t.Errorf("NextReadyAt = %s (err %v), expected %s", at, err, epoch.Add(time.Second))
+	}
+
+	fake.Advance(time.Second)
+	if v, err := dq.Peek(); err != nil || v != "early" {
+		t.Errorf("Peek = %q (err %v), expected early", v, err)
+	}
+	for _, expected := range []string{"early", "same-early"} {
+		if v, err := dq.Poll(); err != nil || v != expected {
+			t.Errorf("Poll = %q (err %v), expected %q", v, err, expected)
+		}
+	}
+	if _, err := dq.Poll(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Poll before late is due error = %v, expected goods.ErrEmpty", err)
+	}
+	if dq.Size() != 1 {
+		t.Errorf("Size = %d, expected 1", dq.Size())
+	}
+
+	fake.Advance(2 * time.Second)
+	if v, err := dq.Poll(); err != nil || v != "late" {
+		t.Errorf("Poll = %q (err %v), expected late", v, err)
+	}
+	if !dq.IsEmpty() {
+		t.Errorf("queue should be empty, size = %d", dq.Size())
+	}
+}
+
+// waitForTimers blocks until n timers are pending on fake, i.e. until Take is
+// waiting for the clock.
+func waitForTimers(t *testing.T, fake *clock.Fake, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for fake.PendingTimers() < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %d pending timers", n)
+		}
+		runtime.Gosched()
+	}
+}
+
+func TestDelayQueue_TakeWaitsForDeadline(t *testing.T) {
+	fake := clock.NewFake(epoch)
+	dq := NewDelayQueue[int](WithClock(fake))
+	_ = dq.OfferAfter(1, time.Minute)
+
+	result := make(chan int)
+	go func() {
+		v, err := dq.Take(context.Background())
+		if err != nil {
+			t.Error(err)
+		}
+		result <- v
+	}()
+
+	waitForTimers(t, fake, 1)
+	fake.Advance(30 * time.Second)
+	select {
+	case v := <-result:
+		t.Fatalf("Take returned %d before the deadline", v)
+	default:
+	}
+
+	fake.Advance(30 * time.Second)
+	if v := <-result; v != 1 {
+		t.Errorf("Take = %d, expected 1", v)
+	}
+}
+
+func TestDelayQueue_TakeWakesOnEarlierOffer(t *testing.T) {
+	fake := clock.NewFake(epoch)
+	dq := NewDelayQueue[int](WithClock(fake))
+
+	result := make(chan int)
+	go func() {
+		v, _ := dq.Take(context.Background())
+		result <- v
+	}()
+
+	// Take is blocked on an empty queue; an offer that is due now wakes it
+	_ = dq.OfferAfter(1, time.Hour)
+	waitForTimers(t, fake, 1)
+	_ = dq.Offer(2)
+	if v := <-result; v != 2 {
+		t.Errorf("Take = %d, expected 2", v)
+	}
+}
+
+func TestDelayQueue_TakeKeepsTimer(t *testing.T) {
+	fake := clock.NewFake(epoch)
+	dq := NewDelayQueue[int](WithClock(fake))
+	_ = dq.OfferAfter(1, time.Minute)
+
+	result := make(chan int)
+	go func() {
+		v, _ := dq.Take(context.Background())
+		result <- v
+	}()
+	waitForTimers(t, fake, 1)
+
+	// Every Offer wakes Take up, but the earliest ready time stays the same,
+	// so Take must keep waiting on its first timer
+	for i := range 50 {
+		_ = dq.OfferAfter(i+2, time.Hour)
+		time.Sleep(100 * time.Microsecond)
+	}
+	if n := fake.PendingTimers(); n != 1 {
+		t.Errorf("pending timers = %d after offering later elements, expected 1", n)
+	}
+
+	fake.Advance(time.Minute)
+	if v := <-result; v != 1 {
+		t.Errorf("Take = %d, expected 1", v)
+	}
+}
+
+func TestDelayQueue_TakeCancelled(t *testing.T) {
+	dq := NewDelayQueue[int](WithClock(clock.NewFake(epoch)))
+	_ = dq.OfferAfter(1, time.Hour)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := dq.Take(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Take error = %v, expected context.DeadlineExceeded", err)
+	}
+	if dq.Size() != 1 {
+		t.Errorf("a cancelled Take must not remove the element, size = %d", dq.Size())
+	}
+}
+
+func TestDelayQueue_SystemClock(t *testing.T) {
+	dq := NewDelayQueue[int]()
+	_ = dq.OfferAfter(7, 5*time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	start := time.Now()
+	if v, err := dq.Take(ctx); err != nil || v != 7 {
+		t.Fatalf("Take = %d (err %v), expected 7", v, err)
+	}
+	if elapsed := time.Since(start); elapsed < 5*time.Millisecond {
+		t.Errorf("Take returned after %s, before the 5ms delay", elapsed)
+	}
+}
+
+func TestDelayQueue_NotInitialized(t *testing.T) {
+	var dq *DelayQueue[int]
+	if err := dq.Offer(1); !errors.Is(err, goods.ErrNotInitialized) {
+		t.Errorf("Offer on nil queue error = %v, expected goods.ErrNotInitialized", err)
+	}
+	if _, err := dq.Take(context.Background()); !errors.Is(err, goods.ErrNotInitialized) {
+		t.Errorf("Take on nil queue error = %v, expected goods.ErrNotInitialized", err)
+	}
+	if !dq.IsEmpty() {
+		t.Error("a nil queue should be empty")
+	}
+}
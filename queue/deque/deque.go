@@ -3,6 +3,7 @@ package deque
 import (
 	"fmt"
 
+	"github.com/Scanf-s/goods"
 	"github.com/Scanf-s/goods/list/linkedlist"
 	"github.com/Scanf-s/goods/queue"
 )
@@ -19,84 +20,138 @@ func NewDeque[T any]() *Deque[T] {
 
 func (d Deque[T]) Offer(element T) error {
 	if d.list == nil {
-		return fmt.Errorf("you didn't initialize the list. please call NewDeque() first")
+		return fmt.Errorf("please call NewDeque() first: %w", goods.ErrNotInitialized)
 	}
 	err := d.list.Append(element)
 	if err != nil {
-		return fmt.Errorf("there was an error adding the element to the list")
+		return fmt.Errorf("there was an error adding the element to the list: %w", err)
 	}
 	return nil
 }
 
 func (d Deque[T]) OfferFront(element T) error {
 	if d.list == nil {
-		return fmt.Errorf("you didn't initialize the list. please call NewDeque() first")
+		return fmt.Errorf("please call NewDeque() first: %w", goods.ErrNotInitialized)
 	}
 	err := d.list.Prepend(element)
 	if err != nil {
-		return fmt.Errorf("there was an error adding the element to the list")
+		return fmt.Errorf("there was an error adding the element to the list: %w", err)
 	}
 	return nil
 }
 
+// Peek returns the front element without removing it, as queue.Queue requires.
+// It is equivalent to PeekFront.
 func (d Deque[T]) Peek() (T, error) {
+	return d.PeekFront()
+}
+
+// Poll removes and returns the front element, as queue.Queue requires.
+// Together with Offer this gives FIFO order. It is equivalent to PollFront.
+func (d Deque[T]) Poll() (T, error) {
+	return d.PollFront()
+}
+
+func (d Deque[T]) PeekFront() (T, error) {
 	var element T
 	if d.list == nil {
-		return element, fmt.Errorf("deque is nil")
+		return element, fmt.Errorf("deque is nil: %w", goods.ErrNotInitialized)
 	}
 
-	element, err := d.list.Tail()
+	element, err := d.list.Head()
 	if err != nil {
-		return element, fmt.Errorf("cannot peek front element from the queue")
+		return element, fmt.Errorf("cannot peek front element from the deque: %w", err)
 	}
 	return element, nil
 }
 
-func (d Deque[T]) Poll() (T, error) {
+func (d Deque[T]) PollFront() (T, error) {
 	var element T
 	if d.list == nil {
-		return element, fmt.Errorf("deque is nil")
+		return element, fmt.Errorf("deque is nil: %w", goods.ErrNotInitialized)
 	}
 
-	element, err := d.list.PopTail()
+	element, err := d.list.PopHead()
 	if err != nil {
-		return element, fmt.Errorf("cannot peek front element from the queue")
+		return element, fmt.Errorf("cannot poll front element from the deque: %w", err)
 	}
 	return element, nil
 }
 
-func (d Deque[T]) PeekFront() (T, error) {
+func (d Deque[T]) PeekBack() (T, error) {
 	var element T
 	if d.list == nil {
-		return element, fmt.Errorf("deque is nil")
+		return element, fmt.Errorf("deque is nil: %w", goods.ErrNotInitialized)
 	}
 
-	element, err := d.list.Head()
+	element, err := d.list.Tail()
 	if err != nil {
-		return element, fmt.Errorf("cannot peek front element from the queue")
+		return element, fmt.Errorf("cannot peek back element from the deque: %w", err)
 	}
 	return element, nil
 }
 
-func (d Deque[T]) PollFront() (T, error) {
+func (d Deque[T]) PollBack() (T, error) {
 	var element T
 	if d.list == nil {
-		return element, fmt.Errorf("deque is nil")
+		return element, fmt.Errorf("deque is nil: %w", goods.ErrNotInitialized)
 	}
 
-	element, err := d.list.PopHead()
+	element, err := d.list.PopTail()
 	if err != nil {
-		return element, fmt.Errorf("cannot peek front element from the queue")
+		return element, fmt.Errorf("cannot poll back element from the deque: %w", err)
 	}
 	return element, nil
 }
 
+// OfferAll adds the elements to the back of the deque in order, splicing them
+// onto the tail of the list as one chain.
+// Time Complexity: O(k) where k is the number of elements
+func (d Deque[T]) OfferAll(elements ...T) error {
+	if d.list == nil {
+		return fmt.Errorf("please call NewDeque() first: %w", goods.ErrNotInitialized)
+	}
+	if err := d.list.AppendAll(elements...); err != nil {
+		return fmt.Errorf("there was an error adding the elements to the list: %w", err)
+	}
+	return nil
+}
+
+// PollN removes up to n elements from the front and returns them in FIFO order.
+// It returns nil if the deque is empty or n is not positive.
+// Time Complexity: O(k) where k is the number of elements returned
+func (d Deque[T]) PollN(n int) []T {
+	if d.list == nil || n <= 0 || d.list.IsEmpty() {
+		return nil
+	}
+	result := make([]T, min(n, d.list.Size()))
+	d.list.PopHeadInto(result)
+	return result
+}
+
+// DrainTo removes up to len(dst) elements from the front, copies them into dst
+// in FIFO order and returns how many were copied. The removed nodes are cut off
+// the list in one step.
+// Time Complexity: O(k) where k is the number of elements copied
+func (d Deque[T]) DrainTo(dst []T) int {
+	if d.list == nil {
+		return 0
+	}
+	return d.list.PopHeadInto(dst)
+}
+
 func (d Deque[T]) Size() int {
+	if d.list == nil {
+		return 0
+	}
 	return d.list.Size()
 }
 
 func (d Deque[T]) IsEmpty() bool {
+	if d.list == nil {
+		return true
+	}
 	return d.list.IsEmpty()
 }
 
-var _ queue.Queue[int] = (*Deque[int])(nil)
+var _ queue.BatchQueue[int] = (*Deque[int])(nil)
@@ -1,6 +1,13 @@
 package deque
 
-import "testing"
+import (
+	"errors"
+	"testing"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/queue"
+	"github.com/Scanf-s/goods/queue/queuetest"
+)
 
 func TestDeque_New(t *testing.T) {
 	deque := NewDeque[int]()
@@ -27,11 +34,11 @@ func TestDeque_Offer_Poll_Integers(t *testing.T) {
 	}
 
 	var val int
-	if val, err = deque.Poll(); err != nil {
+	if val, err = deque.PollBack(); err != nil {
 		t.Error(err)
 	}
 	if val != 3 {
-		t.Errorf("Poll expected 3, got %d", val)
+		t.Errorf("PollBack expected 3, got %d", val)
 	}
 
 	if val, err = deque.PollFront(); err != nil {
@@ -104,12 +111,12 @@ func TestDeque_OfferFront_Ordering(t *testing.T) {
 		t.Errorf("PeekFront = %d, expected 3", front)
 	}
 
-	back, err := deque.Peek()
+	back, err := deque.PeekBack()
 	if err != nil {
 		t.Error(err)
 	}
 	if back != 4 {
-		t.Errorf("Peek (back) = %d, expected 4", back)
+		t.Errorf("PeekBack = %d, expected 4", back)
 	}
 
 	// Remove from the front twice: 3 then 2.
@@ -121,8 +128,8 @@ func TestDeque_OfferFront_Ordering(t *testing.T) {
 	}
 
 	// Remove from the back: 4.
-	if v, err := deque.Poll(); err != nil || v != 4 {
-		t.Errorf("Poll (back) = %d (err %v), expected 4", v, err)
+	if v, err := deque.PollBack(); err != nil || v != 4 {
+		t.Errorf("PollBack = %d (err %v), expected 4", v, err)
 	}
 
 	// Only element 1 should remain.
@@ -196,7 +203,41 @@ func TestDeque_WithStrings(t *testing.T) {
 	if front, err := deque.PeekFront(); err != nil || front != "a" {
 		t.Errorf("PeekFront = %q (err %v), expected \"a\"", front, err)
 	}
-	if back, err := deque.Peek(); err != nil || back != "c" {
-		t.Errorf("Peek (back) = %q (err %v), expected \"c\"", back, err)
+	if back, err := deque.PeekBack(); err != nil || back != "c" {
+		t.Errorf("PeekBack = %q (err %v), expected \"c\"", back, err)
+	}
+	if front, err := deque.Peek(); err != nil || front != "a" {
+		t.Errorf("Peek = %q (err %v), expected \"a\"", front, err)
+	}
+}
+
+func TestDeque_Errors(t *testing.T) {
+	q := NewDeque[int]()
+
+	if _, err := q.Peek(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Peek on empty queue error = %v, expected goods.ErrEmpty", err)
+	}
+	if _, err := q.Poll(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Poll on empty queue error = %v, expected goods.ErrEmpty", err)
 	}
+
+	var nilQueue Deque[int]
+	if err := nilQueue.Offer(1); !errors.Is(err, goods.ErrNotInitialized) {
+		t.Errorf("Offer on uninitialized queue error = %v, expected goods.ErrNotInitialized", err)
+	}
+	if _, err := nilQueue.Poll(); !errors.Is(err, goods.ErrNotInitialized) {
+		t.Errorf("Poll on uninitialized queue error = %v, expected goods.ErrNotInitialized", err)
+	}
+}
+
+func TestDeque_QueueSuite(t *testing.T) {
+	queuetest.RunQueueSuite(t, func() queue.Queue[int] {
+		return NewDeque[int]()
+	})
+}
+
+func TestDeque_BatchQueueSuite(t *testing.T) {
+	queuetest.RunBatchQueueSuite(t, func() queue.BatchQueue[int] {
+		return NewDeque[int]()
+	})
 }
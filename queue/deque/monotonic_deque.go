@@ -0,0 +1,117 @@
+package deque
+
+import (
+	"cmp"
+	"fmt"
+
+	"github.com/Scanf-s/goods"
+)
+
+// MonotonicDeque is a Deque whose elements are kept sorted by compare, so the
+// front is always the smallest element pushed since it was last removed.
+//
+// Push removes from the back every element that compares greater than the new
+// one before appending it, because such elements can never become the front
+// while the new one is still queued. Each element is appended and removed at
+// most once, so Push is amortized O(1). Equal elements are all kept, in push order.
+//
+// This is the building block for sliding-window minimum and maximum: push every
+// new sample and drop the front once it leaves the window.
+type MonotonicDeque[T any] struct {
+	deque *Deque[T]
+
+	// compare orders the elements; the front is the minimum under compare
+	compare func(a, b T) int
+}
+
+// NewMonotonicDeque returns an empty MonotonicDeque whose front is the minimum
+// element according to compare, which must return a negative number when a < b,
+// zero when a == b and a positive number when a > b.
+func NewMonotonicDeque[T any](compare func(a, b T) int) *MonotonicDeque[T] {
+	return &MonotonicDeque[T]{
+		deque:   NewDeque[T](),
+		compare: compare,
+	}
+}
+
+// NewMinMonotonicDeque returns a MonotonicDeque whose front is the smallest element.
+func NewMinMonotonicDeque[T cmp.Ordered]() *MonotonicDeque[T] {
+	return NewMonotonicDeque(cmp.Compare[T])
+}
+
+// NewMaxMonotonicDeque returns a MonotonicDeque whose front is the largest element.
+func NewMaxMonotonicDeque[T cmp.Ordered]() *MonotonicDeque[T] {
+	return NewMonotonicDeque(func(a, b T) int { return cmp.Compare(b, a) })
+}
+
+// Push removes every back element greater than element, then appends element.
+// Time Complexity: O(1) amortized
+func (m *MonotonicDeque[T]) Push(element T) error {
+	if m == nil || m.deque == nil {
+		return fmt.Errorf("please call NewMonotonicDeque() first: %w", goods.ErrNotInitialized)
+	}
+	for !m.deque.IsEmpty() {
+		back, _ := m.deque.PeekBack()
+		if m.compare(back, element) <= 0 {
+			break
+		}
+		_, _ = m.deque.PollBack()
+	}
+	return m.deque.Offer(element)
+}
+
+// Front returns the minimum element without removing it.
+// Returns an error wrapping goods.ErrEmpty if the deque is empty.
+// Time Complexity: O(1)
+func (m *MonotonicDeque[T]) Front() (T, error) {
+	var element T
+	if m == nil || m.deque == nil {
+		return element, fmt.Errorf("please call NewMonotonicDeque() first: %w", goods.ErrNotInitialized)
+	}
+	return m.deque.PeekFront()
+}
+
+// PollFront removes and returns the minimum element.
+// Returns an error wrapping goods.ErrEmpty if the deque is empty.
+// Time Complexity: O(1)
+func (m *MonotonicDeque[T]) PollFront() (T, error) {
+	var element T
+	if m == nil || m.deque == nil {
+		return element, fmt.Errorf("please call NewMonotonicDeque() first: %w", goods.ErrNotInitialized)
+	}
+	return m.deque.PollFront()
+}
+
+// PollFrontWhile removes front elements as long as expired reports true for them
+// and returns how many were removed.
+// Time Complexity: O(k) where k is the number of elements removed
+func (m *MonotonicDeque[T]) PollFrontWhile(expired func(element T) bool) int {
+	if m == nil || m.deque == nil {
+		return 0
+	}
+	removed := 0
+	for !m.deque.IsEmpty() {
+		front, _ := m.deque.PeekFront()
+		if !expired(front) {
+			break
+		}
+		_, _ = m.deque.PollFront()
+		removed++
+	}
+	return removed
+}
+
+// Size returns the number of elements still in the deque.
+// Time Complexity: O(1)
+func (m *MonotonicDeque[T]) Size() int {
+	if m == nil {
+		return 0
+	}
+	return m.deque.Size()
+}
+
+// IsEmpty returns true if the deque has no elements.
+// Time Complexity: O(1)
+func (m *MonotonicDeque[T]) IsEmpty() bool {
+	return m.Size() == 0
+}
@@ -0,0 +1,56 @@
+package deque
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Scanf-s/goods"
+)
+
+func TestMonotonicDeque_MinFront(t *testing.T) {
+	m := NewMinMonotonicDeque[int]()
+	for _, v := range []int{5, 3, 4, 3, 8} {
+		if err := m.Push(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// 5 was dropped by 3 and the first 4 by the second 3; equal elements are kept
+	expected := []int{3, 3, 8}
+	if m.Size() != len(expected) {
+		t.Fatalf("Size = %d, expected %d", m.Size(), len(expected))
+	}
+	for _, e := range expected {
+		if v, err := m.PollFront(); err != nil || v != e {
+			t.Errorf("PollFront = %d (err %v), expected %d", v, err, e)
+		}
+	}
+	if _, err := m.Front(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Front on empty deque error = %v, expected goods.ErrEmpty", err)
+	}
+}
+
+func TestMonotonicDeque_MaxFront(t *testing.T) {
+	m := NewMaxMonotonicDeque[int]()
+	for _, v := range []int{1, 7, 2, 6} {
+		_ = m.Push(v)
+	}
+	if v, err := m.Front(); err != nil || v != 7 {
+		t.Errorf("Front = %d (err %v), expected 7", v, err)
+	}
+	if removed := m.PollFrontWhile(func(v int) bool { return v > 6 }); removed != 1 {
+		t.Errorf("PollFrontWhile removed %d elements, expected 1", removed)
+	}
+	if v, err := m.Front(); err != nil || v != 6 {
+		t.Errorf("Front = %d (err %v), expected 6", v, err)
+	}
+}
+
+func TestMonotonicDeque_NotInitialized(t *testing.T) {
+	var m *MonotonicDeque[int]
+	if err := m.Push(1); !errors.Is(err, goods.ErrNotInitialized) {
+		t.Errorf("Push on nil deque error = %v, expected goods.ErrNotInitialized", err)
+	}
+	if !m.IsEmpty() {
+		t.Error("a nil deque should be empty")
+	}
+}
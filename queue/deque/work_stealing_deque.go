@@ -0,0 +1,170 @@
+package deque
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/Scanf-s/goods"
+)
+
+// ringArray is the growable circular array behind WorkStealingDeque.
+// Slots hold pointers so that thieves can read them atomically while the owner writes.
+type ringArray[T any] struct {
+	slots []atomic.Pointer[T]
+}
+
+func newRingArray[T any](size int) *ringArray[T] {
+	return &ringArray[T]{slots: make([]atomic.Pointer[T], size)}
+}
+
+func (a *ringArray[T]) size() int64 {
+	return int64(len(a.slots))
+}
+
+func (a *ringArray[T]) load(i int64) *T {
+	return a.slots[i%a.size()].Load()
+}
+
+func (a *ringArray[T]) store(i int64, element *T) {
+	a.slots[i%a.size()].Store(element)
+}
+
+// grow returns a copy of the array with twice the size holding positions [top, bottom).
+func (a *ringArray[T]) grow(top, bottom int64) *ringArray[T] {
+	grown := newRingArray[T](len(a.slots) * 2)
+	for i := top; i < bottom; i++ {
+		grown.store(i, a.load(i))
+	}
+	return grown
+}
+
+// WorkStealingDeque is a Chase–Lev work-stealing deque for task schedulers.
+//
+// One owner goroutine pushes and pops tasks at the bottom, LIFO, without locks;
+// any number of thief goroutines take tasks from the top, FIFO, with a CAS on top.
+// The only contended case is the owner and a thief racing for the last element,
+// which the owner also settles with a CAS on top. When the circular array fills
+// up, the owner replaces it with one twice as large; thieves still reading the
+// old array stay correct because the garbage collector keeps it alive.
+//
+// PushBottom and PopBottom must only be called from the owner goroutine.
+// Steal, Size and IsEmpty may be called from any goroutine.
+type WorkStealingDeque[T any] struct {
+	// top is the position thieves steal from; it only grows
+	top atomic.Int64
+	_   [56]byte
+
+	// bottom is the position the owner pushes to next
+	bottom atomic.Int64
+	_      [56]byte
+
+	// array holds positions [top, bottom)
+	array atomic.Pointer[ringArray[T]]
+}
+
+// NewWorkStealingDeque returns an empty WorkStealingDeque with room for
+// capacity tasks before the first resize. A capacity below 1 is treated as 1.
+// Time Complexity: O(capacity)
+func NewWorkStealingDeque[T any](capacity int) *WorkStealingDeque[T] {
+	d := &WorkStealingDeque[T]{}
+	d.array.Store(newRingArray[T](max(capacity, 1)))
+	return d
+}
+
+// PushBottom adds an element at the bottom. Owner only.
+// Time Complexity: O(1) amortized (O(n) when the array grows)
+func (d *WorkStealingDeque[T]) PushBottom(element T) error {
+	if d == nil || d.array.Load() == nil {
+		return fmt.Errorf("please call NewWorkStealingDeque() first: %w", goods.ErrNotInitialized)
+	}
+	b := d.bottom.Load()
+	t := d.top.Load()
+	a := d.array.Load()
+	if b-t >= a.size() {
+		a = a.grow(t, b)
+		d.array.Store(a)
+	}
+	a.store(b, &element)
+	// Publishing the new bottom makes the element visible to thieves
+	d.bottom.Store(b + 1)
+	return nil
+}
+
+// PopBottom removes and returns the most recently pushed element. Owner only.
+// Returns an error wrapping goods.ErrEmpty if the deque is empty or a thief
+// took the last element first.
+// Time Complexity: O(1)
+func (d *WorkStealingDeque[T]) PopBottom() (T, error) {
+	var element T
+	if d == nil || d.array.Load() == nil {
+		return element, fmt.Errorf("please call NewWorkStealingDeque() first: %w", goods.ErrNotInitialized)
+	}
+	b := d.bottom.Load() - 1
+	a := d.array.Load()
+	// Reserve position b before reading top, so a thief that reads bottom
+	// afterwards no longer considers b available
+	d.bottom.Store(b)
+	t := d.top.Load()
+
+	if t > b {
+		// The deque was already empty
+		d.bottom.Store(b + 1)
+		return element, fmt.Errorf("cannot pop bottom element: %w", goods.ErrEmpty)
+	}
+
+	p := a.load(b)
+	if t == b {
+		// Last element: thieves may be racing for it, so claim it through top
+		won := d.top.CompareAndSwap(t, t+1)
+		d.bottom.Store(b + 1)
+		if !won {
+			return element, fmt.Errorf("cannot pop bottom element: %w", goods.ErrEmpty)
+		}
+		return *p, nil
+	}
+	// No thief can reach position b any more, so the slot can be cleared
+	a.store(b, nil)
+	return *p, nil
+}
+
+// Steal removes and returns the oldest element from the top. Safe for any goroutine.
+// When another goroutine takes the top element first, Steal retries.
+// Returns an error wrapping goods.ErrEmpty if the deque is empty.
+// Time Complexity: O(1) per attempt, lock-free
+func (d *WorkStealingDeque[T]) Steal() (T, error) {
+	var element T
+	if d == nil || d.array.Load() == nil {
+		return element, fmt.Errorf("please call NewWorkStealingDeque() first: %w", goods.ErrNotInitialized)
+	}
+	for {
+		t := d.top.Load()
+		b := d.bottom.Load()
+		if t >= b {
+			return element, fmt.Errorf("cannot steal top element: %w", goods.ErrEmpty)
+		}
+		// Read the slot before claiming it: once top moves past t the owner may reuse it
+		p := d.array.Load().load(t)
+		if d.top.CompareAndSwap(t, t+1) {
+			return *p, nil
+		}
+	}
+}
+
+// Size returns the number of elements in the deque. Under concurrent use the
+// result is a snapshot that may already be stale when it is returned.
+// Time Complexity: O(1)
+func (d *WorkStealingDeque[T]) Size() int {
+	if d == nil {
+		return 0
+	}
+	b := d.bottom.Load()
+	t := d.top.Load()
+	return int(max(b-t, 0))
+}
+
+// IsEmpty returns true if the deque has no elements, subject to the same
+// staleness as Size.
+// Time Complexity: O(1)
+func (d *WorkStealingDeque[T]) IsEmpty() bool {
+	return d.Size() == 0
+}
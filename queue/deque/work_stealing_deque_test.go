@@ -0,0 +1,137 @@
+package deque
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Scanf-s/goods"
+)
+
+func TestWorkStealingDeque_OwnerLIFO(t *testing.T) {
+	d := NewWorkStealingDeque[int](2)
+	for i := range 10 {
+		if err := d.PushBottom(i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if d.Size() != 10 {
+		t.Errorf("Size = %d, expected 10", d.Size())
+	}
+	for i := 9; i >= 0; i-- {
+		v, err := d.PopBottom()
+		if err != nil || v != i {
+			t.Fatalf("PopBottom = %d (err %v), expected %d", v, err, i)
+		}
+	}
+	if _, err := d.PopBottom(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("PopBottom on empty deque error = %v, expected goods.ErrEmpty", err)
+	}
+	if !d.IsEmpty() {
+		t.Errorf("deque should be empty, size = %d", d.Size())
+	}
+}
+
+func TestWorkStealingDeque_StealFIFO(t *testing.T) {
+	d := NewWorkStealingDeque[int](1)
+	for i := range 10 {
+		_ = d.PushBottom(i)
+	}
+	for i := range 5 {
+		v, err := d.Steal()
+		if err != nil || v != i {
+			t.Fatalf("Steal = %d (err %v), expected %d", v, err, i)
+		}
+	}
+	// The owner still sees its newest element at the bottom
+	if v, err := d.PopBottom(); err != nil || v != 9 {
+		t.Errorf("PopBottom = %d (err %v), expected 9", v, err)
+	}
+	// Interleave pushes with steals so the ring wraps around
+	for i := 10; i < 20; i++ {
+		_ = d.PushBottom(i)
+		if _, err := d.Steal(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if d.Size() != 4 {
+		t.Errorf("Size = %d, expected 4", d.Size())
+	}
+	for _, expected := range []int{16, 17, 18, 19} {
+		if v, err := d.Steal(); err != nil || v != expected {
+			t.Errorf("Steal = %d (err %v), expected %d", v, err, expected)
+		}
+	}
+	if _, err := d.Steal(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Steal on empty deque error = %v, expected goods.ErrEmpty", err)
+	}
+}
+
+func TestWorkStealingDeque_NotInitialized(t *testing.T) {
+	var d *WorkStealingDeque[int]
+	if err := d.PushBottom(1); !errors.Is(err, goods.ErrNotInitialized) {
+		t.Errorf("PushBottom on nil deque error = %v, expected goods.ErrNotInitialized", err)
+	}
+	if _, err := d.PopBottom(); !errors.Is(err, goods.ErrNotInitialized) {
+		t.Errorf("PopBottom on nil deque error = %v, expected goods.ErrNotInitialized", err)
+	}
+	if _, err := (&WorkStealingDeque[int]{}).Steal(); !errors.Is(err, goods.ErrNotInitialized) {
+		t.Errorf("Steal on zero-value deque error = %v, expected goods.ErrNotInitialized", err)
+	}
+	if !d.IsEmpty() {
+		t.Error("a nil deque should be empty")
+	}
+}
+
+// TestWorkStealingDeque_Stress has the owner push and pop while thieves steal,
+// then checks that every element was taken exactly once. Run with -race.
+func TestWorkStealingDeque_Stress(t *testing.T) {
+	const thieves, count = 4, 50000
+	d := NewWorkStealingDeque[int](4)
+	taken := make([]atomic.Int32, count)
+
+	var done atomic.Bool
+	var wg sync.WaitGroup
+	for range thieves {
+		wg.Go(func() {
+			for {
+				v, err := d.Steal()
+				if err == nil {
+					taken[v].Add(1)
+					continue
+				}
+				if done.Load() && d.IsEmpty() {
+					return
+				}
+				runtime.Gosched()
+			}
+		})
+	}
+
+	for i := range count {
+		_ = d.PushBottom(i)
+		// Pop every third element back so the owner competes with the thieves
+		if i%3 == 0 {
+			if v, err := d.PopBottom(); err == nil {
+				taken[v].Add(1)
+			}
+		}
+	}
+	for {
+		v, err := d.PopBottom()
+		if err != nil {
+			break
+		}
+		taken[v].Add(1)
+	}
+	done.Store(true)
+	wg.Wait()
+
+	for i := range taken {
+		if n := taken[i].Load(); n != 1 {
+			t.Fatalf("element %d was taken %d times, expected exactly once", i, n)
+		}
+	}
+}
@@ -0,0 +1,273 @@
+// Package fairqueue implements a queue that shares its output fairly between
+// keys, such as the tenants of a multi-tenant job runner.
+package fairqueue
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/queue"
+	ringqueue "github.com/Scanf-s/goods/queue/ring_queue"
+)
+
+// ErrInvalidWeight is returned when a weight is not positive.
+var ErrInvalidWeight = errors.New("weight must be positive")
+
+// flow is the sub-queue and scheduling state of one key.
+type flow[T any] struct {
+	// queue holds the elements offered under the key, in FIFO order
+	queue *ringqueue.RingQueue[T]
+
+	// deficit is the cost the key may still spend before its turn ends
+	deficit int
+
+	// visited is set while the key is at the front of the round and has
+	// already received its quantum for the current turn
+	visited bool
+}
+
+// FairQueue keeps one FIFO sub-queue per key and polls them by deficit
+// round-robin (DRR), so that a key with many elements cannot starve the others.
+//
+// Keys with queued elements take turns in a round. At the start of its turn a
+// key's deficit grows by its weight; the key then sends front elements while
+// their cost fits in the deficit, and keeps the rest of the deficit for its next
+// turn. Over time every key receives a share of the output proportional to its
+// weight, measured in cost. With the default cost of 1 per element, a key with
+// weight 3 is polled three times as often as a key with weight 1.
+//
+// Within a key, elements keep their FIFO order. Across keys there is no global
+// FIFO order, which is the point of the structure.
+//
+// Instead of looping turn by turn, Poll computes in one pass over the active
+// keys how many rounds each one needs before its front element fits in its
+// deficit, and jumps straight to the first key that gets there. This keeps
+// Poll O(k) in the number of keys with queued elements, however large the
+// costs are relative to the weights.
+//
+// A FairQueue is not safe for concurrent use.
+type FairQueue[K comparable, T any] struct {
+	// keyOf returns the key an element is queued under
+	keyOf func(T) K
+
+	// cost returns the cost of sending an element, at least 1
+	cost func(T) int
+
+	// flows holds the state of every key with queued elements
+	flows map[K]*flow[T]
+
+	// active lists the keys with queued elements in round-robin order; the
+	// front key is the one whose turn it is
+	active *ringqueue.RingQueue[K]
+
+	// weights holds the weights set with SetWeight; other keys weigh 1
+	weights map[K]int
+
+	// size is the total number of queued elements
+	size int
+}
+
+// Compile-time check that FairQueue implements the queue.Queue interface.
+var _ queue.Queue[int] = (*FairQueue[string, int])(nil)
+
+// Option configures a FairQueue created by NewFairQueue.
+type Option[T any] func(*options[T])
+
+type options[T any] struct {
+	cost func(T) int
+}
+
+// WithCost makes the queue charge cost(element) for each element instead of 1,
+// so that keys share the output by total cost, such as bytes or estimated run
+// time, rather than by element count. Costs below 1 are treated as 1.
+func WithCost[T any](cost func(T) int) Option[T] {
+	return func(o *options[T]) {
+		o.cost = cost
+	}
+}
+
+// NewFairQueue returns an empty FairQueue that queues each element under keyOf(element).
+// Time Complexity: O(1)
+func NewFairQueue[K comparable, T any](keyOf func(T) K, opts ...Option[T]) *FairQueue[K, T] {
+	o := options[T]{cost: func(T) int { return 1 }}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &FairQueue[K, T]{
+		keyOf:   keyOf,
+		cost:    func(element T) int { return max(o.cost(element), 1) },
+		flows:   make(map[K]*flow[T]),
+		active:  ringqueue.NewRingQueue[K](0, ringqueue.WithShrink()),
+		weights: make(map[K]int),
+	}
+}
+
+// SetWeight sets the share of key relative to the other keys. Keys whose weight
+// was never set weigh 1. The new weight applies from the key's next turn.
+// Returns an error wrapping ErrInvalidWeight if weight is not positive.
+// Time Complexity: O(1)
+func (fq *FairQueue[K, T]) SetWeight(key K, weight int) error {
+	if fq == nil || fq.flows == nil {
+		return fmt.Errorf("please call NewFairQueue() first: %w", goods.ErrNotInitialized)
+	}
+	if weight <= 0 {
+		return fmt.Errorf("cannot set weight %d: %w", weight, ErrInvalidWeight)
+	}
+	fq.weights[key] = weight
+	return nil
+}
+
+// Weight returns the weight of key.
+// Time Complexity: O(1)
+func (fq *FairQueue[K, T]) Weight(key K) int {
+	if fq == nil {
+		return 1
+	}
+	if weight, ok := fq.weights[key]; ok {
+		return weight
+	}
+	return 1
+}
+
+// Offer adds an element to the back of the sub-queue of its key.
+// Time Complexity: O(1) amortized
+func (fq *FairQueue[K, T]) Offer(element T) error {
+	if fq == nil || fq.flows == nil {
+		return fmt.Errorf("please call NewFairQueue() first: %w", goods.ErrNotInitialized)
+	}
+	key := fq.keyOf(element)
+	f, ok := fq.flows[key]
+	if !ok {
+		// A key joins the round at the back, with no deficit carried over
+		f = &flow[T]{queue: ringqueue.NewRingQueue[T](0, ringqueue.WithShrink())}
+		fq.flows[key] = f
+		_ = fq.active.Offer(key)
+	}
+	_ = f.queue.Offer(element)
+	fq.size++
+	return nil
+}
+
+// Peek returns the element the next Poll will return, without removing it.
+// Returns an error wrapping goods.ErrEmpty if the queue is empty.
+// Time Complexity: O(k) where k is the number of keys with queued elements
+func (fq *FairQueue[K, T]) Peek() (T, error) {
+	var element T
+	if fq == nil || fq.flows == nil {
+		return element, fmt.Errorf("please call NewFairQueue() first: %w", goods.ErrNotInitialized)
+	}
+	if fq.size == 0 {
+		return element, fmt.Errorf("cannot peek front element from the queue: %w", goods.ErrEmpty)
+	}
+	position, _ := fq.next()
+	key, _ := fq.active.At(position)
+	return fq.flows[key].queue.Peek()
+}
+
+// Poll removes and returns the next element in deficit round-robin order.
+// Returns an error wrapping goods.ErrEmpty if the queue is empty.
+// Time Complexity: O(k) where k is the number of keys with queued elements
+func (fq *FairQueue[K, T]) Poll() (T, error) {
+	var element T
+	if fq == nil || fq.flows == nil {
+		return element, fmt.Errorf("please call NewFairQueue() first: %w", goods.ErrNotInitialized)
+	}
+	if fq.size == 0 {
+		return element, fmt.Errorf("cannot poll front element from the queue: %w", goods.ErrEmpty)
+	}
+
+	position, rounds := fq.next()
+	// Replay the skipped turns: every key is visited rounds times, and the
+	// keys up to the chosen one once more in the final round
+	for i := range fq.active.Size() {
+		key, _ := fq.active.At(i)
+		f := fq.flows[key]
+		turns := rounds
+		if i <= position {
+			turns++
+		}
+		if i == 0 && f.visited {
+			// The front key already received its quantum for the first turn
+			turns--
+		}
+		f.deficit += turns * fq.Weight(key)
+		f.visited = false
+	}
+	// The keys before the chosen one ended their turns and moved to the back
+	for range position {
+		key, _ := fq.active.Poll()
+		_ = fq.active.Offer(key)
+	}
+
+	key, _ := fq.active.Peek()
+	f := fq.flows[key]
+	element, _ = f.queue.Poll()
+	fq.size--
+	f.deficit -= fq.cost(element)
+	f.visited = true
+	if f.queue.IsEmpty() {
+		// A key leaves the round when its sub-queue empties, and loses its deficit
+		_, _ = fq.active.Poll()
+		delete(fq.flows, key)
+	}
+	return element, nil
+}
+
+// next returns the position in fq.active of the key that sends the next element,
+// and how many full rounds pass before it does. fq must not be empty.
+//
+// A key at position i with deficit d and weight w is first considered in round 0
+// with deficit d + w, unless it is the front key and already received its
+// quantum, and gains w in each later round. The first key whose front element's
+// cost fits, by round and then by position, sends it.
+func (fq *FairQueue[K, T]) next() (position, rounds int) {
+	position, rounds = -1, 0
+	for i := range fq.active.Size() {
+		key, _ := fq.active.At(i)
+		f := fq.flows[key]
+		weight := fq.Weight(key)
+		front, _ := f.queue.Peek()
+		cost := fq.cost(front)
+
+		deficit := f.deficit
+		if i != 0 || !f.visited {
+			deficit += weight
+		}
+		needed := 0
+		if deficit < cost {
+			needed = (cost - deficit + weight - 1) / weight
+		}
+		if position == -1 || needed < rounds {
+			position, rounds = i, needed
+		}
+	}
+	return position, rounds
+}
+
+// SizeOf returns the number of elements queued under key.
+// Time Complexity: O(1)
+func (fq *FairQueue[K, T]) SizeOf(key K) int {
+	if fq == nil {
+		return 0
+	}
+	if f, ok := fq.flows[key]; ok {
+		return f.queue.Size()
+	}
+	return 0
+}
+
+// Size returns the total number of elements in the queue.
+// Time Complexity: O(1)
+func (fq *FairQueue[K, T]) Size() int {
+	if fq == nil {
+		return 0
+	}
+	return fq.size
+}
+
+// IsEmpty returns true if the queue has no elements.
+// Time Complexity: O(1)
+func (fq *FairQueue[K, T]) IsEmpty() bool {
+	return fq.Size() == 0
+}
@@ -0,0 +1,186 @@
+package fairqueue
+
+import (
+	"errors"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/queue"
+	"github.com/Scanf-s/goods/queue/queuetest"
+)
+
+func TestFairQueue_QueueSuite(t *testing.T) {
+	// With a single key a FairQueue is a plain FIFO queue
+	queuetest.RunQueueSuite(t, func() queue.Queue[int] {
+		return NewFairQueue(func(int) string { return "tenant" })
+	})
+}
+
+type job struct {
+	tenant string
+	id     int
+	cost   int
+}
+
+func jobTenant(j job) string { return j.tenant }
+
+func TestFairQueue_WeightedRoundRobin(t *testing.T) {
+	fq := NewFairQueue(jobTenant)
+	if err := fq.SetWeight("a", 3); err != nil {
+		t.Fatal(err)
+	}
+	// The noisy tenant offers everything first
+	for i := range 100 {
+		_ = fq.Offer(job{tenant: "a", id: i})
+	}
+	for i := range 10 {
+		_ = fq.Offer(job{tenant: "b", id: i})
+	}
+
+	var order []string
+	for range 8 {
+		j, err := fq.Poll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		order = append(order, j.tenant)
+	}
+	expected := []string{"a", "a", "a", "b", "a", "a", "a", "b"}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Fatalf("poll order = %v, expected %v", order, expected)
+		}
+	}
+	if fq.SizeOf("b") != 8 || fq.Size() != 102 {
+		t.Errorf("SizeOf(b) = %d, Size = %d, expected 8 and 102", fq.SizeOf("b"), fq.Size())
+	}
+}
+
+func TestFairQueue_Cost(t *testing.T) {
+	fq := NewFairQueue(jobTenant, WithCost(func(j job) int { return j.cost }))
+	_ = fq.SetWeight("big", 10)
+	_ = fq.SetWeight("small", 10)
+	// Both tenants get 10 cost units per round: one large job, or five small ones
+	for i := range 3 {
+		_ = fq.Offer(job{tenant: "big", id: i, cost: 10})
+	}
+	for i := range 15 {
+		_ = fq.Offer(job{tenant: "small", id: i, cost: 2})
+	}
+
+	counts := map[string]int{}
+	for range 12 {
+		j, _ := fq.Poll()
+		counts[j.tenant]++
+	}
+	if counts["big"] != 2 || counts["small"] != 10 {
+		t.Errorf("after 12 polls got %v, expected 2 big and 10 small jobs", counts)
+	}
+}
+
+func TestFairQueue_LargeCostSkipsRounds(t *testing.T) {
+	fq := NewFairQueue(jobTenant, WithCost(func(j job) int { return j.cost }))
+	_ = fq.Offer(job{tenant: "a", id: 0, cost: 1_000_000_000})
+	_ = fq.Offer(job{tenant: "b", id: 0, cost: 999_999_999})
+	// Both need about a billion rounds; b gets there one round sooner
+	if j, err := fq.Poll(); err != nil || j.tenant != "b" {
+		t.Errorf("Poll = %+v (err %v), expected the job of b", j, err)
+	}
+	if j, err := fq.Poll(); err != nil || j.tenant != "a" {
+		t.Errorf("Poll = %+v (err %v), expected the job of a", j, err)
+	}
+}
+
+func TestFairQueue_Errors(t *testing.T) {
+	fq := NewFairQueue(jobTenant)
+	if err := fq.SetWeight("a", 0); !errors.Is(err, ErrInvalidWeight) {
+		t.Errorf("SetWeight(0) error = %v, expected ErrInvalidWeight", err)
+	}
+	if _, err := fq.Peek(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Peek on empty queue error = %v, expected goods.ErrEmpty", err)
+	}
+	var zero FairQueue[string, job]
+	if err := zero.Offer(job{}); !errors.Is(err, goods.ErrNotInitialized) {
+		t.Errorf("Offer on zero-value queue error = %v, expected goods.ErrNotInitialized", err)
+	}
+}
+
+// referenceDRR is the textbook deficit round-robin loop, visiting keys one turn
+// at a time, used to check the round-skipping Poll of FairQueue.
+type referenceDRR struct {
+	weights map[string]int
+	queues  map[string][]job
+	deficit map[string]int
+	order   []string
+	visited bool
+}
+
+func (r *referenceDRR) offer(j job) {
+	if len(r.queues[j.tenant]) == 0 {
+		r.order = append(r.order, j.tenant)
+	}
+	r.queues[j.tenant] = append(r.queues[j.tenant], j)
+}
+
+func (r *referenceDRR) poll() job {
+	for {
+		key := r.order[0]
+		if !r.visited {
+			r.deficit[key] += max(r.weights[key], 1)
+			r.visited = true
+		}
+		front := r.queues[key][0]
+		if front.cost <= r.deficit[key] {
+			r.deficit[key] -= front.cost
+			r.queues[key] = r.queues[key][1:]
+			if len(r.queues[key]) == 0 {
+				r.deficit[key] = 0
+				r.order = r.order[1:]
+				r.visited = false
+			}
+			return front
+		}
+		r.order = append(r.order[1:], key)
+		r.visited = false
+	}
+}
+
+func TestFairQueue_RandomizedModel(t *testing.T) {
+	rng := rand.New(rand.NewPCG(31, 32))
+	tenants := []string{"a", "b", "c", "d"}
+	fq := NewFairQueue(jobTenant, WithCost(func(j job) int { return j.cost }))
+	model := &referenceDRR{weights: map[string]int{}, queues: map[string][]job{}, deficit: map[string]int{}}
+	for _, tenant := range tenants {
+		weight := 1 + rng.IntN(5)
+		_ = fq.SetWeight(tenant, weight)
+		model.weights[tenant] = weight
+	}
+
+	size := 0
+	for step := range 5000 {
+		if size == 0 || rng.IntN(2) == 0 {
+			j := job{tenant: tenants[rng.IntN(len(tenants))], id: step, cost: 1 + rng.IntN(8)}
+			_ = fq.Offer(j)
+			model.offer(j)
+			size++
+			continue
+		}
+		peeked, err := fq.Peek()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := fq.Poll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := model.poll()
+		size--
+		if got != expected || peeked != got {
+			t.Fatalf("step %d: Peek = %+v, Poll = %+v, expected %+v", step, peeked, got, expected)
+		}
+		if fq.Size() != size {
+			t.Fatalf("step %d: Size = %d, expected %d", step, fq.Size(), size)
+		}
+	}
+}
@@ -2,6 +2,8 @@ package linkedlist_queue
 
 import (
 	"fmt"
+
+	"github.com/Scanf-s/goods"
 	"github.com/Scanf-s/goods/list/linkedlist"
 	"github.com/Scanf-s/goods/queue"
 )
@@ -10,7 +12,7 @@ type LinkedListQueue[T any] struct {
 	list *linkedlist.SinglyLinkedList[T]
 }
 
-var _ queue.Queue[int] = (*LinkedListQueue[int])(nil)
+var _ queue.BatchQueue[int] = (*LinkedListQueue[int])(nil)
 
 // NewLinkedListQueue initializes the queue that uses linked list for its base data storage.
 func NewLinkedListQueue[T any]() *LinkedListQueue[T] {
@@ -21,11 +23,11 @@ func NewLinkedListQueue[T any]() *LinkedListQueue[T] {
 
 func (llq *LinkedListQueue[T]) Offer(value T) error {
 	if llq == nil {
-		return fmt.Errorf("queue is nil")
+		return fmt.Errorf("queue is nil: %w", goods.ErrNotInitialized)
 	}
 	err := llq.list.Append(value)
 	if err != nil {
-		return fmt.Errorf("failed to append new value into the queue")
+		return fmt.Errorf("failed to append new value into the queue: %w", err)
 	}
 	return nil
 }
@@ -33,12 +35,12 @@ func (llq *LinkedListQueue[T]) Offer(value T) error {
 func (llq *LinkedListQueue[T]) Peek() (T, error) {
 	var element T
 	if llq == nil {
-		return element, fmt.Errorf("queue is nil")
+		return element, fmt.Errorf("queue is nil: %w", goods.ErrNotInitialized)
 	}
 
 	element, err := llq.list.Head()
 	if err != nil {
-		return element, fmt.Errorf("cannot peek front element from the queue")
+		return element, fmt.Errorf("cannot peek front element from the queue: %w", err)
 	}
 	return element, nil
 }
@@ -46,16 +48,52 @@ func (llq *LinkedListQueue[T]) Peek() (T, error) {
 func (llq *LinkedListQueue[T]) Poll() (T, error) {
 	var element T
 	if llq == nil {
-		return element, fmt.Errorf("queue is nil")
+		return element, fmt.Errorf("queue is nil: %w", goods.ErrNotInitialized)
 	}
 
 	element, err := llq.list.PopHead()
 	if err != nil {
-		return element, fmt.Errorf("cannot poll front element from the queue")
+		return element, fmt.Errorf("cannot poll front element from the queue: %w", err)
 	}
 	return element, nil
 }
 
+// OfferAll adds the elements to the back of the queue in order, splicing them
+// onto the tail of the list as one chain.
+// Time Complexity: O(k) where k is the number of elements
+func (llq *LinkedListQueue[T]) OfferAll(elements ...T) error {
+	if llq == nil {
+		return fmt.Errorf("queue is nil: %w", goods.ErrNotInitialized)
+	}
+	if err := llq.list.AppendAll(elements...); err != nil {
+		return fmt.Errorf("failed to append new values into the queue: %w", err)
+	}
+	return nil
+}
+
+// PollN removes up to n elements from the front and returns them in FIFO order.
+// It returns nil if the queue is empty or n is not positive.
+// Time Complexity: O(k) where k is the number of elements returned
+func (llq *LinkedListQueue[T]) PollN(n int) []T {
+	if llq == nil || n <= 0 || llq.list.IsEmpty() {
+		return nil
+	}
+	result := make([]T, min(n, llq.list.Size()))
+	llq.list.PopHeadInto(result)
+	return result
+}
+
+// DrainTo removes up to len(dst) elements from the front, copies them into dst
+// in FIFO order and returns how many were copied. The removed nodes are cut off
+// the list in one step.
+// Time Complexity: O(k) where k is the number of elements copied
+func (llq *LinkedListQueue[T]) DrainTo(dst []T) int {
+	if llq == nil {
+		return 0
+	}
+	return llq.list.PopHeadInto(dst)
+}
+
 func (llq *LinkedListQueue[T]) Size() int {
 	if llq == nil {
 		return 0
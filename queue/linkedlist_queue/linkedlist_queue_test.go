@@ -1,6 +1,13 @@
 package linkedlist_queue
 
-import "testing"
+import (
+	"errors"
+	"testing"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/queue"
+	"github.com/Scanf-s/goods/queue/queuetest"
+)
 
 func TestLinkedListQueue_New(t *testing.T) {
 	queue := NewLinkedListQueue[int]()
@@ -48,3 +55,34 @@ func TestLinkedListQueue_Offer_Poll_Integers(t *testing.T) {
 		t.Errorf("Poll expected 3, got %d", val)
 	}
 }
+
+func TestLinkedListQueue_Errors(t *testing.T) {
+	q := NewLinkedListQueue[int]()
+
+	if _, err := q.Peek(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Peek on empty queue error = %v, expected goods.ErrEmpty", err)
+	}
+	if _, err := q.Poll(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Poll on empty queue error = %v, expected goods.ErrEmpty", err)
+	}
+
+	var nilQueue *LinkedListQueue[int]
+	if err := nilQueue.Offer(1); !errors.Is(err, goods.ErrNotInitialized) {
+		t.Errorf("Offer on uninitialized queue error = %v, expected goods.ErrNotInitialized", err)
+	}
+	if _, err := nilQueue.Poll(); !errors.Is(err, goods.ErrNotInitialized) {
+		t.Errorf("Poll on uninitialized queue error = %v, expected goods.ErrNotInitialized", err)
+	}
+}
+
+func TestLinkedListQueue_QueueSuite(t *testing.T) {
+	queuetest.RunQueueSuite(t, func() queue.Queue[int] {
+		return NewLinkedListQueue[int]()
+	})
+}
+
+func TestLinkedListQueue_BatchQueueSuite(t *testing.T) {
+	queuetest.RunBatchQueueSuite(t, func() queue.BatchQueue[int] {
+		return NewLinkedListQueue[int]()
+	})
+}
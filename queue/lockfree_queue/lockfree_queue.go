@@ -0,0 +1,29 @@
+// Package lockfreequeue implements bounded FIFO queues that are safe for
+// concurrent use without mutexes.
+//
+// MPMCQueue accepts any number of producers and consumers. SPSCQueue is faster
+// but allows exactly one producer goroutine and one consumer goroutine.
+// Both store elements in a ring buffer whose size is a power of two, so that a
+// position maps to a slot with a bit mask instead of a division.
+package lockfreequeue
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/Scanf-s/goods"
+)
+
+// cacheLineSize is the padding used to keep independently updated counters on
+// separate cache lines, so producers and consumers do not invalidate each other.
+const cacheLineSize = 64
+
+// ringSize returns the smallest power of two that is at least capacity and at least minSize.
+// Returns an error wrapping goods.ErrCapacity if capacity is not positive.
+func ringSize(capacity, minSize int) (uint64, error) {
+	if capacity <= 0 {
+		return 0, fmt.Errorf("capacity must be positive, got %d: %w", capacity, goods.ErrCapacity)
+	}
+	capacity = max(capacity, minSize)
+	return 1 << bits.Len64(uint64(capacity-1)), nil
+}
@@ -0,0 +1,382 @@
+package lockfreequeue
+
+import (
+	"errors"
+	"runtime"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/queue"
+	linkedlistqueue "github.com/Scanf-s/goods/queue/linkedlist_queue"
+	"github.com/Scanf-s/goods/queue/queuetest"
+)
+
+func TestMPMCQueue_QueueSuite(t *testing.T) {
+	queuetest.RunQueueSuite(t, func() queue.Queue[int] {
+		q, err := NewMPMCQueue[int](4096)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return q
+	})
+}
+
+func TestSPSCQueue_QueueSuite(t *testing.T) {
+	queuetest.RunQueueSuite(t, func() queue.Queue[int] {
+		q, err := NewSPSCQueue[int](4096)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return q
+	})
+}
+
+func TestRingSize(t *testing.T) {
+	testCases := []struct{ capacity, minSize, expected int }{
+		{1, 1, 1}, {1, 2, 2}, {3, 1, 4}, {4, 1, 4}, {5, 2, 8}, {1000, 2, 1024},
+	}
+	for _, tc := range testCases {
+		size, err := ringSize(tc.capacity, tc.minSize)
+		if err != nil || int(size) != tc.expected {
+			t.Errorf("ringSize(%d, %d) = %d (err %v), expected %d", tc.capacity, tc.minSize, size, err, tc.expected)
+		}
+	}
+	if _, err := NewMPMCQueue[int](0); !errors.Is(err, goods.ErrCapacity) {
+		t.Errorf("NewMPMCQueue(0) error = %v, expected goods.ErrCapacity", err)
+	}
+	if _, err := NewSPSCQueue[int](-1); !errors.Is(err, goods.ErrCapacity) {
+		t.Errorf("NewSPSCQueue(-1) error = %v, expected goods.ErrCapacity", err)
+	}
+}
+
+func TestLockFreeQueues_Full(t *testing.T) {
+	mpmc, _ := NewMPMCQueue[int](4)
+	spsc, _ := NewSPSCQueue[int](4)
+	for _, q := range []queue.Queue[int]{mpmc, spsc} {
+		for i := range 4 {
+			if err := q.Offer(i); err != nil {
+				t.Fatalf("%T: Offer(%d) failed: %s", q, i, err)
+			}
+		}
+		if err := q.Offer(4); !errors.Is(err, goods.ErrFull) {
+			t.Errorf("%T: Offer on full ring error = %v, expected goods.ErrFull", q, err)
+		}
+		if q.Size() != 4 {
+			t.Errorf("%T: Size = %d, expected 4", q, q.Size())
+		}
+		// Freeing one slot makes room again, with the ring wrapped around
+		if v, err := q.Poll(); err != nil || v != 0 {
+			t.Errorf("%T: Poll = %d (err %v), expected 0", q, v, err)
+		}
+		if err := q.Offer(4); err != nil {
+			t.Errorf("%T: Offer after Poll failed: %s", q, err)
+		}
+	}
+}
+
+func TestMPMCQueue_Peek(t *testing.T) {
+	q, _ := NewMPMCQueue[int](2)
+	if _, err := q.Peek(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Peek on empty ring error = %v, expected goods.ErrEmpty", err)
+	}
+	// Wrap the ring around so the front slot is not the first one
+	for i := range 3 {
+		_ = q.Offer(i)
+		if i < 2 {
+			_, _ = q.Poll()
+		}
+	}
+	if v, err := q.Peek(); err != nil || v != 2 {
+		t.Errorf("Peek = %d (err %v), expected 2", v, err)
+	}
+	if q.Size() != 1 {
+		t.Errorf("Peek must not change the size, got %d, expected 1", q.Size())
+	}
+}
+
+// TestMPMCQueue_ConcurrentPeek peeks while producers and consumers run. Every
+// peeked element must be one that was offered, and no element may be lost or
+// consumed twice because of a Peek.
+func TestMPMCQueue_ConcurrentPeek(t *testing.T) {
+	const producers, perProducer = 2, 5000
+	q, _ := NewMPMCQueue[string](8)
+
+	var wg sync.WaitGroup
+	for p := range producers {
+		wg.Go(func() {
+			for i := 0; i < perProducer; {
+				if q.Offer(strconv.Itoa(p*perProducer+i)) == nil {
+					i++
+				} else {
+					runtime.Gosched()
+				}
+			}
+		})
+	}
+
+	done := make(chan struct{})
+	var peekers sync.WaitGroup
+	for range 2 {
+		peekers.Go(func() {
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				if v, err := q.Peek(); err == nil {
+					if n, err := strconv.Atoi(v); err != nil || n < 0 || n >= producers*perProducer {
+						t.Errorf("Peek = %q, which was never offered", v)
+					}
+				}
+				runtime.Gosched()
+			}
+		})
+	}
+
+	seen := make([]bool, producers*perProducer)
+	for polled := 0; polled < len(seen); {
+		v, err := q.Poll()
+		if err != nil {
+			runtime.Gosched()
+			continue
+		}
+		n, _ := strconv.Atoi(v)
+		if seen[n] {
+			t.Fatalf("element %d consumed twice", n)
+		}
+		seen[n] = true
+		polled++
+	}
+	close(done)
+	wg.Wait()
+	peekers.Wait()
+	if !q.IsEmpty() {
+		t.Errorf("Size = %d after draining, expected 0", q.Size())
+	}
+}
+
+// TestMPMCQueue_Stress runs several producers and consumers on a small ring.
+// Every element must be consumed exactly once, and each consumer must see the
+// elements of any single producer in the order they were offered.
+func TestMPMCQueue_Stress(t *testing.T) {
+	const producers, consumers, perProducer = 4, 4, 5000
+	q, _ := NewMPMCQueue[[2]int](64)
+
+	var producerWG sync.WaitGroup
+	for p := range producers {
+		producerWG.Go(func() {
+			for i := 0; i < perProducer; {
+				if q.Offer([2]int{p, i}) == nil {
+					i++
+				} else {
+					runtime.Gosched()
+				}
+			}
+		})
+	}
+
+	var (
+		done       = make(chan struct{})
+		consumerWG sync.WaitGroup
+		mu         sync.Mutex
+		seen       = make([][]bool, producers)
+	)
+	for p := range seen {
+		seen[p] = make([]bool, perProducer)
+	}
+	for range consumers {
+		consumerWG.Go(func() {
+			last := make([]int, producers)
+			for p := range last {
+				last[p] = -1
+			}
+			for {
+				v, err := q.Poll()
+				if err != nil {
+					select {
+					case <-done:
+						if q.IsEmpty() {
+							return
+						}
+					default:
+						runtime.Gosched()
+					}
+					continue
+				}
+				p, i := v[0], v[1]
+				if i <= last[p] {
+					t.Errorf("consumer saw producer %d element %d after %d", p, i, last[p])
+				}
+				last[p] = i
+				mu.Lock()
+				if seen[p][i] {
+					t.Errorf("producer %d element %d consumed twice", p, i)
+				}
+				seen[p][i] = true
+				mu.Unlock()
+			}
+		})
+	}
+
+	producerWG.Wait()
+	close(done)
+	consumerWG.Wait()
+	for p := range seen {
+		for i, ok := range seen[p] {
+			if !ok {
+				t.Fatalf("producer %d element %d was never consumed", p, i)
+			}
+		}
+	}
+}
+
+// TestSPSCQueue_Stress streams elements through a small ring between one
+// producer and one consumer, which must receive them in order.
+func TestSPSCQueue_Stress(t *testing.T) {
+	const count = 50000
+	q, _ := NewSPSCQueue[int](16)
+
+	go func() {
+		for i := 0; i < count; {
+			if q.Offer(i) == nil {
+				i++
+			} else {
+				runtime.Gosched()
+			}
+		}
+	}()
+
+	for expected := 0; expected < count; {
+		v, err := q.Poll()
+		if err != nil {
+			runtime.Gosched()
+			continue
+		}
+		if v != expected {
+			t.Fatalf("Poll = %d, expected %d", v, expected)
+		}
+		expected++
+	}
+}
+
+// mutexQueue is the baseline the lock-free queues are measured against:
+// a LinkedListQueue guarded by a mutex.
+type mutexQueue[T any] struct {
+	mu    sync.Mutex
+	queue *linkedlistqueue.LinkedListQueue[T]
+}
+
+func (q *mutexQueue[T]) Offer(value T) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.queue.Offer(value)
+}
+
+func (q *mutexQueue[T]) Poll() (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.queue.Poll()
+}
+
+// chanQueue adapts a buffered channel to Offer/Poll for the benchmarks.
+type chanQueue[T any] chan T
+
+func (q chanQueue[T]) Offer(value T) error {
+	select {
+	case q <- value:
+		return nil
+	default:
+		return goods.ErrFull
+	}
+}
+
+func (q chanQueue[T]) Poll() (T, error) {
+	select {
+	case v := <-q:
+		return v, nil
+	default:
+		var zero T
+		return zero, goods.ErrEmpty
+	}
+}
+
+type offerPoller interface {
+	Offer(int) error
+	Poll() (int, error)
+}
+
+const benchCapacity = 1024
+
+type benchmarkQueue struct {
+	name     string
+	newQueue func() offerPoller
+}
+
+func benchmarkQueues() []benchmarkQueue {
+	return []benchmarkQueue{
+		{"MPMC", func() offerPoller {
+			q, _ := NewMPMCQueue[int](benchCapacity)
+			return q
+		}},
+		{"MutexLinkedListQueue", func() offerPoller {
+			return &mutexQueue[int]{queue: linkedlistqueue.NewLinkedListQueue[int]()}
+		}},
+		{"Channel", func() offerPoller {
+			return make(chanQueue[int], benchCapacity)
+		}},
+	}
+}
+
+// BenchmarkMPMC has every goroutine alternate Offer and Poll on one shared queue.
+func BenchmarkMPMC(b *testing.B) {
+	for _, bq := range benchmarkQueues() {
+		b.Run(bq.name, func(b *testing.B) {
+			q := bq.newQueue()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					for q.Offer(1) != nil {
+						runtime.Gosched()
+					}
+					for {
+						if _, err := q.Poll(); err == nil {
+							break
+						}
+						runtime.Gosched()
+					}
+				}
+			})
+		})
+	}
+}
+
+// BenchmarkSPSC streams b.N elements from one producer goroutine to one consumer.
+func BenchmarkSPSC(b *testing.B) {
+	queues := append(benchmarkQueues(), benchmarkQueue{"SPSC", func() offerPoller {
+		q, _ := NewSPSCQueue[int](benchCapacity)
+		return q
+	}})
+	for _, bq := range queues {
+		b.Run(bq.name, func(b *testing.B) {
+			q := bq.newQueue()
+			go func() {
+				for i := 0; i < b.N; {
+					if q.Offer(i) == nil {
+						i++
+					} else {
+						runtime.Gosched()
+					}
+				}
+			}()
+			for received := 0; received < b.N; {
+				if _, err := q.Poll(); err == nil {
+					received++
+				} else {
+					runtime.Gosched()
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package lockfreequeue
+
+import (
+	"fmt"
+	"runtime"
+	"sync/atomic"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/queue"
+)
+
+// cell is one slot of the MPMC ring buffer.
+type cell[T any] struct {
+	// sequence tells producers and consumers whose turn it is to use the slot.
+	// For position pos mapped to this slot, sequence == pos means the slot is free
+	// for the producer of pos, and sequence == pos+1 means it holds that element.
+	sequence atomic.Uint64
+
+	// data is the stored element; it is published and consumed through sequence
+	data T
+}
+
+// peeking is set in dequeuePos while a Peek copies the front element. No
+// consumer can claim the position until Peek clears it again.
+const peeking = 1 << 63
+
+// MPMCQueue is a bounded multi-producer/multi-consumer FIFO queue, following
+// Dmitry Vyukov's sequence-number ring buffer design.
+//
+// Producers and consumers each claim a position with a single CAS on their own
+// counter and then synchronize with each other only through the claimed slot's
+// sequence number, so Offer never waits for another goroutine and fails with
+// goods.ErrFull instead of blocking when the ring is full. Poll is lock-free as
+// long as nobody calls Peek: a Peek holds consumers back for the time it takes
+// to copy the front element, so a Peek that is preempted at that moment stalls
+// every Poll until it resumes.
+type MPMCQueue[T any] struct {
+	_ [cacheLineSize]byte
+
+	// enqueuePos is the next position producers claim
+	enqueuePos atomic.Uint64
+	_          [cacheLineSize - 8]byte
+
+	// dequeuePos is the next position consumers claim, with the peeking bit
+	// set while a Peek is in progress
+	dequeuePos atomic.Uint64
+	_          [cacheLineSize - 8]byte
+
+	// buffer is the ring; its length is a power of two
+	buffer []cell[T]
+
+	// mask maps a position to its slot: pos & mask == pos % len(buffer)
+	mask uint64
+}
+
+// Compile-time check that MPMCQueue implements the queue.Queue interface.
+var _ queue.Queue[int] = (*MPMCQueue[int])(nil)
+
+// NewMPMCQueue returns an empty MPMCQueue holding at least capacity elements.
+// The capacity is rounded up to a power of two (and to at least 2).
+// Returns an error wrapping goods.ErrCapacity if capacity is not positive.
+// Time Complexity: O(capacity)
+func NewMPMCQueue[T any](capacity int) (*MPMCQueue[T], error) {
+	size, err := ringSize(capacity, 2)
+	if err != nil {
+		return nil, err
+	}
+	q := &MPMCQueue[T]{
+		buffer: make([]cell[T], size),
+		mask:   size - 1,
+	}
+	for i := range q.buffer {
+		q.buffer[i].sequence.Store(uint64(i))
+	}
+	return q, nil
+}
+
+// Offer adds an element to the back of the queue without blocking.
+// Returns an error wrapping goods.ErrFull if the ring is full.
+// Time Complexity: O(1), lock-free
+func (q *MPMCQueue[T]) Offer(value T) error {
+	if q == nil || q.buffer == nil {
+		return fmt.Errorf("please call NewMPMCQueue() first: %w", goods.ErrNotInitialized)
+	}
+	pos := q.enqueuePos.Load()
+	for {
+		c := &q.buffer[pos&q.mask]
+		diff := int64(c.sequence.Load() - pos)
+		switch {
+		case diff == 0:
+			// The slot is free for pos: try to claim pos
+			if q.enqueuePos.CompareAndSwap(pos, pos+1) {
+				c.data = value
+				c.sequence.Store(pos + 1)
+				return nil
+			}
+			pos = q.enqueuePos.Load()
+		case diff < 0:
+			// The slot still holds the element from one lap ago
+			return fmt.Errorf("cannot offer element: %w", goods.ErrFull)
+		default:
+			// Another producer claimed pos first
+			pos = q.enqueuePos.Load()
+		}
+	}
+}
+
+// Poll removes and returns the front element. It never waits for producers or
+// other consumers, but waits for a concurrent Peek to finish copying the front
+// element.
+// Returns an error wrapping goods.ErrEmpty if the ring is empty.
+// Time Complexity: O(1), lock-free without concurrent Peeks
+func (q *MPMCQueue[T]) Poll() (T, error) {
+	var element T
+	if q == nil || q.buffer == nil {
+		return element, fmt.Errorf("please call NewMPMCQueue() first: %w", goods.ErrNotInitialized)
+	}
+	pos := q.dequeuePos.Load()
+	for {
+		if pos&peeking != 0 {
+			// A Peek is copying the front element: wait for it to finish
+			runtime.Gosched()
+			pos = q.dequeuePos.Load()
+			continue
+		}
+		c := &q.buffer[pos&q.mask]
+		diff := int64(c.sequence.Load() - (pos + 1))
+		switch {
+		case diff == 0:
+			// The slot holds the element for pos: try to claim pos
+			if q.dequeuePos.CompareAndSwap(pos, pos+1) {
+				element = c.data
+				var zero T
+				c.data = zero
+				// Hand the slot to the producer one lap ahead
+				c.sequence.Store(pos + q.mask + 1)
+				return element, nil
+			}
+			pos = q.dequeuePos.Load()
+		case diff < 0:
+			// No producer has published pos yet
+			return element, fmt.Errorf("cannot poll front element from the queue: %w", goods.ErrEmpty)
+		default:
+			// Another consumer claimed pos first
+			pos = q.dequeuePos.Load()
+		}
+	}
+}
+
+// Peek returns the front element without removing it. Under concurrent use the
+// result is a snapshot: another consumer may poll the element as soon as Peek
+// returns.
+//
+// To read the slot safely, Peek sets the peeking bit in dequeuePos with a CAS,
+// which only succeeds while no consumer has claimed the front position. Polls
+// then wait until Peek has copied the element and cleared the bit, and the
+// producer one lap ahead cannot reuse the slot before it is polled. Peek is
+// therefore not lock-free: if it is preempted while the bit is set, every Poll
+// and Peek spins until it resumes. Avoid it on hot consumer paths.
+// Returns an error wrapping goods.ErrEmpty if the ring is empty.
+// Time Complexity: O(1), but blocks consumers while it copies the element
+func (q *MPMCQueue[T]) Peek() (T, error) {
+	var element T
+	if q == nil || q.buffer == nil {
+		return element, fmt.Errorf("please call NewMPMCQueue() first: %w", goods.ErrNotInitialized)
+	}
+	pos := q.dequeuePos.Load()
+	for {
+		if pos&peeking != 0 {
+			// Another Peek is copying the front element: wait for it to finish
+			runtime.Gosched()
+			pos = q.dequeuePos.Load()
+			continue
+		}
+		c := &q.buffer[pos&q.mask]
+		diff := int64(c.sequence.Load() - (pos + 1))
+		switch {
+		case diff == 0:
+			// The slot holds the element for pos: keep consumers off it
+			if q.dequeuePos.CompareAndSwap(pos, pos|peeking) {
+				element = c.data
+				q.dequeuePos.Store(pos)
+				return element, nil
+			}
+			pos = q.dequeuePos.Load()
+		case diff < 0:
+			// No producer has published pos yet
+			return element, fmt.Errorf("cannot peek front element from the queue: %w", goods.ErrEmpty)
+		default:
+			// A consumer claimed pos first
+			pos = q.dequeuePos.Load()
+		}
+	}
+}
+
+// Size returns the number of elements in the queue. Under concurrent use the
+// result is a snapshot that may already be stale when it is returned.
+// Time Complexity: O(1)
+func (q *MPMCQueue[T]) Size() int {
+	if q == nil || q.buffer == nil {
+		return 0
+	}
+	// Load dequeuePos first: a consumer only claims positions a producer already
+	// claimed, so the later enqueuePos load can never be smaller
+	dequeue := q.dequeuePos.Load() &^ peeking
+	enqueue := q.enqueuePos.Load()
+	return int(min(enqueue-dequeue, uint64(len(q.buffer))))
+}
+
+// IsEmpty returns true if the queue has no elements, subject to the same
+// staleness as Size.
+// Time Complexity: O(1)
+func (q *MPMCQueue[T]) IsEmpty() bool {
+	return q.Size() == 0
+}
+
+// Capacity returns the number of slots in the ring.
+// Time Complexity: O(1)
+func (q *MPMCQueue[T]) Capacity() int {
+	if q == nil {
+		return 0
+	}
+	return len(q.buffer)
+}
@@ -0,0 +1,153 @@
+package lockfreequeue
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/queue"
+)
+
+// SPSCQueue is a bounded single-producer/single-consumer FIFO queue.
+//
+// Only the producer writes tail and only the consumer writes head, so every
+// operation finishes in a bounded number of steps without CAS (wait-free).
+// Each side also caches the last value it saw of the other side's counter and
+// only reloads it when the ring looks full or empty, which keeps the shared
+// cache lines quiet on the fast path.
+//
+// Offer must only be called from one producer goroutine, and Poll and Peek
+// only from one consumer goroutine. Size and IsEmpty may be called from anywhere.
+type SPSCQueue[T any] struct {
+	_ [cacheLineSize]byte
+
+	// head is the next position to read; written only by the consumer
+	head atomic.Uint64
+
+	// cachedTail is the consumer's last observed tail
+	cachedTail uint64
+	_          [cacheLineSize - 16]byte
+
+	// tail is the next position to write; written only by the producer
+	tail atomic.Uint64
+
+	// cachedHead is the producer's last observed head
+	cachedHead uint64
+	_          [cacheLineSize - 16]byte
+
+	// buffer is the ring; its length is a power of two
+	buffer []T
+
+	// mask maps a position to its slot: pos & mask == pos % len(buffer)
+	mask uint64
+}
+
+// Compile-time check that SPSCQueue implements the queue.Queue interface.
+var _ queue.Queue[int] = (*SPSCQueue[int])(nil)
+
+// NewSPSCQueue returns an empty SPSCQueue holding at least capacity elements.
+// The capacity is rounded up to a power of two.
+// Returns an error wrapping goods.ErrCapacity if capacity is not positive.
+// Time Complexity: O(capacity)
+func NewSPSCQueue[T any](capacity int) (*SPSCQueue[T], error) {
+	size, err := ringSize(capacity, 1)
+	if err != nil {
+		return nil, err
+	}
+	return &SPSCQueue[T]{
+		buffer: make([]T, size),
+		mask:   size - 1,
+	}, nil
+}
+
+// Offer adds an element to the back of the queue. Producer only.
+// Returns an error wrapping goods.ErrFull if the ring is full.
+// Time Complexity: O(1), wait-free
+func (q *SPSCQueue[T]) Offer(value T) error {
+	if q == nil || q.buffer == nil {
+		return fmt.Errorf("please call NewSPSCQueue() first: %w", goods.ErrNotInitialized)
+	}
+	tail := q.tail.Load()
+	if tail-q.cachedHead == uint64(len(q.buffer)) {
+		q.cachedHead = q.head.Load()
+		if tail-q.cachedHead == uint64(len(q.buffer)) {
+			return fmt.Errorf("cannot offer element: %w", goods.ErrFull)
+		}
+	}
+	q.buffer[tail&q.mask] = value
+	q.tail.Store(tail + 1)
+	return nil
+}
+
+// Poll removes and returns the front element. Consumer only.
+// Returns an error wrapping goods.ErrEmpty if the ring is empty.
+// Time Complexity: O(1), wait-free
+func (q *SPSCQueue[T]) Poll() (T, error) {
+	var element T
+	head, err := q.front()
+	if err != nil {
+		return element, err
+	}
+	slot := &q.buffer[head&q.mask]
+	element = *slot
+	var zero T
+	*slot = zero
+	q.head.Store(head + 1)
+	return element, nil
+}
+
+// Peek returns the front element without removing it. Consumer only.
+// Returns an error wrapping goods.ErrEmpty if the ring is empty.
+// Time Complexity: O(1), wait-free
+func (q *SPSCQueue[T]) Peek() (T, error) {
+	var element T
+	head, err := q.front()
+	if err != nil {
+		return element, err
+	}
+	return q.buffer[head&q.mask], nil
+}
+
+// Size returns the number of elements in the queue. Under concurrent use the
+// result is a snapshot that may already be stale when it is returned.
+// Time Complexity: O(1)
+func (q *SPSCQueue[T]) Size() int {
+	if q == nil || q.buffer == nil {
+		return 0
+	}
+	// Load head first: tail only grows, so tail-head can never go negative
+	head := q.head.Load()
+	tail := q.tail.Load()
+	return int(min(tail-head, uint64(len(q.buffer))))
+}
+
+// IsEmpty returns true if the queue has no elements, subject to the same
+// staleness as Size.
+// Time Complexity: O(1)
+func (q *SPSCQueue[T]) IsEmpty() bool {
+	return q.Size() == 0
+}
+
+// Capacity returns the number of slots in the ring.
+// Time Complexity: O(1)
+func (q *SPSCQueue[T]) Capacity() int {
+	if q == nil {
+		return 0
+	}
+	return len(q.buffer)
+}
+
+// front returns the position of the front element for the consumer.
+func (q *SPSCQueue[T]) front() (uint64, error) {
+	if q == nil || q.buffer == nil {
+		return 0, fmt.Errorf("please call NewSPSCQueue() first: %w", goods.ErrNotInitialized)
+	}
+	head := q.head.Load()
+	if head == q.cachedTail {
+		q.cachedTail = q.tail.Load()
+		if head == q.cachedTail {
+			return 0, fmt.Errorf("cannot read front element from the queue: %w", goods.ErrEmpty)
+		}
+	}
+	return head, nil
+}
@@ -0,0 +1,120 @@
+package persistentqueue
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/queue"
+)
+
+// Codec converts elements to and from the bytes stored by a PersistentQueue.
+type Codec[T any] interface {
+	Encode(value T) ([]byte, error)
+	Decode(data []byte) (T, error)
+}
+
+// JSONCodec is a Codec that stores elements as JSON.
+type JSONCodec[T any] struct{}
+
+// Encode returns the JSON encoding of value.
+func (JSONCodec[T]) Encode(value T) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+// Decode parses the JSON in data.
+func (JSONCodec[T]) Decode(data []byte) (T, error) {
+	var value T
+	err := json.Unmarshal(data, &value)
+	return value, err
+}
+
+// CodecQueue is a durable FIFO queue of any element type, storing each element
+// in a PersistentQueue in the form produced by its Codec.
+type CodecQueue[T any] struct {
+	queue *PersistentQueue
+	codec Codec[T]
+}
+
+// Compile-time check that CodecQueue implements the queue.Queue interface.
+var _ queue.Queue[int] = (*CodecQueue[int])(nil)
+
+// NewCodecQueue opens the queue stored in dir like NewPersistentQueue, encoding
+// and decoding its elements with codec.
+func NewCodecQueue[T any](dir string, codec Codec[T], opts ...Option) (*CodecQueue[T], error) {
+	pq, err := NewPersistentQueue(dir, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &CodecQueue[T]{queue: pq, codec: codec}, nil
+}
+
+// Offer encodes an element and appends it to the back of the queue.
+func (cq *CodecQueue[T]) Offer(element T) error {
+	if cq == nil || cq.queue == nil {
+		return fmt.Errorf("please call NewCodecQueue() first: %w", goods.ErrNotInitialized)
+	}
+	data, err := cq.codec.Encode(element)
+	if err != nil {
+		return fmt.Errorf("cannot encode element: %w", err)
+	}
+	return cq.queue.Offer(data)
+}
+
+// Peek returns the decoded front element without removing it.
+// Returns an error wrapping goods.ErrEmpty if the queue is empty.
+func (cq *CodecQueue[T]) Peek() (T, error) {
+	var element T
+	if cq == nil || cq.queue == nil {
+		return element, fmt.Errorf("please call NewCodecQueue() first: %w", goods.ErrNotInitialized)
+	}
+	data, err := cq.queue.Peek()
+	if err != nil {
+		return element, err
+	}
+	return cq.decode(data)
+}
+
+// Poll removes and returns the decoded front element.
+// Returns an error wrapping goods.ErrEmpty if the queue is empty. An element
+// that cannot be decoded is still removed, so it cannot block the queue.
+func (cq *CodecQueue[T]) Poll() (T, error) {
+	var element T
+	if cq == nil || cq.queue == nil {
+		return element, fmt.Errorf("please call NewCodecQueue() first: %w", goods.ErrNotInitialized)
+	}
+	data, err := cq.queue.Poll()
+	if err != nil {
+		return element, err
+	}
+	return cq.decode(data)
+}
+
+// Size returns the number of elements in the queue.
+func (cq *CodecQueue[T]) Size() int {
+	if cq == nil {
+		return 0
+	}
+	return cq.queue.Size()
+}
+
+// IsEmpty returns true if the queue has no elements.
+func (cq *CodecQueue[T]) IsEmpty() bool {
+	return cq.Size() == 0
+}
+
+// Close closes the underlying PersistentQueue.
+func (cq *CodecQueue[T]) Close() error {
+	if cq == nil {
+		return nil
+	}
+	return cq.queue.Close()
+}
+
+func (cq *CodecQueue[T]) decode(data []byte) (T, error) {
+	element, err := cq.codec.Decode(data)
+	if err != nil {
+		return element, fmt.Errorf("cannot decode element: %w", err)
+	}
+	return element, nil
+}
@@ -0,0 +1,631 @@
+// Package persistentqueue implements a FIFO queue stored on disk, so its
+// contents survive a restart or a crash of the process.
+//
+// The queue is a write-ahead log split into segment files. Each Offer appends a
+// checksummed record to the newest segment, and a separate offset file records
+// how many elements the consumer has polled. Segments whose records have all
+// been polled are deleted.
+//
+// On disk, a queue directory holds:
+//
+//	00000000000000000000.seg   records with sequence numbers 0, 1, ...
+//	00000000000000001234.seg   records starting at sequence number 1234
+//	consumer.offset            sequence number of the next record to poll
+//
+// A record is a 4-byte little-endian payload length, a 4-byte CRC-32C of the
+// payload and the payload itself. The offset file is an 8-byte little-endian
+// sequence number followed by its CRC-32C.
+package persistentqueue
+
+import (
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/queue"
+)
+
+// ErrCorrupt is returned when the files of a queue fail their checksums in a
+// way that recovery cannot repair, such as a damaged record in the middle of
+// the log or a missing segment.
+var ErrCorrupt = errors.New("persistent queue is corrupt")
+
+// ErrTooLarge is returned when an element is larger than a record can hold.
+var ErrTooLarge = errors.New("element is too large")
+
+const (
+	segmentExt     = ".seg"
+	offsetFileName = "consumer.offset"
+
+	// headerSize is the size of the length and checksum in front of every record
+	headerSize = 8
+
+	// offsetFileSize is the size of the sequence number and its checksum
+	offsetFileSize = 12
+
+	// maxRecordSize bounds the payload length read from disk, so that a damaged
+	// length field cannot trigger a huge allocation
+	maxRecordSize = 1 << 30
+
+	defaultSegmentSize = 64 << 20
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// segment describes one segment file.
+type segment struct {
+	// base is the sequence number of the first record in the segment
+	base uint64
+
+	// count is the number of records in the segment
+	count uint64
+
+	// size is the length of the segment file in bytes
+	size int64
+
+	// path is the location of the segment file
+	path string
+}
+
+// end returns the sequence number just past the last record of the segment.
+func (s *segment) end() uint64 {
+	return s.base + s.count
+}
+
+// PersistentQueue is a durable FIFO queue of byte slices.
+//
+// Elements are identified by a sequence number that grows by one with each
+// Offer. head is the sequence number of the front element and tail the one the
+// next Offer will use, so the queue holds tail - head elements. Poll advances
+// head and persists it in the offset file; after a restart the queue resumes
+// from the last persisted head.
+//
+// For at-least-once processing, Peek an element, process it and only then
+// Poll it: if the process crashes in between, the element is still queued
+// when the queue is opened again.
+//
+// PersistentQueue is safe for concurrent use. Only one PersistentQueue may use
+// a directory at a time.
+type PersistentQueue struct {
+	// mu guards every field below
+	mu sync.Mutex
+
+	// dir is the directory holding the queue files
+	dir string
+
+	// segments lists the segment files, oldest first. After compaction the
+	// first segment always contains head, and the last one is written to.
+	segments []*segment
+
+	// writer appends to the last segment
+	writer *os.File
+
+	// reader reads from the first segment
+	reader *os.File
+
+	// readPos is the byte offset of the head record in the first segment
+	readPos int64
+
+	// head is the sequence number of the front element
+	head uint64
+
+	// tail is the sequence number the next Offer will use
+	tail uint64
+
+	// offsetFile stores head
+	offsetFile *os.File
+
+	// closed is set by Close
+	closed bool
+
+	// segmentSize and sync are the options the queue was opened with
+	segmentSize int64
+	sync        bool
+}
+
+// Compile-time check that PersistentQueue implements the queue.Queue interface.
+var _ queue.Queue[[]byte] = (*PersistentQueue)(nil)
+
+// Option configures a PersistentQueue created by NewPersistentQueue.
+type Option func(*options)
+
+type options struct {
+	segmentSize int64
+	sync        bool
+}
+
+// WithSegmentSize makes the queue start a new segment file once the current
+// one reaches size bytes. Smaller segments are deleted sooner after their
+// elements are polled. The default is 64 MiB.
+func WithSegmentSize(size int64) Option {
+	return func(o *options) {
+		o.segmentSize = size
+	}
+}
+
+// WithoutSync stops the queue from calling fsync after every Offer and Poll.
+// It is much faster, but elements offered or polled shortly before an
+// operating-system crash or power loss may be lost or delivered again. A crash
+// of the process alone loses nothing.
+func WithoutSync() Option {
+	return func(o *options) {
+		o.sync = false
+	}
+}
+
+// NewPersistentQueue opens the queue stored in dir, creating the directory if
+// it does not exist, and recovers its state. A record left half-written by a
+// crash at the end of the newest segment is discarded.
+// Returns an error wrapping ErrCorrupt if the files are damaged beyond that,
+// or goods.ErrCapacity if the segment size is not positive.
+// Time Complexity: O(n) in the number of records on disk
+func NewPersistentQueue(dir string, opts ...Option) (*PersistentQueue, error) {
+	o := options{segmentSize: defaultSegmentSize, sync: true}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.segmentSize <= 0 {
+		return nil, fmt.Errorf("segment size must be positive, got %d: %w", o.segmentSize, goods.ErrCapacity)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("cannot create the queue directory: %w", err)
+	}
+
+	pq := &PersistentQueue{dir: dir, segmentSize: o.segmentSize, sync: o.sync}
+	if err := pq.recover(); err != nil {
+		pq.closeFiles()
+		return nil, err
+	}
+	return pq, nil
+}
+
+// Offer appends an element to the back of the queue. With the default options
+// the element is on stable storage when Offer returns.
+// Returns an error wrapping goods.ErrClosed if the queue is closed, or
+// ErrTooLarge if element is larger than 1 GiB.
+// Time Complexity: O(1) plus the cost of the write
+func (pq *PersistentQueue) Offer(element []byte) error {
+	if pq == nil {
+		return fmt.Errorf("please call NewPersistentQueue() first: %w", goods.ErrNotInitialized)
+	}
+	if len(element) > maxRecordSize {
+		return fmt.Errorf("element of %d bytes exceeds the limit of %d bytes: %w", len(element), maxRecordSize, ErrTooLarge)
+	}
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	if err := pq.checkOpen(); err != nil {
+		return err
+	}
+
+	active := pq.segments[len(pq.segments)-1]
+	if active.size >= pq.segmentSize && active.count > 0 {
+		if err := pq.rollSegment(); err != nil {
+			return err
+		}
+		active = pq.segments[len(pq.segments)-1]
+	}
+
+	record := make([]byte, headerSize+len(element))
+	binary.LittleEndian.PutUint32(record[0:4], uint32(len(element)))
+	binary.LittleEndian.PutUint32(record[4:8], crc32.Checksum(element, crcTable))
+	copy(record[headerSize:], element)
+
+	if _, err := pq.writer.Write(record); err != nil {
+		// Cut off whatever part of the record was written, so the next Offer
+		// does not append after a torn record
+		pq.discardAfter(active)
+		return fmt.Errorf("cannot append element: %w", err)
+	}
+	if pq.sync {
+		if err := pq.writer.Sync(); err != nil {
+			// The record was written but may not be durable. Cut it off too, so
+			// that an element Offer reports as failed is not delivered later
+			pq.discardAfter(active)
+			return fmt.Errorf("cannot sync segment: %w", err)
+		}
+	}
+	active.size += int64(len(record))
+	active.count++
+	pq.tail++
+	return nil
+}
+
+// Peek returns the front element without removing it.
+// Returns an error wrapping goods.ErrEmpty if the queue is empty.
+// Time Complexity: O(1) plus the cost of the read
+func (pq *PersistentQueue) Peek() ([]byte, error) {
+	if pq == nil {
+		return nil, fmt.Errorf("please call NewPersistentQueue() first: %w", goods.ErrNotInitialized)
+	}
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	if err := pq.checkOpen(); err != nil {
+		return nil, err
+	}
+	if pq.head == pq.tail {
+		return nil, fmt.Errorf("cannot peek front element from the queue: %w", goods.ErrEmpty)
+	}
+	// Finish a compaction that failed in an earlier Poll, which may have left
+	// the reader behind head
+	if err := pq.compact(); err != nil {
+		return nil, err
+	}
+	element, _, err := readRecord(pq.reader, pq.readPos)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read element %d: %w", pq.head, err)
+	}
+	return element, nil
+}
+
+// Poll removes and returns the front element. The new consumer offset is
+// persisted before Poll returns, and segments that no longer hold any queued
+// element are deleted. A failure to delete them does not fail the Poll.
+// Returns an error wrapping goods.ErrEmpty if the queue is empty.
+// Time Complexity: O(1) plus the cost of the read and the offset write
+func (pq *PersistentQueue) Poll() ([]byte, error) {
+	if pq == nil {
+		return nil, fmt.Errorf("please call NewPersistentQueue() first: %w", goods.ErrNotInitialized)
+	}
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	if err := pq.checkOpen(); err != nil {
+		return nil, err
+	}
+	if pq.head == pq.tail {
+		return nil, fmt.Errorf("cannot poll front element from the queue: %w", goods.ErrEmpty)
+	}
+	if err := pq.compact(); err != nil {
+		return nil, err
+	}
+	element, next, err := readRecord(pq.reader, pq.readPos)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read element %d: %w", pq.head, err)
+	}
+	if err := pq.writeOffset(pq.head + 1); err != nil {
+		return nil, err
+	}
+	pq.head++
+	pq.readPos = next
+	// The element is polled once the offset is persisted, so it must be
+	// returned. Deleting the segments it emptied is best effort: the next Peek
+	// or Poll retries a compaction that failed here.
+	_ = pq.compact()
+	return element, nil
+}
+
+// Size returns the number of elements in the queue.
+// Time Complexity: O(1)
+func (pq *PersistentQueue) Size() int {
+	if pq == nil {
+		return 0
+	}
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	return int(pq.tail - pq.head)
+}
+
+// IsEmpty returns true if the queue has no elements.
+// Time Complexity: O(1)
+func (pq *PersistentQueue) IsEmpty() bool {
+	return pq.Size() == 0
+}
+
+// Close syncs and closes the queue files. Every later call returns an error
+// wrapping goods.ErrClosed. Calling Close more than once is a no-op.
+func (pq *PersistentQueue) Close() error {
+	if pq == nil {
+		return nil
+	}
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+	if pq.closed {
+		return nil
+	}
+	pq.closed = true
+	var err error
+	if pq.writer != nil {
+		err = pq.writer.Sync()
+	}
+	return errors.Join(err, pq.closeFiles())
+}
+
+func (pq *PersistentQueue) checkOpen() error {
+	if pq.closed {
+		return fmt.Errorf("queue in %s: %w", pq.dir, goods.ErrClosed)
+	}
+	return nil
+}
+
+// recover rebuilds the in-memory state from the files in pq.dir.
+func (pq *PersistentQueue) recover() error {
+	segments, err := listSegments(pq.dir)
+	if err != nil {
+		return err
+	}
+	if len(segments) == 0 {
+		// A new queue
+		segments = []*segment{{base: 0, path: segmentPath(pq.dir, 0)}}
+		if err := createFile(segments[0].path); err != nil {
+			return err
+		}
+	}
+
+	// Count the records of every segment, cutting a torn record off the last one
+	for i, s := range segments {
+		if i > 0 && segments[i-1].end() != s.base {
+			return fmt.Errorf("segment %s should start at %d: %w", filepath.Base(s.path), segments[i-1].end(), ErrCorrupt)
+		}
+		if err := scanSegment(s, i == len(segments)-1); err != nil {
+			return err
+		}
+	}
+	pq.segments = segments
+	pq.tail = segments[len(segments)-1].end()
+
+	// Resume from the persisted offset, keeping it within the records on disk.
+	// A missing or damaged offset file restarts from the oldest record, which
+	// may deliver elements again but never loses one.
+	pq.offsetFile, err = os.OpenFile(filepath.Join(pq.dir, offsetFileName), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return fmt.Errorf("cannot open the offset file: %w", err)
+	}
+	head, ok := readOffset(pq.offsetFile)
+	if !ok {
+		head = segments[0].base
+	}
+	pq.head = min(max(head, segments[0].base), pq.tail)
+
+	// Drop the segments polled before the crash, then position the reader. A
+	// segment file that cannot be deleted is only wasted space: try again at
+	// the next open rather than refusing to open the queue.
+	_ = pq.compact()
+	first := pq.segments[0]
+	pq.reader, err = os.Open(first.path)
+	if err != nil {
+		return fmt.Errorf("cannot open segment: %w", err)
+	}
+	for range pq.head - first.base {
+		if _, pq.readPos, err = readRecord(pq.reader, pq.readPos); err != nil {
+			return fmt.Errorf("cannot skip polled records: %w", err)
+		}
+	}
+
+	last := pq.segments[len(pq.segments)-1]
+	pq.writer, err = os.OpenFile(last.path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("cannot open segment: %w", err)
+	}
+	return pq.writeOffset(pq.head)
+}
+
+// rollSegment closes the segment being written and starts a new one at tail.
+func (pq *PersistentQueue) rollSegment() error {
+	if err := pq.writer.Sync(); err != nil {
+		return fmt.Errorf("cannot sync segment: %w", err)
+	}
+	s := &segment{base: pq.tail, path: segmentPath(pq.dir, pq.tail)}
+	writer, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return fmt.Errorf("cannot create segment: %w", err)
+	}
+	if err := pq.syncDir(); err != nil {
+		// Delete the new file, or every later roll would fail to create it again
+		_ = writer.Close()
+		_ = os.Remove(s.path)
+		return err
+	}
+	_ = pq.writer.Close()
+	pq.writer = writer
+	pq.segments = append(pq.segments, s)
+	// The roll is done; like in Poll, deleting drained segments is best effort
+	// and the next Peek or Poll retries it
+	_ = pq.compact()
+	return nil
+}
+
+// compact deletes the segments before the one holding head, whose records have
+// all been polled. The segment being written is never deleted.
+//
+// A failure leaves the queue usable. If the reader cannot move to the segment
+// holding head, nothing changes and the next call tries again. A segment file
+// that cannot be deleted is dropped from the queue anyway; being entirely
+// polled, it is deleted by the next NewPersistentQueue on the directory.
+func (pq *PersistentQueue) compact() error {
+	removed := 0
+	for removed < len(pq.segments)-1 && pq.segments[removed].end() <= pq.head {
+		removed++
+	}
+	if removed == 0 {
+		return nil
+	}
+
+	// Move the reader to the segment now holding head, at its first record,
+	// before anything is deleted
+	if pq.reader != nil {
+		reader, err := os.Open(pq.segments[removed].path)
+		if err != nil {
+			return fmt.Errorf("cannot open segment: %w", err)
+		}
+		_ = pq.reader.Close()
+		pq.reader = reader
+		pq.readPos = 0
+	}
+
+	var errs []error
+	for _, s := range pq.segments[:removed] {
+		if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, fmt.Errorf("cannot delete polled segment: %w", err))
+		}
+	}
+	pq.segments = slices.Delete(pq.segments, 0, removed)
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+	return pq.syncDir()
+}
+
+// discardAfter cuts the segment being written back to the size of active, the
+// end of its last complete record.
+func (pq *PersistentQueue) discardAfter(active *segment) {
+	_ = pq.writer.Truncate(active.size)
+	_, _ = pq.writer.Seek(active.size, io.SeekStart)
+}
+
+// writeOffset persists head in the offset file.
+func (pq *PersistentQueue) writeOffset(head uint64) error {
+	var buf [offsetFileSize]byte
+	binary.LittleEndian.PutUint64(buf[0:8], head)
+	binary.LittleEndian.PutUint32(buf[8:12], crc32.Checksum(buf[0:8], crcTable))
+	if _, err := pq.offsetFile.WriteAt(buf[:], 0); err != nil {
+		return fmt.Errorf("cannot write the consumer offset: %w", err)
+	}
+	if pq.sync {
+		if err := pq.offsetFile.Sync(); err != nil {
+			return fmt.Errorf("cannot sync the consumer offset: %w", err)
+		}
+	}
+	return nil
+}
+
+// syncDir makes file creations and deletions in pq.dir durable.
+func (pq *PersistentQueue) syncDir() error {
+	if !pq.sync {
+		return nil
+	}
+	d, err := os.Open(pq.dir)
+	if err != nil {
+		return fmt.Errorf("cannot open the queue directory: %w", err)
+	}
+	defer d.Close()
+	if err := d.Sync(); err != nil {
+		return fmt.Errorf("cannot sync the queue directory: %w", err)
+	}
+	return nil
+}
+
+func (pq *PersistentQueue) closeFiles() error {
+	var errs []error
+	for _, f := range []*os.File{pq.writer, pq.reader, pq.offsetFile} {
+		if f != nil {
+			errs = append(errs, f.Close())
+		}
+	}
+	pq.writer, pq.reader, pq.offsetFile = nil, nil, nil
+	return errors.Join(errs...)
+}
+
+// readRecord reads the record at pos and returns its payload and the position
+// of the next record. Returns an error wrapping ErrCorrupt if the record is
+// incomplete or fails its checksum.
+func readRecord(r io.ReaderAt, pos int64) ([]byte, int64, error) {
+	var header [headerSize]byte
+	if _, err := r.ReadAt(header[:], pos); err != nil {
+		return nil, pos, fmt.Errorf("incomplete record header at byte %d: %w", pos, errors.Join(ErrCorrupt, err))
+	}
+	length := binary.LittleEndian.Uint32(header[0:4])
+	if length > maxRecordSize {
+		return nil, pos, fmt.Errorf("record length %d at byte %d: %w", length, pos, ErrCorrupt)
+	}
+	payload := make([]byte, length)
+	if _, err := r.ReadAt(payload, pos+headerSize); err != nil {
+		return nil, pos, fmt.Errorf("incomplete record at byte %d: %w", pos, errors.Join(ErrCorrupt, err))
+	}
+	if crc32.Checksum(payload, crcTable) != binary.LittleEndian.Uint32(header[4:8]) {
+		return nil, pos, fmt.Errorf("checksum mismatch at byte %d: %w", pos, ErrCorrupt)
+	}
+	return payload, pos + headerSize + int64(length), nil
+}
+
+// scanSegment counts the valid records of s. In the last segment, everything
+// after the last valid record is a write interrupted by a crash and is
+// truncated; in any other segment it means the log is damaged.
+func scanSegment(s *segment, last bool) error {
+	f, err := os.OpenFile(s.path, os.O_RDWR, 0)
+	if err != nil {
+		return fmt.Errorf("cannot open segment: %w", err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("cannot stat segment: %w", err)
+	}
+
+	var pos int64
+	s.count = 0
+	for pos < info.Size() {
+		_, next, err := readRecord(f, pos)
+		if err != nil {
+			if !last {
+				return fmt.Errorf("segment %s: %w", filepath.Base(s.path), err)
+			}
+			if err := f.Truncate(pos); err != nil {
+				return fmt.Errorf("cannot truncate torn record: %w", err)
+			}
+			if err := f.Sync(); err != nil {
+				return fmt.Errorf("cannot sync segment: %w", err)
+			}
+			break
+		}
+		pos = next
+		s.count++
+	}
+	s.size = pos
+	return nil
+}
+
+// readOffset returns the sequence number stored in the offset file, and false
+// if the file is empty or damaged.
+func readOffset(f *os.File) (uint64, bool) {
+	var buf [offsetFileSize]byte
+	if _, err := f.ReadAt(buf[:], 0); err != nil {
+		return 0, false
+	}
+	if crc32.Checksum(buf[0:8], crcTable) != binary.LittleEndian.Uint32(buf[8:12]) {
+		return 0, false
+	}
+	return binary.LittleEndian.Uint64(buf[0:8]), true
+}
+
+// listSegments returns the segment files in dir sorted by base sequence number.
+func listSegments(dir string) ([]*segment, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the queue directory: %w", err)
+	}
+	var segments []*segment
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), segmentExt)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		base, err := strconv.ParseUint(name, 10, 64)
+		if err != nil {
+			continue
+		}
+		segments = append(segments, &segment{base: base, path: filepath.Join(dir, entry.Name())})
+	}
+	slices.SortFunc(segments, func(a, b *segment) int { return cmp.Compare(a.base, b.base) })
+	return segments, nil
+}
+
+func segmentPath(dir string, base uint64) string {
+	return filepath.Join(dir, fmt.Sprintf("%020d%s", base, segmentExt))
+}
+
+func createFile(path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0o644)
+	if err != nil {
+		return fmt.Errorf("cannot create segment: %w", err)
+	}
+	return f.Close()
+}
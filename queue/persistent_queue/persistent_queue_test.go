@@ -0,0 +1,312 @@
+package persistentqueue
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/queue"
+	"github.com/Scanf-s/goods/queue/queuetest"
+)
+
+func TestCodecQueue_QueueSuite(t *testing.T) {
+	queuetest.RunQueueSuite(t, func() queue.Queue[int] {
+		cq, err := NewCodecQueue[int](t.TempDir(), JSONCodec[int]{}, WithSegmentSize(256), WithoutSync())
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = cq.Close() })
+		return cq
+	})
+}
+
+func openQueue(t *testing.T, dir string, opts ...Option) *PersistentQueue {
+	t.Helper()
+	pq, err := NewPersistentQueue(dir, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = pq.Close() })
+	return pq
+}
+
+func offerAll(t *testing.T, pq *PersistentQueue, from, to int) {
+	t.Helper()
+	for i := from; i < to; i++ {
+		if err := pq.Offer(fmt.Appendf(nil, "element-%d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func expectPoll(t *testing.T, pq *PersistentQueue, i int) {
+	t.Helper()
+	element, err := pq.Poll()
+	if expected := fmt.Sprintf("element-%d", i); err != nil || string(element) != expected {
+		t.Fatalf("Poll = %q (err %v), expected %q", element, err, expected)
+	}
+}
+
+func segmentCount(t *testing.T, dir string) int {
+	t.Helper()
+	matches, err := filepath.Glob(filepath.Join(dir, "*"+segmentExt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return len(matches)
+}
+
+func TestPersistentQueue_Reopen(t *testing.T) {
+	dir := t.TempDir()
+	pq := openQueue(t, dir, WithSegmentSize(64))
+	offerAll(t, pq, 0, 10)
+	for i := range 4 {
+		expectPoll(t, pq, i)
+	}
+	if err := pq.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := pq.Offer(nil); !errors.Is(err, goods.ErrClosed) {
+		t.Errorf("Offer after Close error = %v, expected goods.ErrClosed", err)
+	}
+
+	// The consumer offset survives the restart, so polled elements stay gone
+	pq = openQueue(t, dir, WithSegmentSize(64))
+	if pq.Size() != 6 {
+		t.Fatalf("Size after reopen = %d, expected 6", pq.Size())
+	}
+	if element, err := pq.Peek(); err != nil || string(element) != "element-4" {
+		t.Errorf("Peek after reopen = %q (err %v), expected element-4", element, err)
+	}
+	offerAll(t, pq, 10, 12)
+	for i := 4; i < 12; i++ {
+		expectPoll(t, pq, i)
+	}
+	if _, err := pq.Poll(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Poll on empty queue error = %v, expected goods.ErrEmpty", err)
+	}
+}
+
+func TestPersistentQueue_Compaction(t *testing.T) {
+	dir := t.TempDir()
+	// Every record is 8 header bytes plus 9 or 10 payload bytes, so each
+	// segment holds two records
+	pq := openQueue(t, dir, WithSegmentSize(20))
+	offerAll(t, pq, 0, 10)
+	if n := segmentCount(t, dir); n != 5 {
+		t.Fatalf("segment files = %d, expected 5", n)
+	}
+	for i := range 5 {
+		expectPoll(t, pq, i)
+	}
+	// The first two segments were fully polled and deleted
+	if n := segmentCount(t, dir); n != 3 {
+		t.Errorf("segment files after polling 5 elements = %d, expected 3", n)
+	}
+	for i := 5; i < 10; i++ {
+		expectPoll(t, pq, i)
+	}
+	// The segment being written is kept even once it is fully polled
+	if n := segmentCount(t, dir); n != 1 {
+		t.Errorf("segment files after draining = %d, expected 1", n)
+	}
+	// The next Offer fills the kept segment and the one after starts a new
+	// segment, which deletes the drained one
+	offerAll(t, pq, 10, 12)
+	expectPoll(t, pq, 10)
+	offerAll(t, pq, 12, 13)
+	if n := segmentCount(t, dir); n != 2 {
+		t.Errorf("segment files = %d, expected 2", n)
+	}
+	expectPoll(t, pq, 11)
+	expectPoll(t, pq, 12)
+}
+
+func TestPersistentQueue_CompactionFailure(t *testing.T) {
+	dir := t.TempDir()
+	pq := openQueue(t, dir, WithSegmentSize(20))
+	offerAll(t, pq, 0, 6)
+
+	// Replace the second segment with a dangling link, so the reader cannot
+	// move to it once the first segment is drained
+	second := segmentPath(dir, 2)
+	if err := os.Rename(second, second+".bak"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(dir, "missing"), second); err != nil {
+		t.Skipf("cannot create a symlink: %s", err)
+	}
+	expectPoll(t, pq, 0)
+	// The offset moved past element 1, so it must be returned even though the
+	// compaction after it fails
+	expectPoll(t, pq, 1)
+	if _, err := pq.Peek(); err == nil || errors.Is(err, goods.ErrEmpty) {
+		t.Fatalf("Peek with an unreadable segment: err = %v, expected an I/O error", err)
+	}
+	if _, err := pq.Poll(); err == nil || errors.Is(err, goods.ErrEmpty) {
+		t.Fatalf("Poll with an unreadable segment: err = %v, expected an I/O error", err)
+	}
+	if pq.Size() != 4 {
+		t.Fatalf("Size = %d after failed polls, expected 4", pq.Size())
+	}
+
+	// Once the segment is back, the next Poll finishes the compaction
+	if err := os.Remove(second); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(second+".bak", second); err != nil {
+		t.Fatal(err)
+	}
+	expectPoll(t, pq, 2)
+
+	// Put a non-empty directory where the second segment was, so it cannot be
+	// deleted once drained. The reader still holds the segment open.
+	if err := os.Remove(second); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(second, "blocker"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	expectPoll(t, pq, 3)
+	expectPoll(t, pq, 4)
+	if err := pq.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Nothing was lost or delivered twice
+	pq = openQueue(t, dir, WithSegmentSize(20))
+	expectPoll(t, pq, 5)
+	if !pq.IsEmpty() {
+		t.Fatalf("Size = %d after reopening, expected 0", pq.Size())
+	}
+}
+
+func TestPersistentQueue_RollSurvivesCompactionFailure(t *testing.T) {
+	dir := t.TempDir()
+	pq := openQueue(t, dir, WithSegmentSize(20))
+	offerAll(t, pq, 0, 2)
+	expectPoll(t, pq, 0)
+	expectPoll(t, pq, 1)
+
+	// The drained first segment is still being written. Put a non-empty
+	// directory in its place, so it cannot be deleted once the next Offer
+	// rolls to a new segment. The writer still holds the segment open.
+	first := segmentPath(dir, 0)
+	if err := os.Remove(first); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(first, "blocker"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	offerAll(t, pq, 2, 4)
+	expectPoll(t, pq, 2)
+	expectPoll(t, pq, 3)
+}
+
+func TestPersistentQueue_TornWriteRecovery(t *testing.T) {
+	dir := t.TempDir()
+	pq := openQueue(t, dir)
+	offerAll(t, pq, 0, 3)
+	_ = pq.Close()
+
+	// Simulate a crash in the middle of an Offer: a header without its payload
+	last := segmentPath(dir, 0)
+	f, err := os.OpenFile(last, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.Write([]byte{100, 0, 0, 0, 1, 2})
+	_ = f.Close()
+
+	pq = openQueue(t, dir)
+	if pq.Size() != 3 {
+		t.Fatalf("Size after recovery = %d, expected 3", pq.Size())
+	}
+	offerAll(t, pq, 3, 4)
+	for i := range 4 {
+		expectPoll(t, pq, i)
+	}
+}
+
+func TestPersistentQueue_DamagedOffsetRedelivers(t *testing.T) {
+	dir := t.TempDir()
+	pq := openQueue(t, dir)
+	offerAll(t, pq, 0, 3)
+	expectPoll(t, pq, 0)
+	_ = pq.Close()
+
+	if err := os.WriteFile(filepath.Join(dir, offsetFileName), []byte("garbage-bytes"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// Without a valid offset the queue restarts from the oldest record on disk
+	pq = openQueue(t, dir)
+	if pq.Size() != 3 {
+		t.Errorf("Size = %d, expected 3", pq.Size())
+	}
+	expectPoll(t, pq, 0)
+}
+
+func TestPersistentQueue_Corruption(t *testing.T) {
+	dir := t.TempDir()
+	pq := openQueue(t, dir, WithSegmentSize(20))
+	offerAll(t, pq, 0, 6)
+	_ = pq.Close()
+
+	// Flip a payload byte in a segment that is not the last one
+	path := segmentPath(dir, 0)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data[headerSize] ^= 0xff
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewPersistentQueue(dir, WithSegmentSize(20)); !errors.Is(err, ErrCorrupt) {
+		t.Errorf("opening a damaged queue error = %v, expected ErrCorrupt", err)
+	}
+}
+
+func TestPersistentQueue_Options(t *testing.T) {
+	if _, err := NewPersistentQueue(t.TempDir(), WithSegmentSize(0)); !errors.Is(err, goods.ErrCapacity) {
+		t.Errorf("segment size 0 error = %v, expected goods.ErrCapacity", err)
+	}
+	var pq *PersistentQueue
+	if err := pq.Offer(nil); !errors.Is(err, goods.ErrNotInitialized) {
+		t.Errorf("Offer on nil queue error = %v, expected goods.ErrNotInitialized", err)
+	}
+	// The pages of the oversized element are never touched, so it costs no memory
+	pq = openQueue(t, t.TempDir(), WithoutSync())
+	if err := pq.Offer(make([]byte, maxRecordSize+1)); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Offer of an oversized element error = %v, expected ErrTooLarge", err)
+	}
+}
+
+func TestCodecQueue_Structs(t *testing.T) {
+	type job struct {
+		ID   int
+		Name string
+	}
+	dir := t.TempDir()
+	cq, err := NewCodecQueue[job](dir, JSONCodec[job]{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = cq.Offer(job{1, "build"})
+	_ = cq.Offer(job{2, "test"})
+	_ = cq.Close()
+
+	cq, err = NewCodecQueue[job](dir, JSONCodec[job]{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cq.Close()
+	for _, expected := range []job{{1, "build"}, {2, "test"}} {
+		if v, err := cq.Poll(); err != nil || v != expected {
+			t.Errorf("Poll = %+v (err %v), expected %+v", v, err, expected)
+		}
+	}
+}
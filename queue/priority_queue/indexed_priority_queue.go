@@ -0,0 +1,215 @@
+package priorityqueue
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/queue"
+	"github.com/Scanf-s/goods/tree/heap"
+)
+
+var (
+	// ErrKeyExists is returned by Push when the key is already in the queue.
+	ErrKeyExists = errors.New("key is already in the priority queue")
+
+	// ErrKeyNotFound is returned when an operation names a key that is not in the queue.
+	ErrKeyNotFound = errors.New("key is not in the priority queue")
+)
+
+// Entry is a key together with its priority.
+type Entry[K comparable, P any] struct {
+	Key      K
+	Priority P
+}
+
+// IndexedPriorityQueue is a priority queue of unique keys whose priorities can
+// change while they are queued, as needed by Dijkstra's and Prim's algorithms.
+//
+// It composes a heap.Heap of entries with a map from each key to the heap handle
+// of its entry, so a key is located in O(1) and repositioned in O(log n).
+// The entry whose priority compares smallest is popped first; use
+// NewIndexedMaxPriorityQueue or a reversed comparator to pop the largest first.
+type IndexedPriorityQueue[K comparable, P any] struct {
+	// heap orders the entries by priority
+	heap *heap.Heap[Entry[K, P]]
+
+	// handles maps every queued key to the handle of its entry in heap
+	handles map[K]*heap.Handle[Entry[K, P]]
+}
+
+// Compile-time check that IndexedPriorityQueue implements the queue.Queue interface.
+var _ queue.Queue[Entry[int, int]] = (*IndexedPriorityQueue[int, int])(nil)
+
+// NewIndexedPriorityQueue returns an empty IndexedPriorityQueue whose priorities
+// are ordered by compare.
+// Time Complexity: O(1)
+func NewIndexedPriorityQueue[K comparable, P any](compare func(a, b P) int) *IndexedPriorityQueue[K, P] {
+	return &IndexedPriorityQueue[K, P]{
+		heap: heap.New(func(a, b Entry[K, P]) int {
+			return compare(a.Priority, b.Priority)
+		}),
+		handles: make(map[K]*heap.Handle[Entry[K, P]]),
+	}
+}
+
+// NewIndexedMinPriorityQueue returns an empty queue that pops the smallest priority first.
+// Time Complexity: O(1)
+func NewIndexedMinPriorityQueue[K comparable, P cmp.Ordered]() *IndexedPriorityQueue[K, P] {
+	return NewIndexedPriorityQueue[K](cmp.Compare[P])
+}
+
+// NewIndexedMaxPriorityQueue returns an empty queue that pops the largest priority first.
+// Time Complexity: O(1)
+func NewIndexedMaxPriorityQueue[K comparable, P cmp.Ordered]() *IndexedPriorityQueue[K, P] {
+	return NewIndexedPriorityQueue[K](func(a, b P) int { return cmp.Compare(b, a) })
+}
+
+// Push adds key with the given priority.
+// Returns an error wrapping ErrKeyExists if key is already queued.
+// Time Complexity: O(log n)
+func (pq *IndexedPriorityQueue[K, P]) Push(key K, priority P) error {
+	if err := pq.checkInitialized(); err != nil {
+		return err
+	}
+	if _, ok := pq.handles[key]; ok {
+		return fmt.Errorf("cannot push key %v: %w", key, ErrKeyExists)
+	}
+	pq.handles[key] = pq.heap.Push(Entry[K, P]{Key: key, Priority: priority})
+	return nil
+}
+
+// Update changes the priority of a queued key, moving it up or down as needed.
+// This covers both decrease-key and increase-key.
+// Returns an error wrapping ErrKeyNotFound if key is not queued.
+// Time Complexity: O(log n)
+func (pq *IndexedPriorityQueue[K, P]) Update(key K, priority P) error {
+	if err := pq.checkInitialized(); err != nil {
+		return err
+	}
+	handle, ok := pq.handles[key]
+	if !ok {
+		return fmt.Errorf("cannot update key %v: %w", key, ErrKeyNotFound)
+	}
+	return pq.heap.Update(handle, Entry[K, P]{Key: key, Priority: priority})
+}
+
+// Remove takes key out of the queue and returns its priority.
+// Returns an error wrapping ErrKeyNotFound if key is not queued.
+// Time Complexity: O(log n)
+func (pq *IndexedPriorityQueue[K, P]) Remove(key K) (P, error) {
+	var priority P
+	if err := pq.checkInitialized(); err != nil {
+		return priority, err
+	}
+	handle, ok := pq.handles[key]
+	if !ok {
+		return priority, fmt.Errorf("cannot remove key %v: %w", key, ErrKeyNotFound)
+	}
+	entry, err := pq.heap.Remove(handle)
+	if err != nil {
+		return priority, err
+	}
+	delete(pq.handles, key)
+	return entry.Priority, nil
+}
+
+// Contains reports whether key is queued.
+// Time Complexity: O(1)
+func (pq *IndexedPriorityQueue[K, P]) Contains(key K) bool {
+	if pq == nil {
+		return false
+	}
+	_, ok := pq.handles[key]
+	return ok
+}
+
+// Priority returns the current priority of key and whether key is queued.
+// Time Complexity: O(1)
+func (pq *IndexedPriorityQueue[K, P]) Priority(key K) (P, bool) {
+	var priority P
+	if pq == nil {
+		return priority, false
+	}
+	handle, ok := pq.handles[key]
+	if !ok {
+		return priority, false
+	}
+	return handle.Value().Priority, true
+}
+
+// PeekMin returns the key whose priority compares smallest, without removing it.
+// Returns an error wrapping goods.ErrEmpty if the queue is empty.
+// Time Complexity: O(1)
+func (pq *IndexedPriorityQueue[K, P]) PeekMin() (K, P, error) {
+	entry, err := pq.Peek()
+	return entry.Key, entry.Priority, err
+}
+
+// PopMin removes and returns the key whose priority compares smallest.
+// Returns an error wrapping goods.ErrEmpty if the queue is empty.
+// Time Complexity: O(log n)
+func (pq *IndexedPriorityQueue[K, P]) PopMin() (K, P, error) {
+	entry, err := pq.Poll()
+	return entry.Key, entry.Priority, err
+}
+
+// Offer adds entry to the queue. It is equivalent to Push(entry.Key, entry.Priority).
+// Time Complexity: O(log n)
+func (pq *IndexedPriorityQueue[K, P]) Offer(entry Entry[K, P]) error {
+	return pq.Push(entry.Key, entry.Priority)
+}
+
+// Peek returns the entry with the smallest priority without removing it.
+// Time Complexity: O(1)
+func (pq *IndexedPriorityQueue[K, P]) Peek() (Entry[K, P], error) {
+	var entry Entry[K, P]
+	if err := pq.checkInitialized(); err != nil {
+		return entry, err
+	}
+	entry, err := pq.heap.Peek()
+	if err != nil {
+		return entry, fmt.Errorf("cannot peek element from the priority queue: %w", err)
+	}
+	return entry, nil
+}
+
+// Poll removes and returns the entry with the smallest priority.
+// Time Complexity: O(log n)
+func (pq *IndexedPriorityQueue[K, P]) Poll() (Entry[K, P], error) {
+	var entry Entry[K, P]
+	if err := pq.checkInitialized(); err != nil {
+		return entry, err
+	}
+	entry, err := pq.heap.Pop()
+	if err != nil {
+		return entry, fmt.Errorf("cannot poll element from the priority queue: %w", err)
+	}
+	delete(pq.handles, entry.Key)
+	return entry, nil
+}
+
+// Size returns the number of keys in the queue.
+// Time Complexity: O(1)
+func (pq *IndexedPriorityQueue[K, P]) Size() int {
+	if pq == nil || pq.heap == nil {
+		return 0
+	}
+	return pq.heap.Size()
+}
+
+// IsEmpty returns true if the queue has no keys.
+// Time Complexity: O(1)
+func (pq *IndexedPriorityQueue[K, P]) IsEmpty() bool {
+	return pq.Size() == 0
+}
+
+// checkInitialized returns an error wrapping goods.ErrNotInitialized if pq was
+// not created by one of the constructors.
+func (pq *IndexedPriorityQueue[K, P]) checkInitialized() error {
+	if pq == nil || pq.heap == nil {
+		return fmt.Errorf("please call NewIndexedPriorityQueue() first: %w", goods.ErrNotInitialized)
+	}
+	return nil
+}
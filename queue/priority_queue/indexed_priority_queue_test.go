@@ -0,0 +1,159 @@
+package priorityqueue
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/Scanf-s/goods"
+)
+
+func TestIndexedPriorityQueue_PushPopMin(t *testing.T) {
+	pq := NewIndexedMinPriorityQueue[string, int]()
+	for key, priority := range map[string]int{"c": 3, "a": 1, "b": 2} {
+		if err := pq.Push(key, priority); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := pq.Push("a", 0); !errors.Is(err, ErrKeyExists) {
+		t.Errorf("Push of a queued key error = %v, expected ErrKeyExists", err)
+	}
+
+	for _, expected := range []string{"a", "b", "c"} {
+		key, _, err := pq.PopMin()
+		if err != nil || key != expected {
+			t.Errorf("PopMin = %q (err %v), expected %q", key, err, expected)
+		}
+		if pq.Contains(key) {
+			t.Errorf("Contains(%q) should be false after PopMin", key)
+		}
+	}
+	if _, _, err := pq.PopMin(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("PopMin on empty queue error = %v, expected goods.ErrEmpty", err)
+	}
+}
+
+func TestIndexedPriorityQueue_MaxQueue(t *testing.T) {
+	pq := NewIndexedMaxPriorityQueue[int, float64]()
+	_ = pq.Push(1, 0.5)
+	_ = pq.Push(2, 2.5)
+	_ = pq.Push(3, 1.5)
+	if key, priority, err := pq.PeekMin(); err != nil || key != 2 || priority != 2.5 {
+		t.Errorf("PeekMin = (%d, %v, %v), expected (2, 2.5, nil)", key, priority, err)
+	}
+}
+
+func TestIndexedPriorityQueue_UpdateRemove(t *testing.T) {
+	pq := NewIndexedMinPriorityQueue[string, int]()
+	_ = pq.Push("x", 10)
+	_ = pq.Push("y", 20)
+	_ = pq.Push("z", 30)
+
+	// decrease-key
+	if err := pq.Update("z", 5); err != nil {
+		t.Fatal(err)
+	}
+	if key, _, _ := pq.PeekMin(); key != "z" {
+		t.Errorf("PeekMin after decreasing z = %q, expected z", key)
+	}
+	// increase-key
+	if err := pq.Update("z", 50); err != nil {
+		t.Fatal(err)
+	}
+	if p, ok := pq.Priority("z"); !ok || p != 50 {
+		t.Errorf("Priority(z) = (%d, %v), expected (50, true)", p, ok)
+	}
+	if err := pq.Update("missing", 1); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("Update of a missing key error = %v, expected ErrKeyNotFound", err)
+	}
+
+	if p, err := pq.Remove("x"); err != nil || p != 10 {
+		t.Errorf("Remove(x) = (%d, %v), expected (10, nil)", p, err)
+	}
+	if _, err := pq.Remove("x"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("second Remove(x) error = %v, expected ErrKeyNotFound", err)
+	}
+	if pq.Size() != 2 {
+		t.Errorf("Size = %d, expected 2", pq.Size())
+	}
+	if entry, err := pq.Poll(); err != nil || entry != (Entry[string, int]{"y", 20}) {
+		t.Errorf("Poll = %+v (err %v), expected {y 20}", entry, err)
+	}
+}
+
+func TestIndexedPriorityQueue_NotInitialized(t *testing.T) {
+	var pq IndexedPriorityQueue[int, int]
+	if err := pq.Push(1, 1); !errors.Is(err, goods.ErrNotInitialized) {
+		t.Errorf("Push on zero-value queue error = %v, expected goods.ErrNotInitialized", err)
+	}
+	if pq.Contains(1) || !pq.IsEmpty() {
+		t.Error("a zero-value queue should be empty")
+	}
+}
+
+// TestIndexedPriorityQueue_Dijkstra runs Dijkstra's algorithm with decrease-key
+// and compares the distances with Bellman-Ford on random graphs.
+func TestIndexedPriorityQueue_Dijkstra(t *testing.T) {
+	type edge struct{ to, weight int }
+	rng := rand.New(rand.NewPCG(15, 16))
+
+	for trial := range 20 {
+		n := 2 + rng.IntN(30)
+		graph := make([][]edge, n)
+		for range n * 3 {
+			from, to := rng.IntN(n), rng.IntN(n)
+			graph[from] = append(graph[from], edge{to, rng.IntN(100)})
+		}
+
+		// Reference: Bellman-Ford
+		expected := make([]int, n)
+		for i := range expected {
+			expected[i] = math.MaxInt
+		}
+		expected[0] = 0
+		for range n {
+			for from, edges := range graph {
+				if expected[from] == math.MaxInt {
+					continue
+				}
+				for _, e := range edges {
+					expected[e.to] = min(expected[e.to], expected[from]+e.weight)
+				}
+			}
+		}
+
+		dist := make([]int, n)
+		for i := range dist {
+			dist[i] = math.MaxInt
+		}
+		dist[0] = 0
+		pq := NewIndexedMinPriorityQueue[int, int]()
+		_ = pq.Push(0, 0)
+		for !pq.IsEmpty() {
+			node, d, err := pq.PopMin()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, e := range graph[node] {
+				if nd := d + e.weight; nd < dist[e.to] {
+					if dist[e.to] == math.MaxInt {
+						err = pq.Push(e.to, nd)
+					} else if pq.Contains(e.to) {
+						err = pq.Update(e.to, nd)
+					}
+					if err != nil {
+						t.Fatal(err)
+					}
+					dist[e.to] = nd
+				}
+			}
+		}
+
+		for i := range n {
+			if dist[i] != expected[i] {
+				t.Fatalf("trial %d: dist[%d] = %d, expected %d", trial, i, dist[i], expected[i])
+			}
+		}
+	}
+}
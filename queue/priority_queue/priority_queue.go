@@ -0,0 +1,126 @@
+// Package priorityqueue implements priority queues on top of a binary heap.
+package priorityqueue
+
+import (
+	"fmt"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/queue"
+	"github.com/Scanf-s/goods/tree/heap"
+)
+
+// PriorityQueue is a queue that always polls the element with the highest
+// priority first, built by composing a heap.Heap.
+//
+// Priority is defined by the comparator: the element for which compare reports
+// the smallest value is polled first. Elements with equal priority are polled
+// in an unspecified order.
+type PriorityQueue[T any] struct {
+	heap *heap.Heap[T]
+}
+
+// Compile-time check that PriorityQueue implements the queue.Queue interface.
+var _ queue.Queue[int] = (*PriorityQueue[int])(nil)
+
+// NewPriorityQueue returns an empty PriorityQueue ordered by compare.
+// Time Complexity: O(1)
+func NewPriorityQueue[T any](compare func(a, b T) int) *PriorityQueue[T] {
+	return &PriorityQueue[T]{heap: heap.New(compare)}
+}
+
+// NewPriorityQueueFromSlice returns a PriorityQueue holding a copy of values.
+// Time Complexity: O(n), using bottom-up heap construction
+func NewPriorityQueueFromSlice[T any](compare func(a, b T) int, values []T) *PriorityQueue[T] {
+	return &PriorityQueue[T]{heap: heap.NewFromSlice(compare, values)}
+}
+
+// Offer adds an element to the queue.
+// Time Complexity: O(log n)
+func (pq *PriorityQueue[T]) Offer(value T) error {
+	_, err := pq.Push(value)
+	return err
+}
+
+// Push adds an element and returns a handle that can later be passed to
+// Update or Remove to change its priority or take it out of the queue.
+// Time Complexity: O(log n)
+func (pq *PriorityQueue[T]) Push(value T) (*heap.Handle[T], error) {
+	if pq == nil || pq.heap == nil {
+		return nil, fmt.Errorf("please call NewPriorityQueue() first: %w", goods.ErrNotInitialized)
+	}
+	return pq.heap.Push(value), nil
+}
+
+// Peek returns the highest-priority element without removing it.
+// Time Complexity: O(1)
+func (pq *PriorityQueue[T]) Peek() (T, error) {
+	var element T
+	if pq == nil || pq.heap == nil {
+		return element, fmt.Errorf("please call NewPriorityQueue() first: %w", goods.ErrNotInitialized)
+	}
+	element, err := pq.heap.Peek()
+	if err != nil {
+		return element, fmt.Errorf("cannot peek element from the priority queue: %w", err)
+	}
+	return element, nil
+}
+
+// Poll removes and returns the highest-priority element.
+// Time Complexity: O(log n)
+func (pq *PriorityQueue[T]) Poll() (T, error) {
+	var element T
+	if pq == nil || pq.heap == nil {
+		return element, fmt.Errorf("please call NewPriorityQueue() first: %w", goods.ErrNotInitialized)
+	}
+	element, err := pq.heap.Pop()
+	if err != nil {
+		return element, fmt.Errorf("cannot poll element from the priority queue: %w", err)
+	}
+	return element, nil
+}
+
+// Update replaces the element behind handle, moving it to its new position.
+// Time Complexity: O(log n)
+func (pq *PriorityQueue[T]) Update(handle *heap.Handle[T], value T) error {
+	if pq == nil || pq.heap == nil {
+		return fmt.Errorf("please call NewPriorityQueue() first: %w", goods.ErrNotInitialized)
+	}
+	return pq.heap.Update(handle, value)
+}
+
+// Fix restores the order after the element behind handle was changed in place.
+// Time Complexity: O(log n)
+func (pq *PriorityQueue[T]) Fix(handle *heap.Handle[T]) error {
+	if pq == nil || pq.heap == nil {
+		return fmt.Errorf("please call NewPriorityQueue() first: %w", goods.ErrNotInitialized)
+	}
+	return pq.heap.Fix(handle)
+}
+
+// Remove takes the element behind handle out of the queue and returns it.
+// Time Complexity: O(log n)
+func (pq *PriorityQueue[T]) Remove(handle *heap.Handle[T]) (T, error) {
+	var element T
+	if pq == nil || pq.heap == nil {
+		return element, fmt.Errorf("please call NewPriorityQueue() first: %w", goods.ErrNotInitialized)
+	}
+	return pq.heap.Remove(handle)
+}
+
+// Size returns the number of elements in the queue.
+// Time Complexity: O(1)
+func (pq *PriorityQueue[T]) Size() int {
+	if pq == nil || pq.heap == nil {
+		return 0
+	}
+	return pq.heap.Size()
+}
+
+// IsEmpty returns true if the queue has no elements.
+// Time Complexity: O(1)
+func (pq *PriorityQueue[T]) IsEmpty() bool {
+	if pq == nil || pq.heap == nil {
+		return true
+	}
+	return pq.heap.IsEmpty()
+}
@@ -0,0 +1,94 @@
+package priorityqueue
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/tree/heap"
+)
+
+func TestPriorityQueue_OfferPoll(t *testing.T) {
+	pq := NewPriorityQueue(cmp.Compare[int])
+	for _, v := range []int{4, 1, 3, 5, 2} {
+		if err := pq.Offer(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if v, err := pq.Peek(); err != nil || v != 1 {
+		t.Errorf("Peek = %d (err %v), expected 1", v, err)
+	}
+
+	var got []int
+	for !pq.IsEmpty() {
+		v, err := pq.Poll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("Poll order = %v", got)
+	}
+	if _, err := pq.Poll(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Poll on empty queue error = %v, expected goods.ErrEmpty", err)
+	}
+}
+
+func TestPriorityQueue_FromSlice(t *testing.T) {
+	type job struct {
+		name     string
+		priority int
+	}
+	// Highest priority number first
+	pq := NewPriorityQueueFromSlice(func(a, b job) int { return cmp.Compare(b.priority, a.priority) }, []job{
+		{"low", 1}, {"high", 9}, {"mid", 5},
+	})
+	if pq.Size() != 3 {
+		t.Errorf("Size = %d, expected 3", pq.Size())
+	}
+	for _, expected := range []string{"high", "mid", "low"} {
+		if j, err := pq.Poll(); err != nil || j.name != expected {
+			t.Errorf("Poll = %+v (err %v), expected %s", j, err, expected)
+		}
+	}
+}
+
+func TestPriorityQueue_Handles(t *testing.T) {
+	pq := NewPriorityQueue(cmp.Compare[int])
+	h10, _ := pq.Push(10)
+	h20, _ := pq.Push(20)
+	_, _ = pq.Push(30)
+
+	if err := pq.Update(h20, 1); err != nil {
+		t.Fatalf("Update failed: %s", err)
+	}
+	if v, _ := pq.Peek(); v != 1 {
+		t.Errorf("Peek after Update = %d, expected 1", v)
+	}
+	if v, err := pq.Remove(h10); err != nil || v != 10 {
+		t.Errorf("Remove = %d (err %v), expected 10", v, err)
+	}
+	if _, err := pq.Remove(h10); !errors.Is(err, heap.ErrInvalidHandle) {
+		t.Errorf("second Remove error = %v, expected heap.ErrInvalidHandle", err)
+	}
+	if pq.Size() != 2 {
+		t.Errorf("Size = %d, expected 2", pq.Size())
+	}
+}
+
+func TestPriorityQueue_NotInitialized(t *testing.T) {
+	var pq *PriorityQueue[int]
+	if err := pq.Offer(1); !errors.Is(err, goods.ErrNotInitialized) {
+		t.Errorf("Offer on nil queue error = %v, expected goods.ErrNotInitialized", err)
+	}
+	var zero PriorityQueue[int]
+	if _, err := zero.Poll(); !errors.Is(err, goods.ErrNotInitialized) {
+		t.Errorf("Poll on zero-value queue error = %v, expected goods.ErrNotInitialized", err)
+	}
+	if !zero.IsEmpty() || zero.Size() != 0 {
+		t.Error("a zero-value queue should be empty")
+	}
+}
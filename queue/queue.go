@@ -2,13 +2,17 @@ package queue
 
 type (
 	Queue[T any] interface {
-		// Offer adds new element into the queue data structure
+		// Offer adds new element into the queue data structure.
+		// Bounded queues return an error wrapping goods.ErrFull when there is no room;
+		// boundedqueue.BoundedQueue adds a capacity to any queue.
 		Offer(element T) error
 
 		// Peek returns an element in front of the queue without removing it.
+		// Returns an error wrapping goods.ErrEmpty if the queue is empty.
 		Peek() (T, error)
 
 		// Poll is returns an element in front of the queue as well as removes the front element.
+		// Returns an error wrapping goods.ErrEmpty if the queue is empty.
 		Poll() (T, error)
 
 		// Size returns the number of elements in the queue.
@@ -17,4 +21,25 @@ type (
 		// IsEmpty returns true if the queue has no elements.
 		IsEmpty() bool
 	}
+
+	// BatchQueue is a Queue that moves many elements per call, using a bulk
+	// copy or a splice of its backing store instead of one Offer or Poll per
+	// element. The OfferAll, PollN and DrainTo functions use it when a queue
+	// implements it and fall back to Offer and Poll otherwise.
+	BatchQueue[T any] interface {
+		Queue[T]
+
+		// OfferAll adds the elements to the back of the queue in order.
+		// A bounded queue without room for all of them adds none and returns an
+		// error wrapping goods.ErrFull.
+		OfferAll(elements ...T) error
+
+		// PollN removes up to n elements from the front and returns them in FIFO order.
+		// It returns nil if the queue is empty or n is not positive.
+		PollN(n int) []T
+
+		// DrainTo removes up to len(dst) elements from the front, copies them
+		// into dst in FIFO order and returns how many were copied.
+		DrainTo(dst []T) int
+	}
 )
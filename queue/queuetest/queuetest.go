@@ -0,0 +1,302 @@
+// Package queuetest provides a conformance suite for queue.Queue implementations.
+//
+// Any implementation, built-in or external, can be checked against the FIFO
+// contract of queue.Queue by calling RunQueueSuite from an ordinary test, and
+// against queue.BatchQueue with RunBatchQueueSuite:
+//
+//	func TestMyQueue(t *testing.T) {
+//		queuetest.RunQueueSuite(t, func() queue.Queue[int] { return NewMyQueue[int]() })
+//	}
+package queuetest
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/queue"
+)
+
+// RunQueueSuite checks that the queues returned by newQueue honor the queue.Queue
+// contract: FIFO order, goods.ErrEmpty from Peek and Poll on an empty queue, and
+// consistent Size and IsEmpty. newQueue must return a new, empty queue on every call.
+func RunQueueSuite(t *testing.T, newQueue func() queue.Queue[int]) {
+	t.Helper()
+
+	t.Run("Empty", func(t *testing.T) { testEmpty(t, newQueue()) })
+	t.Run("FIFOOrder", func(t *testing.T) { testFIFOOrder(t, newQueue()) })
+	t.Run("PeekDoesNotRemove", func(t *testing.T) { testPeekDoesNotRemove(t, newQueue()) })
+	t.Run("Size", func(t *testing.T) { testSize(t, newQueue()) })
+	t.Run("DrainAndReuse", func(t *testing.T) { testDrainAndReuse(t, newQueue()) })
+	t.Run("RandomizedModel", func(t *testing.T) { testRandomizedModel(t, newQueue()) })
+}
+
+func testEmpty(t *testing.T, q queue.Queue[int]) {
+	if !q.IsEmpty() {
+		t.Error("a new queue should be empty")
+	}
+	if q.Size() != 0 {
+		t.Errorf("a new queue should have size 0, got %d", q.Size())
+	}
+	if _, err := q.Peek(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Peek on empty queue error = %v, expected goods.ErrEmpty", err)
+	}
+	if _, err := q.Poll(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Poll on empty queue error = %v, expected goods.ErrEmpty", err)
+	}
+}
+
+func testFIFOOrder(t *testing.T, q queue.Queue[int]) {
+	const count = 100
+	for i := range count {
+		if err := q.Offer(i); err != nil {
+			t.Fatalf("Offer(%d) failed: %s", i, err)
+		}
+	}
+	for i := range count {
+		val, err := q.Poll()
+		if err != nil {
+			t.Fatalf("Poll #%d failed: %s", i, err)
+		}
+		if val != i {
+			t.Fatalf("Poll #%d = %d, expected %d (queue must be FIFO)", i, val, i)
+		}
+	}
+	if !q.IsEmpty() {
+		t.Errorf("queue should be empty after polling every element, size = %d", q.Size())
+	}
+}
+
+func testPeekDoesNotRemove(t *testing.T, q queue.Queue[int]) {
+	for _, v := range []int{1, 2, 3} {
+		if err := q.Offer(v); err != nil {
+			t.Fatalf("Offer(%d) failed: %s", v, err)
+		}
+	}
+	for range 3 {
+		val, err := q.Peek()
+		if err != nil || val != 1 {
+			t.Errorf("Peek = %d (err %v), expected 1", val, err)
+		}
+	}
+	if q.Size() != 3 {
+		t.Errorf("Peek must not change the size, got %d, expected 3", q.Size())
+	}
+}
+
+func testSize(t *testing.T, q queue.Queue[int]) {
+	for i := range 10 {
+		if err := q.Offer(i); err != nil {
+			t.Fatalf("Offer(%d) failed: %s", i, err)
+		}
+		if q.Size() != i+1 {
+			t.Errorf("Size after %d offers = %d", i+1, q.Size())
+		}
+		if q.IsEmpty() {
+			t.Errorf("IsEmpty after %d offers should be false", i+1)
+		}
+	}
+	for i := 10; i > 0; i-- {
+		if _, err := q.Poll(); err != nil {
+			t.Fatalf("Poll failed: %s", err)
+		}
+		if q.Size() != i-1 {
+			t.Errorf("Size after poll = %d, expected %d", q.Size(), i-1)
+		}
+	}
+	if !q.IsEmpty() {
+		t.Error("IsEmpty should be true after draining the queue")
+	}
+}
+
+func testDrainAndReuse(t *testing.T, q queue.Queue[int]) {
+	for round := range 3 {
+		for i := range 5 {
+			if err := q.Offer(round*10 + i); err != nil {
+				t.Fatalf("Offer failed: %s", err)
+			}
+		}
+		for i := range 5 {
+			val, err := q.Poll()
+			if err != nil || val != round*10+i {
+				t.Fatalf("round %d: Poll = %d (err %v), expected %d", round, val, err, round*10+i)
+			}
+		}
+		if _, err := q.Poll(); !errors.Is(err, goods.ErrEmpty) {
+			t.Errorf("round %d: Poll on drained queue error = %v, expected goods.ErrEmpty", round, err)
+		}
+	}
+}
+
+// testRandomizedModel applies a random mix of operations to q and to a plain
+// slice, failing on the first observable difference.
+func testRandomizedModel(t *testing.T, q queue.Queue[int]) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	var model []int
+	for step := range 2000 {
+		switch rng.IntN(3) {
+		case 0, 1:
+			v := rng.Int()
+			if err := q.Offer(v); err != nil {
+				t.Fatalf("step %d: Offer failed: %s", step, err)
+			}
+			model = append(model, v)
+		case 2:
+			val, err := q.Poll()
+			if len(model) == 0 {
+				if !errors.Is(err, goods.ErrEmpty) {
+					t.Fatalf("step %d: Poll on empty queue error = %v, expected goods.ErrEmpty", step, err)
+				}
+				continue
+			}
+			if err != nil || val != model[0] {
+				t.Fatalf("step %d: Poll = %d (err %v), expected %d", step, val, err, model[0])
+			}
+			model = model[1:]
+		}
+		if q.Size() != len(model) {
+			t.Fatalf("step %d: Size = %d, expected %d", step, q.Size(), len(model))
+		}
+	}
+}
+
+// RunBatchQueueSuite checks that the queues returned by newQueue honor the
+// queue.BatchQueue contract: OfferAll, PollN and DrainTo move elements in FIFO
+// order and mix freely with Offer and Poll. It does not repeat the checks of
+// RunQueueSuite. newQueue must return a new, empty, unbounded queue on every call.
+func RunBatchQueueSuite(t *testing.T, newQueue func() queue.BatchQueue[int]) {
+	t.Helper()
+
+	t.Run("OfferAll", func(t *testing.T) { testOfferAll(t, newQueue()) })
+	t.Run("PollN", func(t *testing.T) { testPollN(t, newQueue()) })
+	t.Run("DrainTo", func(t *testing.T) { testDrainTo(t, newQueue()) })
+	t.Run("BatchRandomizedModel", func(t *testing.T) { testBatchRandomizedModel(t, newQueue()) })
+}
+
+func testOfferAll(t *testing.T, q queue.BatchQueue[int]) {
+	if err := q.OfferAll(); err != nil {
+		t.Errorf("OfferAll() with no elements failed: %s", err)
+	}
+	if err := q.Offer(0); err != nil {
+		t.Fatalf("Offer failed: %s", err)
+	}
+	if err := q.OfferAll(1, 2, 3); err != nil {
+		t.Fatalf("OfferAll failed: %s", err)
+	}
+	if q.Size() != 4 {
+		t.Errorf("Size after OfferAll = %d, expected 4", q.Size())
+	}
+	for i := range 4 {
+		val, err := q.Poll()
+		if err != nil || val != i {
+			t.Fatalf("Poll #%d = %d (err %v), expected %d", i, val, err, i)
+		}
+	}
+}
+
+func testPollN(t *testing.T, q queue.BatchQueue[int]) {
+	if got := q.PollN(3); got != nil {
+		t.Errorf("PollN on empty queue = %v, expected nil", got)
+	}
+	for i := range 5 {
+		if err := q.Offer(i); err != nil {
+			t.Fatalf("Offer(%d) failed: %s", i, err)
+		}
+	}
+	if got := q.PollN(0); got != nil {
+		t.Errorf("PollN(0) = %v, expected nil", got)
+	}
+	if got := q.PollN(2); !slices.Equal(got, []int{0, 1}) {
+		t.Errorf("PollN(2) = %v, expected [0 1]", got)
+	}
+	if got := q.PollN(10); !slices.Equal(got, []int{2, 3, 4}) {
+		t.Errorf("PollN(10) = %v, expected [2 3 4]", got)
+	}
+	if !q.IsEmpty() {
+		t.Errorf("queue should be empty after PollN took every element, size = %d", q.Size())
+	}
+}
+
+func testDrainTo(t *testing.T, q queue.BatchQueue[int]) {
+	if n := q.DrainTo(make([]int, 3)); n != 0 {
+		t.Errorf("DrainTo on empty queue = %d, expected 0", n)
+	}
+	for i := range 5 {
+		if err := q.Offer(i); err != nil {
+			t.Fatalf("Offer(%d) failed: %s", i, err)
+		}
+	}
+	if n := q.DrainTo(nil); n != 0 || q.Size() != 5 {
+		t.Errorf("DrainTo(nil) = %d with size %d, expected 0 with size 5", n, q.Size())
+	}
+	dst := make([]int, 3)
+	if n := q.DrainTo(dst); n != 3 || !slices.Equal(dst, []int{0, 1, 2}) {
+		t.Errorf("DrainTo = %v (n = %d), expected [0 1 2]", dst, n)
+	}
+	dst = make([]int, 10)
+	if n := q.DrainTo(dst); n != 2 || !slices.Equal(dst[:n], []int{3, 4}) {
+		t.Errorf("DrainTo = %v (n = %d), expected [3 4]", dst[:n], n)
+	}
+	if !q.IsEmpty() {
+		t.Errorf("queue should be empty after DrainTo took every element, size = %d", q.Size())
+	}
+}
+
+// testBatchRandomizedModel mixes single and batch operations on q and on a
+// plain slice, failing on the first observable difference.
+func testBatchRandomizedModel(t *testing.T, q queue.BatchQueue[int]) {
+	rng := rand.New(rand.NewPCG(3, 4))
+	var model []int
+	for step := range 2000 {
+		switch rng.IntN(5) {
+		case 0:
+			v := rng.Int()
+			if err := q.Offer(v); err != nil {
+				t.Fatalf("step %d: Offer failed: %s", step, err)
+			}
+			model = append(model, v)
+		case 1:
+			batch := make([]int, rng.IntN(20))
+			for i := range batch {
+				batch[i] = rng.Int()
+			}
+			if err := q.OfferAll(batch...); err != nil {
+				t.Fatalf("step %d: OfferAll failed: %s", step, err)
+			}
+			model = append(model, batch...)
+		case 2:
+			val, err := q.Poll()
+			if len(model) == 0 {
+				if !errors.Is(err, goods.ErrEmpty) {
+					t.Fatalf("step %d: Poll on empty queue error = %v, expected goods.ErrEmpty", step, err)
+				}
+				continue
+			}
+			if err != nil || val != model[0] {
+				t.Fatalf("step %d: Poll = %d (err %v), expected %d", step, val, err, model[0])
+			}
+			model = model[1:]
+		case 3:
+			n := rng.IntN(15)
+			got := q.PollN(n)
+			expected := model[:min(n, len(model))]
+			if !slices.Equal(got, expected) {
+				t.Fatalf("step %d: PollN(%d) = %v, expected %v", step, n, got, expected)
+			}
+			model = model[len(expected):]
+		case 4:
+			dst := make([]int, rng.IntN(15))
+			n := q.DrainTo(dst)
+			expected := model[:min(len(dst), len(model))]
+			if !slices.Equal(dst[:n], expected) {
+				t.Fatalf("step %d: DrainTo = %v, expected %v", step, dst[:n], expected)
+			}
+			model = model[len(expected):]
+		}
+		if q.Size() != len(model) {
+			t.Fatalf("step %d: Size = %d, expected %d", step, q.Size(), len(model))
+		}
+	}
+}
@@ -0,0 +1,278 @@
+// Package ringqueue implements a FIFO queue on a ring buffer.
+package ringqueue
+
+import (
+	"fmt"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/queue"
+)
+
+// RingQueue is a FIFO queue stored in a slice used as a ring buffer.
+//
+// head is the index of the front element and tail is the index of the slot the
+// next Offer writes to; both wrap around the end of the slice. Removing the
+// front only advances head, so unlike ArrayListQueue nothing is shifted and
+// Poll is O(1). When the buffer is full it doubles, copying the elements so that
+// the front lands at index 0 again.
+type RingQueue[T any] struct {
+	// data is the ring buffer; len(data) is the current capacity
+	data []T
+
+	// head is the index of the front element
+	head int
+
+	// tail is the index where the next element will be written
+	tail int
+
+	// size is the number of elements currently stored
+	size int
+
+	// minCapacity is the capacity the buffer never shrinks below
+	minCapacity int
+
+	// shrink enables halving the buffer when it becomes sparse
+	shrink bool
+}
+
+// Compile-time check that RingQueue implements the queue.BatchQueue interface.
+var _ queue.BatchQueue[int] = (*RingQueue[int])(nil)
+
+// Option configures a RingQueue created by NewRingQueue.
+type Option func(*options)
+
+type options struct {
+	shrink bool
+}
+
+// WithShrink makes the queue halve its buffer when it is at most a quarter
+// full, never going below the initial capacity. Without it the buffer only grows.
+func WithShrink() Option {
+	return func(o *options) {
+		o.shrink = true
+	}
+}
+
+// NewRingQueue returns an empty RingQueue with room for capacity elements
+// before the first resize. A negative capacity is treated as 0.
+// Time Complexity: O(capacity)
+// Space Complexity: O(capacity)
+func NewRingQueue[T any](capacity int, opts ...Option) *RingQueue[T] {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	capacity = max(capacity, 0)
+	return &RingQueue[T]{
+		data:        make([]T, capacity),
+		minCapacity: capacity,
+		shrink:      o.shrink,
+	}
+}
+
+// Offer adds an element to the back of the queue.
+// Time Complexity: O(1) amortized (O(n) when the buffer grows)
+func (rq *RingQueue[T]) Offer(value T) error {
+	if rq == nil {
+		return fmt.Errorf("queue is nil: %w", goods.ErrNotInitialized)
+	}
+	if rq.size == len(rq.data) {
+		rq.resize(max(len(rq.data)*2, 1))
+	}
+	rq.data[rq.tail] = value
+	rq.tail = rq.next(rq.tail)
+	rq.size++
+	return nil
+}
+
+// Peek returns the front element without removing it.
+// Returns an error wrapping goods.ErrEmpty if the queue is empty.
+// Time Complexity: O(1)
+func (rq *RingQueue[T]) Peek() (T, error) {
+	var defaultVal T
+	if rq == nil {
+		return defaultVal, fmt.Errorf("queue is nil: %w", goods.ErrNotInitialized)
+	}
+	if rq.size == 0 {
+		return defaultVal, fmt.Errorf("cannot peek front element from the queue: %w", goods.ErrEmpty)
+	}
+	return rq.data[rq.head], nil
+}
+
+// PeekBack returns the most recently offered element without removing it.
+// Returns an error wrapping goods.ErrEmpty if the queue is empty.
+// Time Complexity: O(1)
+func (rq *RingQueue[T]) PeekBack() (T, error) {
+	var defaultVal T
+	if rq == nil {
+		return defaultVal, fmt.Errorf("queue is nil: %w", goods.ErrNotInitialized)
+	}
+	if rq.size == 0 {
+		return defaultVal, fmt.Errorf("cannot peek back element from the queue: %w", goods.ErrEmpty)
+	}
+	return rq.data[rq.index(rq.size-1)], nil
+}
+
+// Poll returns and removes the front element.
+// Returns an error wrapping goods.ErrEmpty if the queue is empty.
+// Time Complexity: O(1) amortized (O(n) when a shrinking queue halves its buffer)
+func (rq *RingQueue[T]) Poll() (T, error) {
+	var defaultVal T
+	if rq == nil {
+		return defaultVal, fmt.Errorf("queue is nil: %w", goods.ErrNotInitialized)
+	}
+	if rq.size == 0 {
+		return defaultVal, fmt.Errorf("cannot poll front element from the queue: %w", goods.ErrEmpty)
+	}
+
+	value := rq.data[rq.head]
+	// Clear the slot so the buffer does not keep the element reachable
+	rq.data[rq.head] = defaultVal
+	rq.head = rq.next(rq.head)
+	rq.size--
+
+	if rq.shrink && rq.size <= len(rq.data)/4 && len(rq.data)/2 >= max(rq.minCapacity, 1) {
+		rq.resize(len(rq.data) / 2)
+	}
+	return value, nil
+}
+
+// OfferAll adds the elements to the back of the queue in order. The buffer grows
+// at most once, and the elements are copied in with at most two copy calls.
+// Time Complexity: O(k) amortized where k is the number of elements
+func (rq *RingQueue[T]) OfferAll(elements ...T) error {
+	if rq == nil {
+		return fmt.Errorf("queue is nil: %w", goods.ErrNotInitialized)
+	}
+	if rq.size+len(elements) > len(rq.data) {
+		rq.resize(max(len(rq.data)*2, rq.size+len(elements)))
+	}
+	if len(elements) == 0 {
+		return nil
+	}
+	// Copy up to the end of the buffer, then wrap around to the start
+	n := copy(rq.data[rq.tail:], elements)
+	copy(rq.data, elements[n:])
+	rq.tail = (rq.tail + len(elements)) % len(rq.data)
+	rq.size += len(elements)
+	return nil
+}
+
+// PollN removes up to n elements from the front and returns them in FIFO order.
+// It returns nil if the queue is empty or n is not positive.
+// Time Complexity: O(k) where k is the number of elements returned
+func (rq *RingQueue[T]) PollN(n int) []T {
+	if rq == nil || n <= 0 || rq.size == 0 {
+		return nil
+	}
+	result := make([]T, min(n, rq.size))
+	rq.DrainTo(result)
+	return result
+}
+
+// DrainTo removes up to len(dst) elements from the front, copies them into dst
+// in FIFO order and returns how many were copied, with at most two copy calls.
+// Time Complexity: O(k) where k is the number of elements copied
+func (rq *RingQueue[T]) DrainTo(dst []T) int {
+	if rq == nil {
+		return 0
+	}
+	n := min(len(dst), rq.size)
+	if n == 0 {
+		return 0
+	}
+	// The drained elements may wrap around: [head, end) then [0, ...)
+	first := min(n, len(rq.data)-rq.head)
+	copy(dst, rq.data[rq.head:rq.head+first])
+	copy(dst[first:n], rq.data[:n-first])
+	// Clear the slots so the buffer does not keep the elements reachable
+	clear(rq.data[rq.head : rq.head+first])
+	clear(rq.data[:n-first])
+	rq.head = (rq.head + n) % len(rq.data)
+	rq.size -= n
+
+	if rq.shrink {
+		newCapacity := len(rq.data)
+		for rq.size <= newCapacity/4 && newCapacity/2 >= max(rq.minCapacity, 1) {
+			newCapacity /= 2
+		}
+		if newCapacity != len(rq.data) {
+			rq.resize(newCapacity)
+		}
+	}
+	return n
+}
+
+// At returns the element at position i counted from the front (0 is the front).
+// An index outside [0, Size()) returns a *goods.IndexError.
+// Time Complexity: O(1)
+func (rq *RingQueue[T]) At(i int) (T, error) {
+	var defaultVal T
+	if rq == nil {
+		return defaultVal, fmt.Errorf("queue is nil: %w", goods.ErrNotInitialized)
+	}
+	if i < 0 || i >= rq.size {
+		return defaultVal, goods.NewIndexError(i, 0, rq.size)
+	}
+	return rq.data[rq.index(i)], nil
+}
+
+// Size returns the number of elements in the queue.
+// Time Complexity: O(1)
+func (rq *RingQueue[T]) Size() int {
+	if rq == nil {
+		return 0
+	}
+	return rq.size
+}
+
+// IsEmpty returns true if the queue has no elements.
+// Time Complexity: O(1)
+func (rq *RingQueue[T]) IsEmpty() bool {
+	if rq == nil {
+		return true
+	}
+	return rq.size == 0
+}
+
+// Capacity returns the number of elements the buffer holds before it grows.
+// Time Complexity: O(1)
+func (rq *RingQueue[T]) Capacity() int {
+	if rq == nil {
+		return 0
+	}
+	return len(rq.data)
+}
+
+// index maps a position counted from the front onto a slot in data.
+func (rq *RingQueue[T]) index(i int) int {
+	return (rq.head + i) % len(rq.data)
+}
+
+// next returns the slot after i, wrapping around the end of data.
+func (rq *RingQueue[T]) next(i int) int {
+	i++
+	if i == len(rq.data) {
+		return 0
+	}
+	return i
+}
+
+// resize copies the elements into a buffer of newCapacity slots, front first.
+// Time Complexity: O(n)
+// Space Complexity: O(newCapacity)
+func (rq *RingQueue[T]) resize(newCapacity int) {
+	newData := make([]T, newCapacity)
+	if rq.size > 0 {
+		if rq.head < rq.tail {
+			copy(newData, rq.data[rq.head:rq.tail])
+		} else {
+			// The elements wrap around: copy [head, end) then [0, tail)
+			n := copy(newData, rq.data[rq.head:])
+			copy(newData[n:], rq.data[:rq.tail])
+		}
+	}
+	rq.data = newData
+	rq.head = 0
+	rq.tail = rq.size % newCapacity
+}
@@ -0,0 +1,152 @@
+package ringqueue
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/queue"
+	"github.com/Scanf-s/goods/queue/queuetest"
+)
+
+func TestRingQueue_QueueSuite(t *testing.T) {
+	queuetest.RunQueueSuite(t, func() queue.Queue[int] {
+		return NewRingQueue[int](0)
+	})
+	t.Run("Shrinking", func(t *testing.T) {
+		queuetest.RunQueueSuite(t, func() queue.Queue[int] {
+			return NewRingQueue[int](2, WithShrink())
+		})
+	})
+}
+
+func TestRingQueue_BatchQueueSuite(t *testing.T) {
+	queuetest.RunBatchQueueSuite(t, func() queue.BatchQueue[int] {
+		return NewRingQueue[int](0)
+	})
+	t.Run("Shrinking", func(t *testing.T) {
+		queuetest.RunBatchQueueSuite(t, func() queue.BatchQueue[int] {
+			return NewRingQueue[int](2, WithShrink())
+		})
+	})
+}
+
+func TestRingQueue_WrapAround(t *testing.T) {
+	q := NewRingQueue[int](4)
+	for i := range 3 {
+		if err := q.Offer(i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for range 2 {
+		if _, err := q.Poll(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// head is now at slot 2, so these offers wrap around to slots 3, 0, 1
+	for i := 3; i < 6; i++ {
+		if err := q.Offer(i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if q.Capacity() != 4 {
+		t.Errorf("Capacity = %d, expected 4 (no growth while the ring has room)", q.Capacity())
+	}
+	for i := 2; i < 6; i++ {
+		if v, err := q.Poll(); err != nil || v != i {
+			t.Errorf("Poll = %d (err %v), expected %d", v, err, i)
+		}
+	}
+}
+
+func TestRingQueue_GrowWhileWrapped(t *testing.T) {
+	q := NewRingQueue[int](3)
+	_ = q.Offer(0)
+	_ = q.Offer(1)
+	_, _ = q.Poll()
+	_ = q.Offer(2)
+	_ = q.Offer(3) // wraps to slot 0, the ring is full
+	_ = q.Offer(4) // triggers growth with a wrapped layout
+
+	if q.Capacity() != 6 {
+		t.Errorf("Capacity = %d, expected 6", q.Capacity())
+	}
+	for i := 1; i <= 4; i++ {
+		if v, err := q.Poll(); err != nil || v != i {
+			t.Errorf("Poll = %d (err %v), expected %d", v, err, i)
+		}
+	}
+}
+
+func TestRingQueue_Shrink(t *testing.T) {
+	q := NewRingQueue[int](2, WithShrink())
+	for i := range 64 {
+		_ = q.Offer(i)
+	}
+	grown := q.Capacity()
+	for range 62 {
+		_, _ = q.Poll()
+	}
+	if q.Capacity() >= grown {
+		t.Errorf("Capacity = %d, expected it to shrink below %d", q.Capacity(), grown)
+	}
+	if q.Capacity() < 2 {
+		t.Errorf("Capacity = %d, must not shrink below the initial capacity 2", q.Capacity())
+	}
+	for i := 62; i < 64; i++ {
+		if v, err := q.Poll(); err != nil || v != i {
+			t.Errorf("Poll = %d (err %v), expected %d", v, err, i)
+		}
+	}
+
+	noShrink := NewRingQueue[int](2)
+	for i := range 64 {
+		_ = noShrink.Offer(i)
+	}
+	for range 64 {
+		_, _ = noShrink.Poll()
+	}
+	if noShrink.Capacity() != grown {
+		t.Errorf("Capacity = %d, expected %d without WithShrink", noShrink.Capacity(), grown)
+	}
+}
+
+func TestRingQueue_PeekBack_At(t *testing.T) {
+	q := NewRingQueue[string](2)
+	if _, err := q.PeekBack(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("PeekBack on empty queue error = %v, expected goods.ErrEmpty", err)
+	}
+
+	for _, v := range []string{"a", "b", "c"} {
+		_ = q.Offer(v)
+	}
+	_, _ = q.Poll()
+	_ = q.Offer("d")
+
+	if back, err := q.PeekBack(); err != nil || back != "d" {
+		t.Errorf("PeekBack = %q (err %v), expected \"d\"", back, err)
+	}
+	for i, expected := range []string{"b", "c", "d"} {
+		if v, err := q.At(i); err != nil || v != expected {
+			t.Errorf("At(%d) = %q (err %v), expected %q", i, v, err, expected)
+		}
+	}
+
+	var indexErr *goods.IndexError
+	if _, err := q.At(3); !errors.As(err, &indexErr) || indexErr.Max != 3 {
+		t.Errorf("At(3) error = %v, expected IndexError{Max:3}", err)
+	}
+	if _, err := q.At(-1); !errors.Is(err, goods.ErrIndexOutOfRange) {
+		t.Errorf("At(-1) error = %v, expected goods.ErrIndexOutOfRange", err)
+	}
+}
+
+func TestRingQueue_Nil(t *testing.T) {
+	var q *RingQueue[int]
+	if err := q.Offer(1); !errors.Is(err, goods.ErrNotInitialized) {
+		t.Errorf("Offer on nil queue error = %v, expected goods.ErrNotInitialized", err)
+	}
+	if q.Size() != 0 || !q.IsEmpty() {
+		t.Error("a nil queue should report size 0")
+	}
+}
@@ -0,0 +1,237 @@
+// Package slidingwindow aggregates the most recent samples of a stream.
+package slidingwindow
+
+import (
+	"cmp"
+	"fmt"
+	"time"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/clock"
+	"github.com/Scanf-s/goods/queue/deque"
+	ringqueue "github.com/Scanf-s/goods/queue/ring_queue"
+)
+
+// Number is the set of types SlidingWindow can sum and average.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// sample is one pushed value with its position in the stream and arrival time.
+type sample[T Number] struct {
+	seq   uint64
+	value T
+	at    time.Time
+}
+
+// SlidingWindow keeps the samples of a stream that fall in a window, either
+// the last N samples or the samples pushed during the last span of time, and
+// reports their minimum, maximum, sum and mean.
+//
+// The samples themselves live in a ringqueue.RingQueue in arrival order. Two
+// deque.MonotonicDeque hold the candidates for the minimum and the maximum, and
+// a running sum is updated as samples enter and leave. Every sample is added
+// to and removed from each structure at most once, so Push is amortized O(1)
+// and every query is O(1) apart from evicting expired samples.
+//
+// For floating-point T the running sum accumulates rounding error over very
+// long streams, as any incremental sum does.
+//
+// A SlidingWindow is not safe for concurrent use.
+type SlidingWindow[T Number] struct {
+	// samples holds the samples currently in the window, oldest first
+	samples *ringqueue.RingQueue[sample[T]]
+
+	// minimum and maximum hold the candidates for Min and Max
+	minimum *deque.MonotonicDeque[sample[T]]
+	maximum *deque.MonotonicDeque[sample[T]]
+
+	// sum is the sum of every value in samples
+	sum T
+
+	// nextSeq is the sequence number of the next pushed sample
+	nextSeq uint64
+
+	// count is the window size for a count window, 0 for a time window
+	count int
+
+	// span is the window length for a time window, 0 for a count window
+	span time.Duration
+
+	// clock timestamps samples in a time window
+	clock clock.Clock
+}
+
+// Option configures a time window created by NewTimeWindow.
+type Option func(*options)
+
+type options struct {
+	clock clock.Clock
+}
+
+// WithClock makes the window read the current time from c instead of clock.System.
+func WithClock(c clock.Clock) Option {
+	return func(o *options) {
+		o.clock = c
+	}
+}
+
+// NewCountWindow returns a window over the last size samples.
+// Returns an error wrapping goods.ErrCapacity if size is not positive.
+// Time Complexity: O(size)
+// Space Complexity: O(size)
+func NewCountWindow[T Number](size int) (*SlidingWindow[T], error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("window size must be positive, got %d: %w", size, goods.ErrCapacity)
+	}
+	// One extra slot holds the newest sample until Push evicts the oldest
+	w := newSlidingWindow[T](size + 1)
+	w.count = size
+	return w, nil
+}
+
+// NewTimeWindow returns a window over the samples pushed during the last span.
+// A sample pushed at time t leaves the window once the clock reaches t + span.
+// Returns an error wrapping goods.ErrCapacity if span is not positive.
+func NewTimeWindow[T Number](span time.Duration, opts ...Option) (*SlidingWindow[T], error) {
+	if span <= 0 {
+		return nil, fmt.Errorf("window span must be positive, got %s: %w", span, goods.ErrCapacity)
+	}
+	o := options{clock: clock.System}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	w := newSlidingWindow[T](0)
+	w.span = span
+	w.clock = o.clock
+	return w, nil
+}
+
+func newSlidingWindow[T Number](capacity int) *SlidingWindow[T] {
+	return &SlidingWindow[T]{
+		samples: ringqueue.NewRingQueue[sample[T]](capacity),
+		minimum: deque.NewMonotonicDeque(func(a, b sample[T]) int { return cmp.Compare(a.value, b.value) }),
+		maximum: deque.NewMonotonicDeque(func(a, b sample[T]) int { return cmp.Compare(b.value, a.value) }),
+	}
+}
+
+// Push adds value to the window, evicting the samples that fall out of it.
+// Time Complexity: O(1) amortized
+func (w *SlidingWindow[T]) Push(value T) error {
+	if w == nil || w.samples == nil {
+		return fmt.Errorf("please call NewCountWindow() or NewTimeWindow() first: %w", goods.ErrNotInitialized)
+	}
+	s := sample[T]{seq: w.nextSeq, value: value}
+	if w.clock != nil {
+		s.at = w.clock.Now()
+	}
+	w.nextSeq++
+
+	_ = w.samples.Offer(s)
+	_ = w.minimum.Push(s)
+	_ = w.maximum.Push(s)
+	w.sum += value
+	w.evict()
+	return nil
+}
+
+// Min returns the smallest value in the window.
+// Returns an error wrapping goods.ErrEmpty if the window is empty.
+// Time Complexity: O(1) amortized
+func (w *SlidingWindow[T]) Min() (T, error) {
+	return w.front(w.minimum, "minimum")
+}
+
+// Max returns the largest value in the window.
+// Returns an error wrapping goods.ErrEmpty if the window is empty.
+// Time Complexity: O(1) amortized
+func (w *SlidingWindow[T]) Max() (T, error) {
+	return w.front(w.maximum, "maximum")
+}
+
+// Sum returns the sum of the values in the window, 0 if it is empty.
+// Time Complexity: O(1) amortized
+func (w *SlidingWindow[T]) Sum() T {
+	if w == nil || w.samples == nil {
+		return 0
+	}
+	w.evict()
+	return w.sum
+}
+
+// Mean returns the arithmetic mean of the values in the window.
+// Returns an error wrapping goods.ErrEmpty if the window is empty.
+// Time Complexity: O(1) amortized
+func (w *SlidingWindow[T]) Mean() (float64, error) {
+	if w == nil || w.samples == nil {
+		return 0, fmt.Errorf("please call NewCountWindow() or NewTimeWindow() first: %w", goods.ErrNotInitialized)
+	}
+	w.evict()
+	if w.samples.IsEmpty() {
+		return 0, fmt.Errorf("cannot compute the mean: %w", goods.ErrEmpty)
+	}
+	return float64(w.sum) / float64(w.samples.Size()), nil
+}
+
+// Size returns the number of samples in the window.
+// Time Complexity: O(1) amortized
+func (w *SlidingWindow[T]) Size() int {
+	if w == nil || w.samples == nil {
+		return 0
+	}
+	w.evict()
+	return w.samples.Size()
+}
+
+// IsEmpty returns true if the window has no samples.
+// Time Complexity: O(1) amortized
+func (w *SlidingWindow[T]) IsEmpty() bool {
+	return w.Size() == 0
+}
+
+func (w *SlidingWindow[T]) front(candidates *deque.MonotonicDeque[sample[T]], name string) (T, error) {
+	if w == nil || w.samples == nil {
+		return 0, fmt.Errorf("please call NewCountWindow() or NewTimeWindow() first: %w", goods.ErrNotInitialized)
+	}
+	w.evict()
+	s, err := candidates.Front()
+	if err != nil {
+		return 0, fmt.Errorf("cannot get the %s: %w", name, err)
+	}
+	return s.value, nil
+}
+
+// evict removes the oldest samples while they are outside the window.
+// A time window also drops samples on queries, because time passes between pushes.
+func (w *SlidingWindow[T]) evict() {
+	var cutoff time.Time
+	if w.span > 0 {
+		cutoff = w.clock.Now().Add(-w.span)
+	}
+	for !w.samples.IsEmpty() {
+		oldest, _ := w.samples.Peek()
+		if w.count > 0 && w.samples.Size() <= w.count {
+			break
+		}
+		if w.span > 0 && oldest.at.After(cutoff) {
+			break
+		}
+		_, _ = w.samples.Poll()
+		w.sum -= oldest.value
+	}
+	if w.samples.IsEmpty() {
+		// Drop any rounding error left over from floating-point subtraction
+		w.sum = 0
+	}
+
+	// Candidates older than the oldest sample left have left the window
+	oldestSeq := w.nextSeq
+	if oldest, err := w.samples.Peek(); err == nil {
+		oldestSeq = oldest.seq
+	}
+	expired := func(s sample[T]) bool { return s.seq < oldestSeq }
+	w.minimum.PollFrontWhile(expired)
+	w.maximum.PollFrontWhile(expired)
+}
@@ -0,0 +1,123 @@
+package slidingwindow
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/clock"
+)
+
+func TestCountWindow(t *testing.T) {
+	w, err := NewCountWindow[int](3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Min(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Min on empty window error = %v, expected goods.ErrEmpty", err)
+	}
+	if _, err := w.Mean(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Mean on empty window error = %v, expected goods.ErrEmpty", err)
+	}
+
+	for _, v := range []int{4, 1, 7, 5} {
+		_ = w.Push(v)
+	}
+	// The window now holds 1, 7, 5
+	if v, _ := w.Min(); v != 1 {
+		t.Errorf("Min = %d, expected 1", v)
+	}
+	if v, _ := w.Max(); v != 7 {
+		t.Errorf("Max = %d, expected 7", v)
+	}
+	if w.Sum() != 13 || w.Size() != 3 {
+		t.Errorf("Sum = %d, Size = %d, expected 13 and 3", w.Sum(), w.Size())
+	}
+	_ = w.Push(2)
+	// The window now holds 7, 5, 2
+	if v, _ := w.Min(); v != 2 {
+		t.Errorf("Min = %d, expected 2", v)
+	}
+	if mean, _ := w.Mean(); mean != 14.0/3 {
+		t.Errorf("Mean = %v, expected %v", mean, 14.0/3)
+	}
+}
+
+func TestTimeWindow(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	w, err := NewTimeWindow[float64](10*time.Second, WithClock(fake))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = w.Push(3)
+	fake.Advance(4 * time.Second)
+	_ = w.Push(1)
+	fake.Advance(4 * time.Second)
+	_ = w.Push(2)
+	if v, _ := w.Min(); v != 1 {
+		t.Errorf("Min = %v, expected 1", v)
+	}
+	if v, _ := w.Max(); v != 3 {
+		t.Errorf("Max = %v, expected 3", v)
+	}
+
+	// At t=10s the first sample leaves the window without any push
+	fake.Advance(2 * time.Second)
+	if v, _ := w.Max(); v != 2 {
+		t.Errorf("Max after the first sample expired = %v, expected 2", v)
+	}
+	if w.Sum() != 3 || w.Size() != 2 {
+		t.Errorf("Sum = %v, Size = %d, expected 3 and 2", w.Sum(), w.Size())
+	}
+
+	fake.Advance(time.Hour)
+	if !w.IsEmpty() || w.Sum() != 0 {
+		t.Errorf("window should be empty after every sample expired, size = %d", w.Size())
+	}
+	if _, err := w.Max(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Max on expired window error = %v, expected goods.ErrEmpty", err)
+	}
+}
+
+func TestInvalidWindow(t *testing.T) {
+	if _, err := NewCountWindow[int](0); !errors.Is(err, goods.ErrCapacity) {
+		t.Errorf("NewCountWindow(0) error = %v, expected goods.ErrCapacity", err)
+	}
+	if _, err := NewTimeWindow[int](-time.Second); !errors.Is(err, goods.ErrCapacity) {
+		t.Errorf("NewTimeWindow(-1s) error = %v, expected goods.ErrCapacity", err)
+	}
+	var w *SlidingWindow[int]
+	if err := w.Push(1); !errors.Is(err, goods.ErrNotInitialized) {
+		t.Errorf("Push on nil window error = %v, expected goods.ErrNotInitialized", err)
+	}
+}
+
+// TestCountWindow_RandomizedModel compares every aggregate with a brute-force
+// computation over the last samples.
+func TestCountWindow_RandomizedModel(t *testing.T) {
+	rng := rand.New(rand.NewPCG(21, 22))
+	for _, size := range []int{1, 2, 5, 16} {
+		w, _ := NewCountWindow[int](size)
+		var pushed []int
+		for step := range 500 {
+			v := rng.IntN(100) - 50
+			_ = w.Push(v)
+			pushed = append(pushed, v)
+			window := pushed[max(len(pushed)-size, 0):]
+
+			sum := 0
+			for _, x := range window {
+				sum += x
+			}
+			minimum, _ := w.Min()
+			maximum, _ := w.Max()
+			if minimum != slices.Min(window) || maximum != slices.Max(window) || w.Sum() != sum {
+				t.Fatalf("size %d step %d: Min/Max/Sum = %d/%d/%d, expected %d/%d/%d",
+					size, step, minimum, maximum, w.Sum(), slices.Min(window), slices.Max(window), sum)
+			}
+		}
+	}
+}
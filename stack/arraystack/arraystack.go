@@ -4,6 +4,7 @@ package arraystack
 import (
 	"fmt"
 
+	"github.com/Scanf-s/goods"
 	"github.com/Scanf-s/goods/stack"
 )
 
@@ -41,7 +42,7 @@ func (as *ArrayStack[T]) Push(data T) error {
 func (as *ArrayStack[T]) Pop() (T, error) {
 	var defaultValue T
 	if len(as.data) == 0 {
-		return defaultValue, fmt.Errorf("cannot pop element from empty stack")
+		return defaultValue, fmt.Errorf("cannot pop element: %w", goods.ErrEmpty)
 	}
 
 	value := as.data[len(as.data)-1]
@@ -57,7 +58,7 @@ func (as *ArrayStack[T]) Pop() (T, error) {
 func (as *ArrayStack[T]) Top() (T, error) {
 	var defaultValue T
 	if len(as.data) == 0 {
-		return defaultValue, fmt.Errorf("cannot get top element from empty stack")
+		return defaultValue, fmt.Errorf("cannot get top element: %w", goods.ErrEmpty)
 	}
 	return as.data[len(as.data)-1], nil
 }
@@ -1,6 +1,13 @@
 package arraystack
 
-import "testing"
+import (
+	"errors"
+	"testing"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/stack"
+	"github.com/Scanf-s/goods/stack/stacktest"
+)
 
 func TestArrayStack_New(t *testing.T) {
 	arrayStack := NewArrayStack[int]()
@@ -150,3 +157,20 @@ func TestArrayStack_Size(t *testing.T) {
 		t.Error("Stack size should be 1")
 	}
 }
+
+func TestArrayStack_Errors(t *testing.T) {
+	s := NewArrayStack[int]()
+
+	if _, err := s.Pop(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Pop on empty stack error = %v, expected goods.ErrEmpty", err)
+	}
+	if _, err := s.Top(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Top on empty stack error = %v, expected goods.ErrEmpty", err)
+	}
+}
+
+func TestArrayStack_StackSuite(t *testing.T) {
+	stacktest.RunStackSuite(t, func() stack.Stack[int] {
+		return NewArrayStack[int]()
+	})
+}
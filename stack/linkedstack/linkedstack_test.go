@@ -1,6 +1,13 @@
 package linkedstack
 
-import "testing"
+import (
+	"errors"
+	"testing"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/stack"
+	"github.com/Scanf-s/goods/stack/stacktest"
+)
 
 func TestArrayStack_New(t *testing.T) {
 	arrayStack := NewLinkedStack[int]()
@@ -140,3 +147,20 @@ func TestArrayStack_Size(t *testing.T) {
 		t.Error("Stack size should be 1")
 	}
 }
+
+func TestLinkedStack_Errors(t *testing.T) {
+	s := NewLinkedStack[int]()
+
+	if _, err := s.Pop(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Pop on empty stack error = %v, expected goods.ErrEmpty", err)
+	}
+	if _, err := s.Top(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Top on empty stack error = %v, expected goods.ErrEmpty", err)
+	}
+}
+
+func TestLinkedStack_StackSuite(t *testing.T) {
+	stacktest.RunStackSuite(t, func() stack.Stack[int] {
+		return NewLinkedStack[int]()
+	})
+}
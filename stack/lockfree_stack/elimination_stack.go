@@ -0,0 +1,153 @@
+package lockfreestack
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"runtime"
+	"sync/atomic"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/stack"
+)
+
+// eliminationSpins is how many times a Push waits in the elimination array
+// for a Pop to take its element before withdrawing it.
+const eliminationSpins = 128
+
+// offer is an element a Push left in an elimination slot.
+type offer[T any] struct {
+	value T
+}
+
+// EliminationStack is a TreiberStack with elimination backoff, after Hendler,
+// Shavit and Yerushalmi.
+//
+// Under high contention most CAS attempts on the head fail, and every goroutine
+// retries on the same cache line. A concurrent Push and Pop cancel each other
+// out, though: the stack is the same whether both happen or neither does. So
+// when its CAS fails, a Push leaves its element in a random slot of a small
+// elimination array and waits briefly, and a Pop whose CAS fails looks in a
+// random slot for such an element. A Pop that takes one completes together with
+// the waiting Push without either of them touching the head. A Push whose
+// element is not taken in time withdraws it and retries on the stack.
+//
+// A slot changes hands with a single CAS from the offer to nil, made either by
+// the Pop taking the element or by the Push withdrawing it, so exactly one of
+// them wins. Every offer is a new allocation, so the CAS is immune to ABA for
+// the same reason as TreiberStack.
+type EliminationStack[T any] struct {
+	// stack holds the elements that were not eliminated
+	stack TreiberStack[T]
+
+	// slots is the elimination array
+	slots []atomic.Pointer[offer[T]]
+}
+
+// Compile-time check that EliminationStack implements the stack.Stack interface.
+var _ stack.Stack[int] = (*EliminationStack[int])(nil)
+
+// NewEliminationStack returns an empty EliminationStack with one elimination
+// slot per two processors, and at least one.
+// Time Complexity: O(GOMAXPROCS)
+func NewEliminationStack[T any]() *EliminationStack[T] {
+	return newEliminationStack[T](max(runtime.GOMAXPROCS(0)/2, 1))
+}
+
+func newEliminationStack[T any](slots int) *EliminationStack[T] {
+	return &EliminationStack[T]{slots: make([]atomic.Pointer[offer[T]], slots)}
+}
+
+// Push adds an element to the top of the stack.
+// Time Complexity: O(1), lock-free
+func (s *EliminationStack[T]) Push(element T) error {
+	if s == nil || s.slots == nil {
+		return fmt.Errorf("please call NewEliminationStack() first: %w", goods.ErrNotInitialized)
+	}
+	n := &node[T]{value: element}
+	for {
+		if s.stack.tryPush(n) || s.eliminatePush(element) {
+			return nil
+		}
+	}
+}
+
+// Pop removes and returns the top element from the stack.
+// Returns an error wrapping goods.ErrEmpty if the stack is empty.
+// Time Complexity: O(1), lock-free
+func (s *EliminationStack[T]) Pop() (T, error) {
+	var element T
+	if s == nil || s.slots == nil {
+		return element, fmt.Errorf("please call NewEliminationStack() first: %w", goods.ErrNotInitialized)
+	}
+	for {
+		head, ok := s.stack.tryPop()
+		if ok {
+			if head == nil {
+				return element, fmt.Errorf("cannot pop element: %w", goods.ErrEmpty)
+			}
+			return head.value, nil
+		}
+		if element, ok = s.eliminatePop(); ok {
+			return element, nil
+		}
+	}
+}
+
+// Top returns the top element without removing it. Elements waiting in the
+// elimination array are not part of the stack yet and are not considered.
+// Returns an error wrapping goods.ErrEmpty if the stack is empty.
+// Time Complexity: O(1)
+func (s *EliminationStack[T]) Top() (T, error) {
+	if s == nil || s.slots == nil {
+		var element T
+		return element, fmt.Errorf("please call NewEliminationStack() first: %w", goods.ErrNotInitialized)
+	}
+	return s.stack.Top()
+}
+
+// IsEmpty returns true if the stack contains no elements, subject to the same
+// staleness as Size.
+// Time Complexity: O(1)
+func (s *EliminationStack[T]) IsEmpty() bool {
+	return s.Size() == 0
+}
+
+// Size returns the number of elements in the stack, as of one instant. Under
+// concurrent use the result may already be stale when it is returned.
+// Time Complexity: O(1)
+func (s *EliminationStack[T]) Size() int {
+	if s == nil {
+		return 0
+	}
+	return s.stack.Size()
+}
+
+// eliminatePush offers value in a random slot and waits for a Pop to take it.
+// It returns true if a Pop took it, and false if the slot was busy or the
+// offer was withdrawn.
+func (s *EliminationStack[T]) eliminatePush(value T) bool {
+	o := &offer[T]{value: value}
+	slot := &s.slots[rand.IntN(len(s.slots))]
+	if !slot.CompareAndSwap(nil, o) {
+		return false
+	}
+	for range eliminationSpins {
+		if slot.Load() != o {
+			// A Pop swapped the offer out; another Push may already have
+			// filled the slot again, but never with o
+			return true
+		}
+	}
+	// Withdraw the offer, unless a Pop takes it at the last moment
+	return !slot.CompareAndSwap(o, nil)
+}
+
+// eliminatePop takes the element offered in a random slot, if there is one.
+func (s *EliminationStack[T]) eliminatePop() (T, bool) {
+	slot := &s.slots[rand.IntN(len(s.slots))]
+	if o := slot.Load(); o != nil && slot.CompareAndSwap(o, nil) {
+		return o.value, true
+	}
+	var element T
+	return element, false
+}
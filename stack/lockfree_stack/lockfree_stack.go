@@ -0,0 +1,21 @@
+// Package lockfreestack implements LIFO stacks that are safe for concurrent use
+// without mutexes.
+//
+// TreiberStack is the classic lock-free linked stack. EliminationStack adds an
+// elimination array in front of it, which lets a Push and a Pop that collide
+// under high contention hand the element over directly instead of both retrying
+// on the same head pointer.
+package lockfreestack
+
+// node is one element of a lock-free stack. A node is immutable once it has
+// been published as the head, so readers may follow it without synchronization.
+type node[T any] struct {
+	value T
+
+	// next is the node below this one, or nil at the bottom of the stack
+	next *node[T]
+
+	// size is the number of nodes from this one to the bottom, so that the
+	// head alone gives a consistent Size
+	size int
+}
@@ -0,0 +1,226 @@
+package lockfreestack
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/stack"
+	"github.com/Scanf-s/goods/stack/arraystack"
+	"github.com/Scanf-s/goods/stack/linkedstack"
+	"github.com/Scanf-s/goods/stack/stacktest"
+	gsync "github.com/Scanf-s/goods/sync"
+)
+
+func TestTreiberStack_StackSuite(t *testing.T) {
+	stacktest.RunStackSuite(t, func() stack.Stack[int] {
+		return NewTreiberStack[int]()
+	})
+}
+
+func TestEliminationStack_StackSuite(t *testing.T) {
+	stacktest.RunStackSuite(t, func() stack.Stack[int] {
+		return NewEliminationStack[int]()
+	})
+}
+
+func TestLockFreeStacks_NotInitialized(t *testing.T) {
+	var treiber *TreiberStack[int]
+	var elimination *EliminationStack[int]
+	for _, s := range []stack.Stack[int]{treiber, elimination, &EliminationStack[int]{}} {
+		if err := s.Push(1); !errors.Is(err, goods.ErrNotInitialized) {
+			t.Errorf("%T: Push error = %v, expected goods.ErrNotInitialized", s, err)
+		}
+		if _, err := s.Pop(); !errors.Is(err, goods.ErrNotInitialized) {
+			t.Errorf("%T: Pop error = %v, expected goods.ErrNotInitialized", s, err)
+		}
+		if s.Size() != 0 || !s.IsEmpty() {
+			t.Errorf("%T: uninitialized stack should be empty", s)
+		}
+	}
+
+	// The zero TreiberStack is ready to use
+	var zero TreiberStack[int]
+	if err := zero.Push(1); err != nil {
+		t.Errorf("Push on zero TreiberStack failed: %s", err)
+	}
+}
+
+// stress has every goroutine push its own range of elements, popping between
+// pushes, and checks that every element is popped exactly once.
+func stress(t *testing.T, s stack.Stack[int]) {
+	t.Helper()
+	const goroutines, perGoroutine = 8, 5000
+
+	popped := make([]atomic.Int32, goroutines*perGoroutine)
+	pop := func() bool {
+		v, err := s.Pop()
+		if err != nil {
+			if !errors.Is(err, goods.ErrEmpty) {
+				t.Errorf("Pop failed: %s", err)
+			}
+			return false
+		}
+		if popped[v].Add(1) != 1 {
+			t.Errorf("element %d popped twice", v)
+		}
+		return true
+	}
+
+	var wg sync.WaitGroup
+	for g := range goroutines {
+		wg.Go(func() {
+			for i := range perGoroutine {
+				if err := s.Push(g*perGoroutine + i); err != nil {
+					t.Error(err)
+					return
+				}
+				if i%2 == 1 {
+					pop()
+				}
+			}
+		})
+	}
+	wg.Wait()
+
+	for pop() {
+	}
+	for v := range popped {
+		if popped[v].Load() != 1 {
+			t.Fatalf("element %d popped %d times, expected once", v, popped[v].Load())
+		}
+	}
+	if !s.IsEmpty() {
+		t.Errorf("Size() = %d after popping every element", s.Size())
+	}
+}
+
+func TestTreiberStack_Stress(t *testing.T) {
+	stress(t, NewTreiberStack[int]())
+}
+
+func TestEliminationStack_Stress(t *testing.T) {
+	stress(t, NewEliminationStack[int]())
+	// A single slot makes Push and Pop meet in the elimination array more often
+	stress(t, newEliminationStack[int](1))
+}
+
+func TestTreiberStack_SizeIsConsistent(t *testing.T) {
+	s := NewTreiberStack[int]()
+	var wg sync.WaitGroup
+	var done atomic.Bool
+	wg.Go(func() {
+		for i := range 20000 {
+			_ = s.Push(i)
+			if i%3 == 0 {
+				_, _ = s.Pop()
+			}
+		}
+		done.Store(true)
+	})
+	wg.Go(func() {
+		for !done.Load() {
+			if size := s.Size(); size < 0 {
+				t.Errorf("Size() = %d", size)
+				return
+			}
+		}
+	})
+	wg.Wait()
+}
+
+func TestEliminationStack_Exchange(t *testing.T) {
+	s := newEliminationStack[int](1)
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		for !s.eliminatePush(42) {
+		}
+	})
+	for {
+		if v, ok := s.eliminatePop(); ok {
+			if v != 42 {
+				t.Errorf("eliminatePop() = %d, expected 42", v)
+			}
+			break
+		}
+	}
+	wg.Wait()
+
+	// The element was handed over directly and never reached the stack
+	if !s.IsEmpty() {
+		t.Errorf("Size() = %d after an elimination, expected 0", s.Size())
+	}
+	if s.slots[0].Load() != nil {
+		t.Error("slot still holds an offer after the exchange")
+	}
+}
+
+func TestEliminationStack_WithdrawnOffer(t *testing.T) {
+	s := newEliminationStack[int](1)
+
+	// With no Pop around, the offer is withdrawn and the slot freed
+	if s.eliminatePush(1) {
+		t.Error("eliminatePush reported a Pop that never happened")
+	}
+	if _, ok := s.eliminatePop(); ok {
+		t.Error("eliminatePop took a withdrawn offer")
+	}
+}
+
+func benchmarkStacks() []struct {
+	name     string
+	newStack func() stack.Stack[int]
+} {
+	return []struct {
+		name     string
+		newStack func() stack.Stack[int]
+	}{
+		{"Treiber", func() stack.Stack[int] { return NewTreiberStack[int]() }},
+		{"Elimination", func() stack.Stack[int] { return NewEliminationStack[int]() }},
+		{"MutexArrayStack", func() stack.Stack[int] {
+			return gsync.NewSyncStack[int](arraystack.NewArrayStack[int]())
+		}},
+		{"MutexLinkedStack", func() stack.Stack[int] {
+			return gsync.NewSyncStack[int](linkedstack.NewLinkedStack[int]())
+		}},
+	}
+}
+
+// BenchmarkPushPop has every goroutine alternate Push and Pop on one shared stack.
+func BenchmarkPushPop(b *testing.B) {
+	for _, bs := range benchmarkStacks() {
+		b.Run(bs.name, func(b *testing.B) {
+			s := bs.newStack()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					_ = s.Push(1)
+					_, _ = s.Pop()
+				}
+			})
+		})
+	}
+}
+
+// BenchmarkBurst has every goroutine push a burst of elements and pop them
+// back, like a parser pushing and popping nested scopes.
+func BenchmarkBurst(b *testing.B) {
+	const burst = 16
+	for _, bs := range benchmarkStacks() {
+		b.Run(bs.name, func(b *testing.B) {
+			s := bs.newStack()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					for i := range burst {
+						_ = s.Push(i)
+					}
+					for range burst {
+						_, _ = s.Pop()
+					}
+				}
+			})
+		})
+	}
+}
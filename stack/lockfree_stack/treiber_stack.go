@@ -0,0 +1,132 @@
+package lockfreestack
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/stack"
+)
+
+// TreiberStack is an unbounded lock-free stack, following R. Kent Treiber's
+// design: the stack is a singly linked list, and Push and Pop replace its head
+// with a single compare-and-swap, retrying if another goroutine moved it first.
+//
+// The classic pitfall of this design is the ABA problem: a Pop reads head A and
+// its successor B, other goroutines pop A, pop B and push A again, and the CAS
+// then succeeds because the head is A once more, installing the freed B. It
+// cannot happen here. Push always allocates a new node and popped nodes are
+// never reused, and the garbage collector does not free a node while any
+// goroutine still holds a pointer to it. A head that compares equal is
+// therefore the very same node, whose next field has not changed since it was
+// published.
+//
+// The zero value is an empty stack ready to use.
+type TreiberStack[T any] struct {
+	// head is the top node, or nil when the stack is empty
+	head atomic.Pointer[node[T]]
+}
+
+// Compile-time check that TreiberStack implements the stack.Stack interface.
+var _ stack.Stack[int] = (*TreiberStack[int])(nil)
+
+// NewTreiberStack returns an empty TreiberStack.
+// Time Complexity: O(1)
+func NewTreiberStack[T any]() *TreiberStack[T] {
+	return &TreiberStack[T]{}
+}
+
+// Push adds an element to the top of the stack.
+// Time Complexity: O(1), lock-free
+func (s *TreiberStack[T]) Push(element T) error {
+	if s == nil {
+		return fmt.Errorf("please call NewTreiberStack() first: %w", goods.ErrNotInitialized)
+	}
+	n := &node[T]{value: element}
+	for {
+		if s.tryPush(n) {
+			return nil
+		}
+	}
+}
+
+// Pop removes and returns the top element from the stack.
+// Returns an error wrapping goods.ErrEmpty if the stack is empty.
+// Time Complexity: O(1), lock-free
+func (s *TreiberStack[T]) Pop() (T, error) {
+	var element T
+	if s == nil {
+		return element, fmt.Errorf("please call NewTreiberStack() first: %w", goods.ErrNotInitialized)
+	}
+	for {
+		head, ok := s.tryPop()
+		if !ok {
+			continue
+		}
+		if head == nil {
+			return element, fmt.Errorf("cannot pop element: %w", goods.ErrEmpty)
+		}
+		return head.value, nil
+	}
+}
+
+// Top returns the top element without removing it.
+// Returns an error wrapping goods.ErrEmpty if the stack is empty.
+// Time Complexity: O(1)
+func (s *TreiberStack[T]) Top() (T, error) {
+	var element T
+	if s == nil {
+		return element, fmt.Errorf("please call NewTreiberStack() first: %w", goods.ErrNotInitialized)
+	}
+	head := s.head.Load()
+	if head == nil {
+		return element, fmt.Errorf("cannot get top element: %w", goods.ErrEmpty)
+	}
+	return head.value, nil
+}
+
+// IsEmpty returns true if the stack contains no elements. Under concurrent use
+// the result is a snapshot that may already be stale when it is returned.
+// Time Complexity: O(1)
+func (s *TreiberStack[T]) IsEmpty() bool {
+	return s.Size() == 0
+}
+
+// Size returns the number of elements in the stack, as of one instant. Under
+// concurrent use the result may already be stale when it is returned.
+// Time Complexity: O(1)
+func (s *TreiberStack[T]) Size() int {
+	if s == nil {
+		return 0
+	}
+	if head := s.head.Load(); head != nil {
+		return head.size
+	}
+	return 0
+}
+
+// tryPush makes one attempt to install n as the head. It returns false if
+// another goroutine changed the head first. n must not have been published yet.
+func (s *TreiberStack[T]) tryPush(n *node[T]) bool {
+	head := s.head.Load()
+	n.next = head
+	n.size = 1
+	if head != nil {
+		n.size = head.size + 1
+	}
+	return s.head.CompareAndSwap(head, n)
+}
+
+// tryPop makes one attempt to remove the head. It returns the removed node, or
+// nil if the stack was empty, and false if another goroutine changed the head
+// first.
+func (s *TreiberStack[T]) tryPop() (*node[T], bool) {
+	head := s.head.Load()
+	if head == nil {
+		return nil, true
+	}
+	if !s.head.CompareAndSwap(head, head.next) {
+		return nil, false
+	}
+	return head, true
+}
@@ -8,11 +8,11 @@ type Stack[T any] interface {
 	Push(element T) error
 
 	// Pop removes and returns the top element from the stack.
-	// Returns an error if the stack is empty.
+	// Returns an error wrapping goods.ErrEmpty if the stack is empty.
 	Pop() (T, error)
 
 	// Top returns the top element without removing it.
-	// Returns an error if the stack is empty.
+	// Returns an error wrapping goods.ErrEmpty if the stack is empty.
 	Top() (T, error)
 
 	// IsEmpty returns true if the stack contains no elements.
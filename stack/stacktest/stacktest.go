@@ -0,0 +1,146 @@
+// Package stacktest provides a conformance suite for stack.Stack implementations.
+//
+// Call RunStackSuite from an ordinary test to validate any implementation:
+//
+//	func TestMyStack(t *testing.T) {
+//		stacktest.RunStackSuite(t, func() stack.Stack[int] { return NewMyStack[int]() })
+//	}
+package stacktest
+
+import (
+	"errors"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/stack"
+)
+
+// RunStackSuite checks that the stacks returned by newStack honor the stack.Stack
+// contract: LIFO order, goods.ErrEmpty from Pop and Top on an empty stack, and
+// consistent Size and IsEmpty. newStack must return a new, empty stack on every call.
+func RunStackSuite(t *testing.T, newStack func() stack.Stack[int]) {
+	t.Helper()
+
+	t.Run("Empty", func(t *testing.T) { testEmpty(t, newStack()) })
+	t.Run("LIFOOrder", func(t *testing.T) { testLIFOOrder(t, newStack()) })
+	t.Run("TopDoesNotRemove", func(t *testing.T) { testTopDoesNotRemove(t, newStack()) })
+	t.Run("DrainAndReuse", func(t *testing.T) { testDrainAndReuse(t, newStack()) })
+	t.Run("RandomizedModel", func(t *testing.T) { testRandomizedModel(t, newStack()) })
+}
+
+func testEmpty(t *testing.T, s stack.Stack[int]) {
+	if !s.IsEmpty() || s.Size() != 0 {
+		t.Errorf("a new stack should be empty, size = %d", s.Size())
+	}
+	if _, err := s.Pop(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Pop on empty stack error = %v, expected goods.ErrEmpty", err)
+	}
+	if _, err := s.Top(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Top on empty stack error = %v, expected goods.ErrEmpty", err)
+	}
+}
+
+func testLIFOOrder(t *testing.T, s stack.Stack[int]) {
+	const count = 100
+	for i := range count {
+		if err := s.Push(i); err != nil {
+			t.Fatalf("Push(%d) failed: %s", i, err)
+		}
+		if s.Size() != i+1 {
+			t.Fatalf("Size after %d pushes = %d", i+1, s.Size())
+		}
+	}
+	for i := count - 1; i >= 0; i-- {
+		val, err := s.Pop()
+		if err != nil {
+			t.Fatalf("Pop failed: %s", err)
+		}
+		if val != i {
+			t.Fatalf("Pop = %d, expected %d (stack must be LIFO)", val, i)
+		}
+		if s.Size() != i {
+			t.Fatalf("Size after pop = %d, expected %d", s.Size(), i)
+		}
+	}
+	if !s.IsEmpty() {
+		t.Error("stack should be empty after popping every element")
+	}
+}
+
+func testTopDoesNotRemove(t *testing.T, s stack.Stack[int]) {
+	for _, v := range []int{1, 2, 3} {
+		if err := s.Push(v); err != nil {
+			t.Fatalf("Push(%d) failed: %s", v, err)
+		}
+	}
+	for range 3 {
+		if val, err := s.Top(); err != nil || val != 3 {
+			t.Errorf("Top = %d (err %v), expected 3", val, err)
+		}
+	}
+	if s.Size() != 3 {
+		t.Errorf("Top must not change the size, got %d, expected 3", s.Size())
+	}
+}
+
+func testDrainAndReuse(t *testing.T, s stack.Stack[int]) {
+	for round := range 3 {
+		for i := range 5 {
+			if err := s.Push(round*10 + i); err != nil {
+				t.Fatalf("Push failed: %s", err)
+			}
+		}
+		for i := 4; i >= 0; i-- {
+			if val, err := s.Pop(); err != nil || val != round*10+i {
+				t.Fatalf("round %d: Pop = %d (err %v), expected %d", round, val, err, round*10+i)
+			}
+		}
+		if _, err := s.Pop(); !errors.Is(err, goods.ErrEmpty) {
+			t.Errorf("round %d: Pop on drained stack error = %v, expected goods.ErrEmpty", round, err)
+		}
+	}
+}
+
+// testRandomizedModel applies a random mix of operations to s and to a plain
+// slice, failing on the first observable difference.
+func testRandomizedModel(t *testing.T, s stack.Stack[int]) {
+	rng := rand.New(rand.NewPCG(5, 6))
+	var model []int
+	for step := range 2000 {
+		switch op := rng.IntN(4); op {
+		case 0, 1:
+			v := rng.Int()
+			if err := s.Push(v); err != nil {
+				t.Fatalf("step %d: Push failed: %s", step, err)
+			}
+			model = append(model, v)
+		case 2, 3:
+			var (
+				val int
+				err error
+			)
+			if op == 2 {
+				val, err = s.Pop()
+			} else {
+				val, err = s.Top()
+			}
+			if len(model) == 0 {
+				if !errors.Is(err, goods.ErrEmpty) {
+					t.Fatalf("step %d: error on empty stack = %v, expected goods.ErrEmpty", step, err)
+				}
+				continue
+			}
+			top := model[len(model)-1]
+			if err != nil || val != top {
+				t.Fatalf("step %d: got %d (err %v), expected %d", step, val, err, top)
+			}
+			if op == 2 {
+				model = model[:len(model)-1]
+			}
+		}
+		if s.Size() != len(model) {
+			t.Fatalf("step %d: Size = %d, expected %d", step, s.Size(), len(model))
+		}
+	}
+}
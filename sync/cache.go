@@ -0,0 +1,268 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	stdsync "sync"
+	"time"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/cache"
+	"github.com/Scanf-s/goods/clock"
+)
+
+// ErrInvalidInterval is returned when a janitor interval is not positive.
+var ErrInvalidInterval = errors.New("interval must be positive")
+
+// SyncCache is a cache.Cache guarded by a sync.RWMutex.
+//
+// Peek, Contains, Len, Keys and All take the read lock. Get takes the write
+// lock, not the read lock: a hit updates the eviction state of the cache, such
+// as the recency order of an LRU cache.
+//
+// Callbacks registered on the wrapped cache, such as LRUCache.OnEvict, run
+// while the lock is held.
+type SyncCache[K comparable, V any] struct {
+	// mu guards cache
+	mu stdsync.RWMutex
+
+	// cache is the wrapped cache
+	cache cache.Cache[K, V]
+}
+
+// Compile-time check that SyncCache implements the cache.Cache interface.
+var _ cache.Cache[int, int] = (*SyncCache[int, int])(nil)
+
+// Expirer is implemented by caches whose entries can expire, such as
+// lru_cache.LRUCache, and which can reclaim the expired entries on demand.
+type Expirer interface {
+	// RemoveExpired removes every expired entry and returns how many it removed.
+	RemoveExpired() int
+}
+
+// NewSyncCache returns a SyncCache wrapping c. c must not be used directly afterwards.
+// Time Complexity: O(1)
+func NewSyncCache[K comparable, V any](c cache.Cache[K, V]) *SyncCache[K, V] {
+	return &SyncCache[K, V]{cache: c}
+}
+
+// Get returns the value cached under key and whether it was found.
+func (sc *SyncCache[K, V]) Get(key K) (V, bool) {
+	if sc == nil || sc.cache == nil {
+		var value V
+		return value, false
+	}
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return sc.cache.Get(key)
+}
+
+// Put stores data under key.
+func (sc *SyncCache[K, V]) Put(key K, data V) {
+	if sc == nil || sc.cache == nil {
+		return
+	}
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.cache.Put(key, data)
+}
+
+// UpdateCapacity changes the capacity of the cache.
+func (sc *SyncCache[K, V]) UpdateCapacity(capacity int) error {
+	if sc == nil || sc.cache == nil {
+		return fmt.Errorf("please call NewSyncCache() first: %w", goods.ErrNotInitialized)
+	}
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return sc.cache.UpdateCapacity(capacity)
+}
+
+// Delete removes key from the cache and reports whether it was cached.
+func (sc *SyncCache[K, V]) Delete(key K) bool {
+	if sc == nil || sc.cache == nil {
+		return false
+	}
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return sc.cache.Delete(key)
+}
+
+// Peek returns the value cached under key without counting as a use.
+func (sc *SyncCache[K, V]) Peek(key K) (V, bool) {
+	if sc == nil || sc.cache == nil {
+		var value V
+		return value, false
+	}
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.cache.Peek(key)
+}
+
+// Contains reports whether key is cached, without counting as a use.
+func (sc *SyncCache[K, V]) Contains(key K) bool {
+	if sc == nil || sc.cache == nil {
+		return false
+	}
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.cache.Contains(key)
+}
+
+// Len returns the number of cached keys.
+func (sc *SyncCache[K, V]) Len() int {
+	if sc == nil || sc.cache == nil {
+		return 0
+	}
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.cache.Len()
+}
+
+// Keys returns the cached keys in eviction order.
+func (sc *SyncCache[K, V]) Keys() []K {
+	if sc == nil || sc.cache == nil {
+		return nil
+	}
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.cache.Keys()
+}
+
+// Purge removes every key from the cache.
+func (sc *SyncCache[K, V]) Purge() {
+	if sc == nil || sc.cache == nil {
+		return
+	}
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.cache.Purge()
+}
+
+// All returns an iterator over the cached key-value pairs in eviction order.
+// Like SyncList.All, it walks a snapshot copied under the read lock when
+// iteration starts, so the loop body may call any method of the cache.
+// Time Complexity: O(n) for the snapshot
+func (sc *SyncCache[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if sc == nil || sc.cache == nil {
+			return
+		}
+		type entry struct {
+			key   K
+			value V
+		}
+		sc.mu.RLock()
+		entries := make([]entry, 0, sc.cache.Len())
+		for key, value := range sc.cache.All() {
+			entries = append(entries, entry{key, value})
+		}
+		sc.mu.RUnlock()
+
+		for _, e := range entries {
+			if !yield(e.key, e.value) {
+				return
+			}
+		}
+	}
+}
+
+// PutIfAbsent stores data under key unless key is already cached.
+// It returns the value cached under key afterwards and whether it was already
+// there (loaded), in which case data is discarded.
+func (sc *SyncCache[K, V]) PutIfAbsent(key K, data V) (actual V, loaded bool) {
+	if sc == nil || sc.cache == nil {
+		return data, false
+	}
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if value, ok := sc.cache.Get(key); ok {
+		return value, true
+	}
+	sc.cache.Put(key, data)
+	return data, false
+}
+
+// ComputeIfAbsent returns the value cached under key. On a miss it calls
+// compute(key), stores the result and returns it, so that concurrent callers
+// asking for the same missing key compute it only once.
+//
+// compute runs under the write lock, which blocks every other caller for its
+// duration; it must not call methods of sc.
+func (sc *SyncCache[K, V]) ComputeIfAbsent(key K, compute func(key K) V) V {
+	if sc == nil || sc.cache == nil {
+		return compute(key)
+	}
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if value, ok := sc.cache.Get(key); ok {
+		return value
+	}
+	value := compute(key)
+	sc.cache.Put(key, value)
+	return value
+}
+
+// JanitorOption configures a janitor started by StartJanitor.
+type JanitorOption func(*janitorOptions)
+
+type janitorOptions struct {
+	clock clock.Clock
+}
+
+// WithJanitorClock makes the janitor wait for its interval on c instead of
+// clock.System, so that tests can run it with a clock.Fake.
+func WithJanitorClock(c clock.Clock) JanitorOption {
+	return func(o *janitorOptions) {
+		o.clock = c
+	}
+}
+
+// StartJanitor starts a goroutine that calls RemoveExpired on the wrapped cache
+// under the write lock every interval, until ctx is done. It reclaims expired
+// entries of a cache that is not being used; a busy cache reclaims them on
+// access anyway.
+// Returns an error wrapping errors.ErrUnsupported if the wrapped cache does not
+// implement Expirer, and ErrInvalidInterval if interval is not positive.
+func (sc *SyncCache[K, V]) StartJanitor(ctx context.Context, interval time.Duration, opts ...JanitorOption) error {
+	if sc == nil || sc.cache == nil {
+		return fmt.Errorf("please call NewSyncCache() first: %w", goods.ErrNotInitialized)
+	}
+	expirer, ok := sc.cache.(Expirer)
+	if !ok {
+		return fmt.Errorf("cannot start a janitor for %T: %w", sc.cache, errors.ErrUnsupported)
+	}
+	if interval <= 0 {
+		return fmt.Errorf("cannot start a janitor every %s: %w", interval, ErrInvalidInterval)
+	}
+	o := janitorOptions{clock: clock.System}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-o.clock.After(interval):
+				sc.mu.Lock()
+				expirer.RemoveExpired()
+				sc.mu.Unlock()
+			}
+		}
+	}()
+	return nil
+}
+
+// Do calls fn with the wrapped cache while holding the write lock, so that fn
+// can run any sequence of calls atomically. fn must not call methods of sc or
+// keep c after it returns.
+func (sc *SyncCache[K, V]) Do(fn func(c cache.Cache[K, V])) {
+	if sc == nil || sc.cache == nil {
+		return
+	}
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	fn(sc.cache)
+}
@@ -0,0 +1,192 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"slices"
+	stdsync "sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/cache"
+	"github.com/Scanf-s/goods/cache/cachetest"
+	"github.com/Scanf-s/goods/cache/lru_cache"
+	"github.com/Scanf-s/goods/clock"
+)
+
+func newSyncLRU(t *testing.T, capacity int) *SyncCache[int, int] {
+	t.Helper()
+	c, err := lru_cache.NewLRUCache[int, int](capacity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewSyncCache[int, int](c)
+}
+
+func TestSyncCache_LRUSuite(t *testing.T) {
+	cachetest.RunLRUSuite(t, func(capacity int) cache.Cache[int, int] {
+		return newSyncLRU(t, capacity)
+	})
+}
+
+func TestSyncCache_ConcurrentGetPut(t *testing.T) {
+	sc := newSyncLRU(t, 64)
+
+	var wg stdsync.WaitGroup
+	for w := range 8 {
+		wg.Go(func() {
+			for i := range 2000 {
+				key := (w*31 + i) % 128
+				sc.Put(key, key*10)
+				if v, ok := sc.Get(key); ok && v != key*10 {
+					t.Errorf("Get(%d) = %d, expected %d", key, v, key*10)
+					return
+				}
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func TestSyncCache_PutIfAbsent(t *testing.T) {
+	sc := newSyncLRU(t, 4)
+
+	if actual, loaded := sc.PutIfAbsent(1, 10); loaded || actual != 10 {
+		t.Errorf("first PutIfAbsent(1, 10) = (%d, %v), expected (10, false)", actual, loaded)
+	}
+	if actual, loaded := sc.PutIfAbsent(1, 20); !loaded || actual != 10 {
+		t.Errorf("second PutIfAbsent(1, 20) = (%d, %v), expected (10, true)", actual, loaded)
+	}
+
+	// Exactly one of many racing callers stores its value
+	var stored atomic.Int64
+	var wg stdsync.WaitGroup
+	for w := range 16 {
+		wg.Go(func() {
+			if _, loaded := sc.PutIfAbsent(2, w); !loaded {
+				stored.Add(1)
+			}
+		})
+	}
+	wg.Wait()
+	if stored.Load() != 1 {
+		t.Errorf("%d callers stored a value for the same key, expected 1", stored.Load())
+	}
+}
+
+func TestSyncCache_ComputeIfAbsent(t *testing.T) {
+	sc := newSyncLRU(t, 4)
+
+	var calls atomic.Int64
+	compute := func(key int) int {
+		calls.Add(1)
+		return key * 100
+	}
+	var wg stdsync.WaitGroup
+	for range 16 {
+		wg.Go(func() {
+			if v := sc.ComputeIfAbsent(7, compute); v != 700 {
+				t.Errorf("ComputeIfAbsent(7) = %d, expected 700", v)
+			}
+		})
+	}
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("compute called %d times for one missing key, expected 1", calls.Load())
+	}
+	if v, ok := sc.Get(7); !ok || v != 700 {
+		t.Errorf("Get(7) = (%d, %v), expected (700, true)", v, ok)
+	}
+}
+
+func TestSyncCache_NotInitialized(t *testing.T) {
+	var sc *SyncCache[int, int]
+	if err := sc.UpdateCapacity(1); !errors.Is(err, goods.ErrNotInitialized) {
+		t.Errorf("UpdateCapacity on nil SyncCache error = %v, expected goods.ErrNotInitialized", err)
+	}
+	if _, ok := sc.Get(1); ok {
+		t.Error("Get on nil SyncCache reported a hit")
+	}
+}
+
+func TestSyncCache_AllIsSnapshot(t *testing.T) {
+	sc := newSyncLRU(t, 4)
+	for k := range 3 {
+		sc.Put(k, k)
+	}
+
+	// The body runs outside the lock, so it may modify the cache
+	var keys []int
+	for k := range sc.All() {
+		keys = append(keys, k)
+		sc.Delete(k)
+	}
+	if !slices.Equal(keys, []int{0, 1, 2}) {
+		t.Errorf("All() yielded %v, expected [0 1 2]", keys)
+	}
+	if sc.Len() != 0 {
+		t.Errorf("Len() = %d after deleting every key", sc.Len())
+	}
+}
+
+func TestSyncCache_StartJanitor(t *testing.T) {
+	fake := clock.NewFake(time.Unix(0, 0))
+	c, err := lru_cache.NewLRUCache[int, int](4, lru_cache.WithClock(fake))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sc := NewSyncCache[int, int](c)
+	sc.Do(func(c cache.Cache[int, int]) {
+		c.(*lru_cache.LRUCache[int, int]).PutWithTTL(1, 1, time.Second)
+	})
+	sc.Put(2, 2)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := sc.StartJanitor(ctx, time.Minute, WithJanitorClock(fake)); err != nil {
+		t.Fatal(err)
+	}
+	waitForTimers(t, fake)
+
+	// Nobody touches the cache; the janitor alone must reclaim the entry. Once
+	// it waits for its next round, the first one is done.
+	fake.Advance(time.Minute)
+	waitForTimers(t, fake)
+	if sc.Len() != 1 {
+		t.Fatalf("Len() = %d; the janitor did not reclaim the expired entry", sc.Len())
+	}
+}
+
+// waitForTimers blocks until a timer is pending on fake, i.e. until the
+// janitor is waiting for the clock.
+func waitForTimers(t *testing.T, fake *clock.Fake) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for fake.PendingTimers() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the janitor to wait for the clock")
+		}
+		runtime.Gosched()
+	}
+}
+
+func TestSyncCache_StartJanitorErrors(t *testing.T) {
+	sc := NewSyncCache[int, int](noExpiryCache{})
+	if err := sc.StartJanitor(context.Background(), time.Second); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("StartJanitor on a cache without RemoveExpired error = %v, expected errors.ErrUnsupported", err)
+	}
+	for _, interval := range []time.Duration{0, -time.Second} {
+		if err := newSyncLRU(t, 1).StartJanitor(context.Background(), interval); !errors.Is(err, ErrInvalidInterval) {
+			t.Errorf("StartJanitor(%s) error = %v, expected ErrInvalidInterval", interval, err)
+		}
+	}
+}
+
+// noExpiryCache is a cache.Cache that does not implement Expirer.
+type noExpiryCache struct {
+	cache.Cache[int, int]
+}
@@ -0,0 +1,199 @@
+package sync
+
+import (
+	"fmt"
+	"iter"
+	stdsync "sync"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/list"
+)
+
+// SyncList is a list.List guarded by a sync.RWMutex. Get, Size, IsEmpty and
+// the iterators take the read lock, so any number of readers run in parallel;
+// every other method takes the write lock.
+type SyncList[T any] struct {
+	// mu guards list
+	mu stdsync.RWMutex
+
+	// list is the wrapped list
+	list list.List[T]
+}
+
+// Compile-time check that SyncList implements the list.List interface.
+var _ list.List[int] = (*SyncList[int])(nil)
+
+// NewSyncList returns a SyncList wrapping l. l must not be used directly afterwards.
+// Time Complexity: O(1)
+func NewSyncList[T any](l list.List[T]) *SyncList[T] {
+	return &SyncList[T]{list: l}
+}
+
+// Append adds an element at the end of the list.
+func (sl *SyncList[T]) Append(element T) error {
+	if sl == nil || sl.list == nil {
+		return fmt.Errorf("please call NewSyncList() first: %w", goods.ErrNotInitialized)
+	}
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	return sl.list.Append(element)
+}
+
+// AppendAll adds all elements to the end of the list. Other goroutines never
+// observe the list with only some of them added.
+func (sl *SyncList[T]) AppendAll(elements ...T) error {
+	if sl == nil || sl.list == nil {
+		return fmt.Errorf("please call NewSyncList() first: %w", goods.ErrNotInitialized)
+	}
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	return sl.list.AppendAll(elements...)
+}
+
+// Prepend adds an element at the head of the list.
+func (sl *SyncList[T]) Prepend(element T) error {
+	if sl == nil || sl.list == nil {
+		return fmt.Errorf("please call NewSyncList() first: %w", goods.ErrNotInitialized)
+	}
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	return sl.list.Prepend(element)
+}
+
+// Add inserts an element at the specific index in the list.
+func (sl *SyncList[T]) Add(index int, element T) error {
+	if sl == nil || sl.list == nil {
+		return fmt.Errorf("please call NewSyncList() first: %w", goods.ErrNotInitialized)
+	}
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	return sl.list.Add(index, element)
+}
+
+// Set replaces the element at the specific index in the list with newElement.
+func (sl *SyncList[T]) Set(index int, newElement T) error {
+	if sl == nil || sl.list == nil {
+		return fmt.Errorf("please call NewSyncList() first: %w", goods.ErrNotInitialized)
+	}
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	return sl.list.Set(index, newElement)
+}
+
+// Get returns the element at the specific index in the list.
+func (sl *SyncList[T]) Get(index int) (T, error) {
+	if sl == nil || sl.list == nil {
+		var element T
+		return element, fmt.Errorf("please call NewSyncList() first: %w", goods.ErrNotInitialized)
+	}
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+	return sl.list.Get(index)
+}
+
+// Delete removes the element at the specific index in the list.
+func (sl *SyncList[T]) Delete(index int) error {
+	if sl == nil || sl.list == nil {
+		return fmt.Errorf("please call NewSyncList() first: %w", goods.ErrNotInitialized)
+	}
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	return sl.list.Delete(index)
+}
+
+// Size returns the number of elements in the list.
+func (sl *SyncList[T]) Size() int {
+	if sl == nil || sl.list == nil {
+		return 0
+	}
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+	return sl.list.Size()
+}
+
+// IsEmpty returns true if the list is empty.
+func (sl *SyncList[T]) IsEmpty() bool {
+	return sl.Size() == 0
+}
+
+// Clear removes all elements from the list.
+func (sl *SyncList[T]) Clear() {
+	if sl == nil || sl.list == nil {
+		return
+	}
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	sl.list.Clear()
+}
+
+// All returns an iterator over index-element pairs from head to tail.
+//
+// The iterator walks a snapshot copied under the read lock when iteration
+// starts, so it never panics with list.ErrConcurrentModification and the loop
+// body may call any method of the list, including ones that modify it.
+// Time Complexity: O(n) for the snapshot
+func (sl *SyncList[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for index, element := range sl.snapshot() {
+			if !yield(index, element) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over the elements from head to tail. Like All, it
+// walks a snapshot taken when iteration starts.
+// Time Complexity: O(n) for the snapshot
+func (sl *SyncList[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, element := range sl.snapshot() {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Update replaces the element at index with update(element) as one atomic step,
+// so no other write can slip in between reading and storing the element.
+// update runs under the write lock and must not call methods of sl.
+// An index outside [0, Size()) returns a *goods.IndexError.
+func (sl *SyncList[T]) Update(index int, update func(element T) T) error {
+	if sl == nil || sl.list == nil {
+		return fmt.Errorf("please call NewSyncList() first: %w", goods.ErrNotInitialized)
+	}
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	element, err := sl.list.Get(index)
+	if err != nil {
+		return err
+	}
+	return sl.list.Set(index, update(element))
+}
+
+// Do calls fn with the wrapped list while holding the write lock, so that fn
+// can run any sequence of calls atomically. fn must not call methods of sl or
+// keep l after it returns.
+func (sl *SyncList[T]) Do(fn func(l list.List[T])) {
+	if sl == nil || sl.list == nil {
+		return
+	}
+	sl.mu.Lock()
+	defer sl.mu.Unlock()
+	fn(sl.list)
+}
+
+// snapshot returns a copy of the elements taken under the read lock.
+func (sl *SyncList[T]) snapshot() []T {
+	if sl == nil || sl.list == nil {
+		return nil
+	}
+	sl.mu.RLock()
+	defer sl.mu.RUnlock()
+	elements := make([]T, 0, sl.list.Size())
+	for element := range sl.list.Values() {
+		elements = append(elements, element)
+	}
+	return elements
+}
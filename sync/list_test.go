@@ -0,0 +1,131 @@
+package sync
+
+import (
+	"errors"
+	"slices"
+	stdsync "sync"
+	"testing"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/list"
+	"github.com/Scanf-s/goods/list/arraylist"
+	"github.com/Scanf-s/goods/list/linkedlist"
+	"github.com/Scanf-s/goods/list/listtest"
+)
+
+func TestSyncList_ListSuite(t *testing.T) {
+	listtest.RunListSuite(t, func() list.List[int] {
+		return NewSyncList[int](arraylist.New[int](0))
+	})
+}
+
+func TestSyncList_ConcurrentAppend(t *testing.T) {
+	sl := NewSyncList[int](linkedlist.NewDoublyLinkedList[int]())
+	const writers, perWriter = 8, 500
+
+	var wg stdsync.WaitGroup
+	for w := range writers {
+		wg.Go(func() {
+			for i := range perWriter {
+				if err := sl.Append(w*perWriter + i); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		})
+		// Readers iterate while the writers append
+		wg.Go(func() {
+			for range 50 {
+				previous := -1
+				for index := range sl.All() {
+					if index != previous+1 {
+						t.Errorf("iterator skipped from index %d to %d", previous, index)
+						return
+					}
+					previous = index
+				}
+			}
+		})
+	}
+	wg.Wait()
+
+	if sl.Size() != writers*perWriter {
+		t.Fatalf("Size() = %d, expected %d", sl.Size(), writers*perWriter)
+	}
+	values := slices.Sorted(sl.Values())
+	for i, v := range values {
+		if v != i {
+			t.Fatalf("sorted values[%d] = %d, expected %d", i, v, i)
+		}
+	}
+}
+
+func TestSyncList_Update(t *testing.T) {
+	sl := NewSyncList[int](arraylist.New[int](0))
+	_ = sl.Append(0)
+
+	var wg stdsync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			for range 1000 {
+				if err := sl.Update(0, func(v int) int { return v + 1 }); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		})
+	}
+	wg.Wait()
+
+	if v, _ := sl.Get(0); v != 8000 {
+		t.Errorf("Get(0) = %d after 8000 concurrent increments", v)
+	}
+	var indexErr *goods.IndexError
+	if err := sl.Update(1, func(v int) int { return v }); !errors.As(err, &indexErr) {
+		t.Errorf("Update(1) error = %v, expected a *goods.IndexError", err)
+	}
+}
+
+func TestSyncList_IteratorBodyMayModify(t *testing.T) {
+	sl := NewSyncList[int](linkedlist.NewSinglyLinkedList[int]())
+	_ = sl.AppendAll(1, 2, 3)
+
+	// The body runs outside the lock and walks a snapshot, so it neither
+	// deadlocks nor panics with list.ErrConcurrentModification
+	var seen []int
+	for v := range sl.Values() {
+		seen = append(seen, v)
+		_ = sl.Append(v * 10)
+	}
+	if !slices.Equal(seen, []int{1, 2, 3}) {
+		t.Errorf("iterated %v, expected the snapshot [1 2 3]", seen)
+	}
+	if got := slices.Collect(sl.Values()); !slices.Equal(got, []int{1, 2, 3, 10, 20, 30}) {
+		t.Errorf("Values() = %v after the loop", got)
+	}
+}
+
+func TestSyncList_Do(t *testing.T) {
+	sl := NewSyncList[int](arraylist.New[int](0))
+	sl.Do(func(l list.List[int]) {
+		if l.IsEmpty() {
+			_ = l.AppendAll(1, 2)
+		}
+	})
+	if got := slices.Collect(sl.Values()); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("Values() = %v, expected [1 2]", got)
+	}
+}
+
+func TestSyncList_NotInitialized(t *testing.T) {
+	var sl *SyncList[int]
+	if err := sl.Append(1); !errors.Is(err, goods.ErrNotInitialized) {
+		t.Errorf("Append on nil SyncList error = %v, expected goods.ErrNotInitialized", err)
+	}
+	if _, err := (&SyncList[int]{}).Get(0); !errors.Is(err, goods.ErrNotInitialized) {
+		t.Errorf("Get on zero SyncList error = %v, expected goods.ErrNotInitialized", err)
+	}
+	if sl.Size() != 0 || !sl.IsEmpty() {
+		t.Error("nil SyncList should be empty")
+	}
+}
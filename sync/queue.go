@@ -0,0 +1,116 @@
+package sync
+
+import (
+	"fmt"
+	stdsync "sync"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/queue"
+)
+
+// SyncQueue is a queue.Queue guarded by a sync.Mutex.
+//
+// Unlike the other wrappers it has no read lock: queue traffic is dominated by
+// Offer and Poll, and some queues, such as chanqueue.ChanQueue, update their
+// state in Peek and Size, so every method takes the lock exclusively.
+type SyncQueue[T any] struct {
+	// mu guards queue
+	mu stdsync.Mutex
+
+	// queue is the wrapped queue
+	queue queue.Queue[T]
+}
+
+// Compile-time check that SyncQueue implements the queue.Queue interface.
+var _ queue.Queue[int] = (*SyncQueue[int])(nil)
+
+// NewSyncQueue returns a SyncQueue wrapping q. q must not be used directly afterwards.
+// Time Complexity: O(1)
+func NewSyncQueue[T any](q queue.Queue[T]) *SyncQueue[T] {
+	return &SyncQueue[T]{queue: q}
+}
+
+// Offer adds an element to the back of the queue.
+func (sq *SyncQueue[T]) Offer(element T) error {
+	if sq == nil || sq.queue == nil {
+		return fmt.Errorf("please call NewSyncQueue() first: %w", goods.ErrNotInitialized)
+	}
+	sq.mu.Lock()
+	defer sq.mu.Unlock()
+	return sq.queue.Offer(element)
+}
+
+// Peek returns the front element without removing it.
+// Returns an error wrapping goods.ErrEmpty if the queue is empty.
+func (sq *SyncQueue[T]) Peek() (T, error) {
+	if sq == nil || sq.queue == nil {
+		var element T
+		return element, fmt.Errorf("please call NewSyncQueue() first: %w", goods.ErrNotInitialized)
+	}
+	sq.mu.Lock()
+	defer sq.mu.Unlock()
+	return sq.queue.Peek()
+}
+
+// Poll removes and returns the front element.
+// Returns an error wrapping goods.ErrEmpty if the queue is empty.
+func (sq *SyncQueue[T]) Poll() (T, error) {
+	if sq == nil || sq.queue == nil {
+		var element T
+		return element, fmt.Errorf("please call NewSyncQueue() first: %w", goods.ErrNotInitialized)
+	}
+	sq.mu.Lock()
+	defer sq.mu.Unlock()
+	return sq.queue.Poll()
+}
+
+// Size returns the number of elements in the queue.
+func (sq *SyncQueue[T]) Size() int {
+	if sq == nil || sq.queue == nil {
+		return 0
+	}
+	sq.mu.Lock()
+	defer sq.mu.Unlock()
+	return sq.queue.Size()
+}
+
+// IsEmpty returns true if the queue has no elements.
+func (sq *SyncQueue[T]) IsEmpty() bool {
+	return sq.Size() == 0
+}
+
+// PollIfNotEmpty removes and returns the front element if there is one.
+// It reports false instead of an error when the queue is empty.
+func (sq *SyncQueue[T]) PollIfNotEmpty() (T, bool) {
+	return sq.PollIf(func(T) bool { return true })
+}
+
+// PollIf removes and returns the front element if the queue is not empty and
+// pred(front) returns true, as one atomic step. pred runs under the lock and
+// must not call methods of sq.
+func (sq *SyncQueue[T]) PollIf(pred func(front T) bool) (T, bool) {
+	var element T
+	if sq == nil || sq.queue == nil {
+		return element, false
+	}
+	sq.mu.Lock()
+	defer sq.mu.Unlock()
+	front, err := sq.queue.Peek()
+	if err != nil || !pred(front) {
+		return element, false
+	}
+	element, err = sq.queue.Poll()
+	return element, err == nil
+}
+
+// Do calls fn with the wrapped queue while holding the lock, so that fn can run
+// any sequence of calls atomically. fn must not call methods of sq or keep q
+// after it returns.
+func (sq *SyncQueue[T]) Do(fn func(q queue.Queue[T])) {
+	if sq == nil || sq.queue == nil {
+		return
+	}
+	sq.mu.Lock()
+	defer sq.mu.Unlock()
+	fn(sq.queue)
+}
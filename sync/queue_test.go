@@ -0,0 +1,94 @@
+package sync
+
+import (
+	"errors"
+	stdsync "sync"
+	"testing"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/queue"
+	"github.com/Scanf-s/goods/queue/queuetest"
+	ringqueue "github.com/Scanf-s/goods/queue/ring_queue"
+)
+
+func TestSyncQueue_QueueSuite(t *testing.T) {
+	queuetest.RunQueueSuite(t, func() queue.Queue[int] {
+		return NewSyncQueue[int](ringqueue.NewRingQueue[int](0))
+	})
+}
+
+func TestSyncQueue_ConcurrentProducersConsumers(t *testing.T) {
+	sq := NewSyncQueue[int](ringqueue.NewRingQueue[int](0))
+	const producers, perProducer = 4, 2000
+
+	var wg stdsync.WaitGroup
+	for p := range producers {
+		wg.Go(func() {
+			for i := range perProducer {
+				_ = sq.Offer(p*perProducer + i)
+			}
+		})
+	}
+	wg.Wait()
+
+	// Each producer's elements come out in the order it offered them
+	var mu stdsync.Mutex
+	last := make([]int, producers)
+	for p := range last {
+		last[p] = -1
+	}
+	polled := 0
+	for range 4 {
+		wg.Go(func() {
+			for {
+				mu.Lock()
+				v, ok := sq.PollIfNotEmpty()
+				if !ok {
+					mu.Unlock()
+					return
+				}
+				p, i := v/perProducer, v%perProducer
+				if i <= last[p] {
+					t.Errorf("producer %d: element %d polled after %d", p, i, last[p])
+				}
+				last[p] = i
+				polled++
+				mu.Unlock()
+			}
+		})
+	}
+	wg.Wait()
+
+	if polled != producers*perProducer {
+		t.Errorf("polled %d elements, expected %d", polled, producers*perProducer)
+	}
+}
+
+func TestSyncQueue_PollIf(t *testing.T) {
+	sq := NewSyncQueue[int](ringqueue.NewRingQueue[int](0))
+	_ = sq.Offer(5)
+	_ = sq.Offer(1)
+
+	if v, ok := sq.PollIf(func(front int) bool { return front < 3 }); ok {
+		t.Errorf("PollIf(<3) = (%d, true) with 5 in front", v)
+	}
+	if v, ok := sq.PollIf(func(front int) bool { return front >= 3 }); !ok || v != 5 {
+		t.Errorf("PollIf(>=3) = (%d, %v), expected (5, true)", v, ok)
+	}
+	sq.Do(func(q queue.Queue[int]) {
+		_, _ = q.Poll()
+	})
+	if _, ok := sq.PollIfNotEmpty(); ok {
+		t.Error("PollIfNotEmpty on an empty queue reported an element")
+	}
+}
+
+func TestSyncQueue_NotInitialized(t *testing.T) {
+	var sq *SyncQueue[int]
+	if err := sq.Offer(1); !errors.Is(err, goods.ErrNotInitialized) {
+		t.Errorf("Offer on nil SyncQueue error = %v, expected goods.ErrNotInitialized", err)
+	}
+	if _, err := sq.Poll(); !errors.Is(err, goods.ErrNotInitialized) {
+		t.Errorf("Poll on nil SyncQueue error = %v, expected goods.ErrNotInitialized", err)
+	}
+}
@@ -0,0 +1,114 @@
+package sync
+
+import (
+	"fmt"
+	stdsync "sync"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/stack"
+)
+
+// SyncStack is a stack.Stack guarded by a sync.RWMutex. Top, Size and IsEmpty
+// take the read lock; Push and Pop take the write lock.
+type SyncStack[T any] struct {
+	// mu guards stack
+	mu stdsync.RWMutex
+
+	// stack is the wrapped stack
+	stack stack.Stack[T]
+}
+
+// Compile-time check that SyncStack implements the stack.Stack interface.
+var _ stack.Stack[int] = (*SyncStack[int])(nil)
+
+// NewSyncStack returns a SyncStack wrapping s. s must not be used directly afterwards.
+// Time Complexity: O(1)
+func NewSyncStack[T any](s stack.Stack[T]) *SyncStack[T] {
+	return &SyncStack[T]{stack: s}
+}
+
+// Push adds an element to the top of the stack.
+func (ss *SyncStack[T]) Push(element T) error {
+	if ss == nil || ss.stack == nil {
+		return fmt.Errorf("please call NewSyncStack() first: %w", goods.ErrNotInitialized)
+	}
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return ss.stack.Push(element)
+}
+
+// Pop removes and returns the top element from the stack.
+// Returns an error wrapping goods.ErrEmpty if the stack is empty.
+func (ss *SyncStack[T]) Pop() (T, error) {
+	if ss == nil || ss.stack == nil {
+		var element T
+		return element, fmt.Errorf("please call NewSyncStack() first: %w", goods.ErrNotInitialized)
+	}
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	return ss.stack.Pop()
+}
+
+// Top returns the top element without removing it.
+// Returns an error wrapping goods.ErrEmpty if the stack is empty.
+func (ss *SyncStack[T]) Top() (T, error) {
+	if ss == nil || ss.stack == nil {
+		var element T
+		return element, fmt.Errorf("please call NewSyncStack() first: %w", goods.ErrNotInitialized)
+	}
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.stack.Top()
+}
+
+// IsEmpty returns true if the stack contains no elements.
+func (ss *SyncStack[T]) IsEmpty() bool {
+	return ss.Size() == 0
+}
+
+// Size returns the number of elements in the stack.
+func (ss *SyncStack[T]) Size() int {
+	if ss == nil || ss.stack == nil {
+		return 0
+	}
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.stack.Size()
+}
+
+// PopIfNotEmpty removes and returns the top element if there is one.
+// It reports false instead of an error when the stack is empty, which is the
+// common case for consumers racing each other for the last element.
+func (ss *SyncStack[T]) PopIfNotEmpty() (T, bool) {
+	return ss.PopIf(func(T) bool { return true })
+}
+
+// PopIf removes and returns the top element if the stack is not empty and
+// pred(top) returns true, as one atomic step. pred runs under the write lock
+// and must not call methods of ss.
+func (ss *SyncStack[T]) PopIf(pred func(top T) bool) (T, bool) {
+	var element T
+	if ss == nil || ss.stack == nil {
+		return element, false
+	}
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	top, err := ss.stack.Top()
+	if err != nil || !pred(top) {
+		return element, false
+	}
+	element, err = ss.stack.Pop()
+	return element, err == nil
+}
+
+// Do calls fn with the wrapped stack while holding the write lock, so that fn
+// can run any sequence of calls atomically. fn must not call methods of ss or
+// keep s after it returns.
+func (ss *SyncStack[T]) Do(fn func(s stack.Stack[T])) {
+	if ss == nil || ss.stack == nil {
+		return
+	}
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	fn(ss.stack)
+}
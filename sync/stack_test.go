@@ -0,0 +1,99 @@
+package sync
+
+import (
+	"errors"
+	stdsync "sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/stack"
+	"github.com/Scanf-s/goods/stack/arraystack"
+	"github.com/Scanf-s/goods/stack/linkedstack"
+	"github.com/Scanf-s/goods/stack/stacktest"
+)
+
+func TestSyncStack_StackSuite(t *testing.T) {
+	stacktest.RunStackSuite(t, func() stack.Stack[int] {
+		return NewSyncStack[int](arraystack.NewArrayStack[int]())
+	})
+}
+
+func TestSyncStack_PopIfNotEmpty(t *testing.T) {
+	ss := NewSyncStack[int](linkedstack.NewLinkedStack[int]())
+	const elements = 10000
+	for i := range elements {
+		_ = ss.Push(i)
+	}
+
+	// Every element is popped exactly once, and no consumer sees ErrEmpty
+	// from a stack another consumer emptied after its check
+	var popped atomic.Int64
+	seen := make([]atomic.Bool, elements)
+	var wg stdsync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			for {
+				v, ok := ss.PopIfNotEmpty()
+				if !ok {
+					return
+				}
+				if seen[v].Swap(true) {
+					t.Errorf("element %d popped twice", v)
+				}
+				popped.Add(1)
+			}
+		})
+	}
+	wg.Wait()
+
+	if popped.Load() != elements {
+		t.Errorf("popped %d elements, expected %d", popped.Load(), elements)
+	}
+	if v, ok := ss.PopIfNotEmpty(); ok {
+		t.Errorf("PopIfNotEmpty on an empty stack = (%d, true)", v)
+	}
+}
+
+func TestSyncStack_PopIf(t *testing.T) {
+	ss := NewSyncStack[int](arraystack.NewArrayStack[int]())
+	_ = ss.Push(1)
+	_ = ss.Push(2)
+
+	if v, ok := ss.PopIf(func(top int) bool { return top%2 == 1 }); ok {
+		t.Errorf("PopIf(odd) = (%d, true) with 2 on top", v)
+	}
+	if v, ok := ss.PopIf(func(top int) bool { return top%2 == 0 }); !ok || v != 2 {
+		t.Errorf("PopIf(even) = (%d, %v), expected (2, true)", v, ok)
+	}
+	if ss.Size() != 1 {
+		t.Errorf("Size() = %d, expected 1", ss.Size())
+	}
+}
+
+func TestSyncStack_Do(t *testing.T) {
+	ss := NewSyncStack[int](arraystack.NewArrayStack[int]())
+	_ = ss.Push(1)
+	_ = ss.Push(2)
+
+	// Swap the two top elements atomically
+	ss.Do(func(s stack.Stack[int]) {
+		a, _ := s.Pop()
+		b, _ := s.Pop()
+		_ = s.Push(a)
+		_ = s.Push(b)
+	})
+	if top, _ := ss.Top(); top != 1 {
+		t.Errorf("Top() = %d after the swap, expected 1", top)
+	}
+}
+
+func TestSyncStack_NotInitialized(t *testing.T) {
+	var ss *SyncStack[int]
+	if err := ss.Push(1); !errors.Is(err, goods.ErrNotInitialized) {
+		t.Errorf("Push on nil SyncStack error = %v, expected goods.ErrNotInitialized", err)
+	}
+	if _, ok := ss.PopIfNotEmpty(); ok {
+		t.Error("PopIfNotEmpty on nil SyncStack reported an element")
+	}
+}
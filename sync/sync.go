@@ -0,0 +1,22 @@
+// Package sync provides decorators that make the collections of this module
+// safe for concurrent use.
+//
+// Each constructor wraps a value of one of the collection interfaces in a lock:
+//
+//	stack := sync.NewSyncStack[int](arraystack.NewArrayStack[int]())
+//	cache := sync.NewSyncCache[string, int](lru)
+//
+// A wrapper implements the same interface as the collection it wraps, so it can
+// replace it anywhere. After wrapping, the collection must only be used through
+// the wrapper.
+//
+// Calling two methods in a row is not atomic: another goroutine may run between
+// IsEmpty and Pop. The wrappers therefore add compound operations, such as
+// PopIfNotEmpty and PutIfAbsent, that check and act under a single lock, and a
+// Do method that runs any sequence of calls under the lock.
+//
+// The package shares its name with the standard library package, so callers
+// that need both usually import it under another name:
+//
+//	import gsync "github.com/Scanf-s/goods/sync"
+package sync
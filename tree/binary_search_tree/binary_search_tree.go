@@ -4,6 +4,7 @@ import (
 	"cmp"
 	"fmt"
 
+	"github.com/Scanf-s/goods"
 	"github.com/Scanf-s/goods/queue/deque"
 	"github.com/Scanf-s/goods/tree"
 )
@@ -32,7 +33,7 @@ func (b *BinarySearchTree[T]) Clear() {
 
 func (b *BinarySearchTree[T]) Add(element T) error {
 	if b == nil {
-		return fmt.Errorf("please initialize binary tree first")
+		return fmt.Errorf("please initialize binary tree first: %w", goods.ErrNotInitialized)
 	}
 
 	newNode := &tree.Node[T]{
@@ -152,8 +153,11 @@ func calculateHeight[T cmp.Ordered](node *tree.Node[T]) int {
 }
 
 func (b *BinarySearchTree[T]) BreadthFirstSearch() ([]T, error) {
-	if b == nil || b.Root == nil {
-		return nil, fmt.Errorf("please initialize binary tree first")
+	if b == nil {
+		return nil, fmt.Errorf("please initialize binary tree first: %w", goods.ErrNotInitialized)
+	}
+	if b.Root == nil {
+		return nil, fmt.Errorf("cannot traverse the tree: %w", goods.ErrEmpty)
 	}
 	dq := deque.NewDeque[*tree.Node[T]]()
 	result := []T{}
@@ -162,7 +166,7 @@ func (b *BinarySearchTree[T]) BreadthFirstSearch() ([]T, error) {
 	for !dq.IsEmpty() {
 		curNode, err := dq.PollFront()
 		if err != nil {
-			return nil, fmt.Errorf("cannot poll element from deque while bfs")
+			return nil, fmt.Errorf("cannot poll element from deque while bfs: %w", err)
 		}
 		
 		if curNode.Left != nil {
@@ -178,8 +182,11 @@ func (b *BinarySearchTree[T]) BreadthFirstSearch() ([]T, error) {
 }
 
 func (b *BinarySearchTree[T]) DepthFirstSearch() ([]T, error) {
-	if b == nil || b.Root == nil {
-		return nil, fmt.Errorf("please initialize binary tree first")
+	if b == nil {
+		return nil, fmt.Errorf("please initialize binary tree first: %w", goods.ErrNotInitialized)
+	}
+	if b.Root == nil {
+		return nil, fmt.Errorf("cannot traverse the tree: %w", goods.ErrEmpty)
 	}
 
 	result := []T{}
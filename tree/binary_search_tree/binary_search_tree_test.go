@@ -1,12 +1,15 @@
 package binarysearchtree_test
 
 import (
+	"errors"
 	"slices"
 	"testing"
 	"time"
 
+	"github.com/Scanf-s/goods"
 	"github.com/Scanf-s/goods/tree"
 	bst "github.com/Scanf-s/goods/tree/binary_search_tree"
+	"github.com/Scanf-s/goods/tree/treetest"
 )
 
 // buildBST inserts values in an order that produces this BST:
@@ -273,3 +276,24 @@ func TestDepthFirstSearch(t *testing.T) {
 		t.Errorf("DepthFirstSearch on incomplete tree = %v, want %v", got, want)
 	}
 }
+
+func TestBinarySearchTree_Errors(t *testing.T) {
+	b := bst.NewBinarySearchTree[int]()
+	if _, err := b.BreadthFirstSearch(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("BreadthFirstSearch on empty tree error = %v, expected goods.ErrEmpty", err)
+	}
+	if _, err := b.DepthFirstSearch(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("DepthFirstSearch on empty tree error = %v, expected goods.ErrEmpty", err)
+	}
+
+	var nilTree *bst.BinarySearchTree[int]
+	if err := nilTree.Add(1); !errors.Is(err, goods.ErrNotInitialized) {
+		t.Errorf("Add on nil tree error = %v, expected goods.ErrNotInitialized", err)
+	}
+}
+
+func TestBinarySearchTree_TreeSuite(t *testing.T) {
+	treetest.RunTreeSuite(t, func() tree.Tree[int] {
+		return bst.NewBinarySearchTree[int]()
+	})
+}
@@ -1,12 +1,15 @@
 package binarytree_test
 
 import (
+	"errors"
 	"slices"
 	"testing"
 	"time"
 
+	"github.com/Scanf-s/goods"
 	"github.com/Scanf-s/goods/tree"
 	binarytree "github.com/Scanf-s/goods/tree/binary_tree"
+	"github.com/Scanf-s/goods/tree/treetest"
 )
 
 func newNode(v int) *tree.Node[int] {
@@ -308,3 +311,24 @@ func TestDepthFirstSearch(t *testing.T) {
 		t.Errorf("DepthFirstSearch on incomplete tree = %v, want %v", got, want)
 	}
 }
+
+func TestBinaryTree_Errors(t *testing.T) {
+	b := binarytree.NewBinaryTree[int]()
+	if _, err := b.BreadthFirstSearch(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("BreadthFirstSearch on empty tree error = %v, expected goods.ErrEmpty", err)
+	}
+	if _, err := b.DepthFirstSearch(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("DepthFirstSearch on empty tree error = %v, expected goods.ErrEmpty", err)
+	}
+
+	var nilTree *binarytree.BinaryTree[int]
+	if err := nilTree.Add(1); !errors.Is(err, goods.ErrNotInitialized) {
+		t.Errorf("Add on nil tree error = %v, expected goods.ErrNotInitialized", err)
+	}
+}
+
+func TestBinaryTree_TreeSuite(t *testing.T) {
+	treetest.RunTreeSuite(t, func() tree.Tree[int] {
+		return binarytree.NewBinaryTree[int]()
+	})
+}
@@ -0,0 +1,224 @@
+// Package heap implements a binary heap stored in a slice.
+package heap
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Scanf-s/goods"
+)
+
+// ErrInvalidHandle is returned when a Handle does not refer to an element
+// currently stored in the heap, for example after the element was popped.
+var ErrInvalidHandle = errors.New("handle does not refer to an element in the heap")
+
+// Handle refers to an element stored in a Heap.
+// It tracks the element's position so that Fix, Update and Remove run in
+// O(log n) without searching. A handle becomes invalid once its element leaves the heap.
+type Handle[T any] struct {
+	// value is the stored element
+	value T
+
+	// index is the position of the handle in Heap.items, or -1 once removed
+	index int
+}
+
+// Value returns the element the handle refers to.
+func (h *Handle[T]) Value() T {
+	return h.value
+}
+
+// Heap is a binary heap ordered by a comparator.
+//
+// The element for which compare reports the smallest value sits at the root, so
+// Pop returns elements in ascending order. Pass a reversed comparator for a max-heap.
+// The tree is stored level by level in a slice: the children of index i are at
+// 2i+1 and 2i+2, and its parent is at (i-1)/2.
+type Heap[T any] struct {
+	// items is the heap-ordered slice of handles
+	items []*Handle[T]
+
+	// compare returns a negative number when a must come out before b,
+	// zero when they are equal and a positive number otherwise
+	compare func(a, b T) int
+}
+
+// New returns an empty Heap ordered by compare.
+// Time Complexity: O(1)
+// Space Complexity: O(1)
+func New[T any](compare func(a, b T) int) *Heap[T] {
+	return &Heap[T]{compare: compare}
+}
+
+// NewFromSlice returns a Heap holding a copy of values, built bottom-up.
+// Time Complexity: O(n)
+// Space Complexity: O(n)
+func NewFromSlice[T any](compare func(a, b T) int, values []T) *Heap[T] {
+	h := &Heap[T]{
+		items:   make([]*Handle[T], len(values)),
+		compare: compare,
+	}
+	for i, v := range values {
+		h.items[i] = &Handle[T]{value: v, index: i}
+	}
+	// Leaves are already heaps, so sift down every internal node from the last one up
+	for i := len(h.items)/2 - 1; i >= 0; i-- {
+		h.down(i)
+	}
+	return h
+}
+
+// Push adds an element and returns a handle to it.
+// Time Complexity: O(log n)
+// Space Complexity: O(1) amortized
+func (h *Heap[T]) Push(value T) *Handle[T] {
+	handle := &Handle[T]{value: value, index: len(h.items)}
+	h.items = append(h.items, handle)
+	h.up(handle.index)
+	return handle
+}
+
+// Peek returns the root element without removing it.
+// Returns an error wrapping goods.ErrEmpty if the heap is empty.
+// Time Complexity: O(1)
+func (h *Heap[T]) Peek() (T, error) {
+	var defaultValue T
+	if len(h.items) == 0 {
+		return defaultValue, fmt.Errorf("cannot peek root element: %w", goods.ErrEmpty)
+	}
+	return h.items[0].value, nil
+}
+
+// Pop removes and returns the root element.
+// Returns an error wrapping goods.ErrEmpty if the heap is empty.
+// Time Complexity: O(log n)
+func (h *Heap[T]) Pop() (T, error) {
+	var defaultValue T
+	if len(h.items) == 0 {
+		return defaultValue, fmt.Errorf("cannot pop root element: %w", goods.ErrEmpty)
+	}
+	return h.removeAt(0), nil
+}
+
+// Update replaces the element behind handle and restores the heap order.
+// Time Complexity: O(log n)
+func (h *Heap[T]) Update(handle *Handle[T], value T) error {
+	if !h.owns(handle) {
+		return ErrInvalidHandle
+	}
+	handle.value = value
+	h.fix(handle.index)
+	return nil
+}
+
+// Fix restores the heap order after the ordering key of the element behind
+// handle changed in place, e.g. through a pointer.
+// Time Complexity: O(log n)
+func (h *Heap[T]) Fix(handle *Handle[T]) error {
+	if !h.owns(handle) {
+		return ErrInvalidHandle
+	}
+	h.fix(handle.index)
+	return nil
+}
+
+// Remove deletes the element behind handle and returns it.
+// Time Complexity: O(log n)
+func (h *Heap[T]) Remove(handle *Handle[T]) (T, error) {
+	var defaultValue T
+	if !h.owns(handle) {
+		return defaultValue, ErrInvalidHandle
+	}
+	return h.removeAt(handle.index), nil
+}
+
+// Size returns the number of elements in the heap.
+// Time Complexity: O(1)
+func (h *Heap[T]) Size() int {
+	return len(h.items)
+}
+
+// IsEmpty returns true if the heap has no elements.
+// Time Complexity: O(1)
+func (h *Heap[T]) IsEmpty() bool {
+	return len(h.items) == 0
+}
+
+// Clear removes all elements and invalidates every handle.
+// Time Complexity: O(n)
+func (h *Heap[T]) Clear() {
+	for _, handle := range h.items {
+		handle.index = -1
+	}
+	h.items = nil
+}
+
+// owns reports whether handle refers to an element currently in this heap.
+func (h *Heap[T]) owns(handle *Handle[T]) bool {
+	return handle != nil && handle.index >= 0 && handle.index < len(h.items) && h.items[handle.index] == handle
+}
+
+// removeAt removes the element at index i by moving the last element into its place.
+func (h *Heap[T]) removeAt(i int) T {
+	removed := h.items[i]
+	last := len(h.items) - 1
+	if i != last {
+		h.swap(i, last)
+	}
+	h.items[last] = nil
+	h.items = h.items[:last]
+	if i != last {
+		h.fix(i)
+	}
+	removed.index = -1
+	return removed.value
+}
+
+// fix moves the element at index i up or down, whichever restores the order.
+func (h *Heap[T]) fix(i int) {
+	if !h.down(i) {
+		h.up(i)
+	}
+}
+
+// up moves the element at index i towards the root while it precedes its parent.
+func (h *Heap[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if h.compare(h.items[i].value, h.items[parent].value) >= 0 {
+			break
+		}
+		h.swap(i, parent)
+		i = parent
+	}
+}
+
+// down moves the element at index i towards the leaves while a child precedes it.
+// It reports whether the element moved.
+func (h *Heap[T]) down(i int) bool {
+	start := i
+	n := len(h.items)
+	for {
+		smallest := i
+		left, right := 2*i+1, 2*i+2
+		if left < n && h.compare(h.items[left].value, h.items[smallest].value) < 0 {
+			smallest = left
+		}
+		if right < n && h.compare(h.items[right].value, h.items[smallest].value) < 0 {
+			smallest = right
+		}
+		if smallest == i {
+			break
+		}
+		h.swap(i, smallest)
+		i = smallest
+	}
+	return i > start
+}
+
+// swap exchanges two handles and keeps their recorded indices in sync.
+func (h *Heap[T]) swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].index = i
+	h.items[j].index = j
+}
@@ -0,0 +1,229 @@
+package heap
+
+import (
+	"cmp"
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/Scanf-s/goods"
+)
+
+// drain pops every element, failing the test on error.
+func drain[T any](t *testing.T, h *Heap[T]) []T {
+	t.Helper()
+	var result []T
+	for !h.IsEmpty() {
+		v, err := h.Pop()
+		if err != nil {
+			t.Fatalf("Pop failed: %s", err)
+		}
+		result = append(result, v)
+	}
+	return result
+}
+
+// checkInvariant verifies the heap order and the handle indices.
+func checkInvariant[T any](t *testing.T, h *Heap[T]) {
+	t.Helper()
+	for i, handle := range h.items {
+		if handle.index != i {
+			t.Fatalf("handle at %d records index %d", i, handle.index)
+		}
+		if i > 0 && h.compare(h.items[(i-1)/2].value, handle.value) > 0 {
+			t.Fatalf("element at %d precedes its parent", i)
+		}
+	}
+}
+
+func TestHeap_PushPop(t *testing.T) {
+	h := New(cmp.Compare[int])
+	for _, v := range []int{5, 3, 8, 1, 9, 2, 7} {
+		h.Push(v)
+		checkInvariant(t, h)
+	}
+	if h.Size() != 7 {
+		t.Errorf("Size = %d, expected 7", h.Size())
+	}
+	if v, err := h.Peek(); err != nil || v != 1 {
+		t.Errorf("Peek = %d (err %v), expected 1", v, err)
+	}
+	if got := drain(t, h); !slices.Equal(got, []int{1, 2, 3, 5, 7, 8, 9}) {
+		t.Errorf("Pop order = %v", got)
+	}
+}
+
+func TestHeap_MaxHeap(t *testing.T) {
+	h := New(func(a, b int) int { return cmp.Compare(b, a) })
+	for _, v := range []int{5, 3, 8, 1} {
+		h.Push(v)
+	}
+	if got := drain(t, h); !slices.Equal(got, []int{8, 5, 3, 1}) {
+		t.Errorf("Pop order = %v, expected descending", got)
+	}
+}
+
+func TestHeap_Empty(t *testing.T) {
+	h := New(cmp.Compare[int])
+	if _, err := h.Peek(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Peek on empty heap error = %v, expected goods.ErrEmpty", err)
+	}
+	if _, err := h.Pop(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("Pop on empty heap error = %v, expected goods.ErrEmpty", err)
+	}
+}
+
+func TestHeap_NewFromSlice(t *testing.T) {
+	values := []int{9, 4, 7, 1, 8, 2, 6, 3, 5, 0}
+	h := NewFromSlice(cmp.Compare[int], values)
+	checkInvariant(t, h)
+
+	// The input slice must not be modified
+	if !slices.Equal(values, []int{9, 4, 7, 1, 8, 2, 6, 3, 5, 0}) {
+		t.Errorf("NewFromSlice modified its input: %v", values)
+	}
+	if got := drain(t, h); !slices.Equal(got, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+		t.Errorf("Pop order = %v", got)
+	}
+}
+
+func TestHeap_Update(t *testing.T) {
+	h := New(cmp.Compare[int])
+	handles := make(map[int]*Handle[int])
+	for _, v := range []int{10, 20, 30, 40, 50} {
+		handles[v] = h.Push(v)
+	}
+
+	if err := h.Update(handles[40], 5); err != nil {
+		t.Fatalf("Update failed: %s", err)
+	}
+	checkInvariant(t, h)
+	if v, _ := h.Peek(); v != 5 {
+		t.Errorf("Peek after decreasing 40 to 5 = %d, expected 5", v)
+	}
+	if handles[40].Value() != 5 {
+		t.Errorf("handle Value = %d, expected 5", handles[40].Value())
+	}
+
+	if err := h.Update(handles[10], 60); err != nil {
+		t.Fatalf("Update failed: %s", err)
+	}
+	checkInvariant(t, h)
+	if got := drain(t, h); !slices.Equal(got, []int{5, 20, 30, 50, 60}) {
+		t.Errorf("Pop order = %v", got)
+	}
+}
+
+func TestHeap_Fix(t *testing.T) {
+	type task struct{ priority int }
+	h := New(func(a, b *task) int { return cmp.Compare(a.priority, b.priority) })
+	a, b, c := &task{1}, &task{2}, &task{3}
+	h.Push(a)
+	h.Push(b)
+	hc := h.Push(c)
+
+	c.priority = 0
+	if err := h.Fix(hc); err != nil {
+		t.Fatalf("Fix failed: %s", err)
+	}
+	if top, _ := h.Peek(); top != c {
+		t.Errorf("Peek after Fix = %+v, expected the task with priority 0", top)
+	}
+}
+
+func TestHeap_Remove(t *testing.T) {
+	h := New(cmp.Compare[int])
+	var handles []*Handle[int]
+	for v := range 10 {
+		handles = append(handles, h.Push(v))
+	}
+
+	for _, i := range []int{0, 9, 4} {
+		v, err := h.Remove(handles[i])
+		if err != nil || v != i {
+			t.Errorf("Remove = %d (err %v), expected %d", v, err, i)
+		}
+		checkInvariant(t, h)
+	}
+	if got := drain(t, h); !slices.Equal(got, []int{1, 2, 3, 5, 6, 7, 8}) {
+		t.Errorf("Pop order = %v", got)
+	}
+}
+
+func TestHeap_InvalidHandle(t *testing.T) {
+	h := New(cmp.Compare[int])
+	handle := h.Push(1)
+	if _, err := h.Pop(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := h.Update(handle, 2); !errors.Is(err, ErrInvalidHandle) {
+		t.Errorf("Update with a popped handle error = %v, expected ErrInvalidHandle", err)
+	}
+	if _, err := h.Remove(handle); !errors.Is(err, ErrInvalidHandle) {
+		t.Errorf("Remove with a popped handle error = %v, expected ErrInvalidHandle", err)
+	}
+
+	other := New(cmp.Compare[int])
+	foreign := other.Push(1)
+	h.Push(1)
+	if err := h.Fix(foreign); !errors.Is(err, ErrInvalidHandle) {
+		t.Errorf("Fix with a handle from another heap error = %v, expected ErrInvalidHandle", err)
+	}
+	if err := h.Fix(nil); !errors.Is(err, ErrInvalidHandle) {
+		t.Errorf("Fix(nil) error = %v, expected ErrInvalidHandle", err)
+	}
+
+	h.Clear()
+	if h.Size() != 0 || h.owns(foreign) {
+		t.Error("Clear should empty the heap")
+	}
+}
+
+func TestHeap_RandomizedModel(t *testing.T) {
+	rng := rand.New(rand.NewPCG(13, 14))
+	h := New(cmp.Compare[int])
+	live := make(map[*Handle[int]]bool)
+	var model []int
+	for step := range 3000 {
+		switch rng.IntN(4) {
+		case 0, 1:
+			v := rng.IntN(1000)
+			live[h.Push(v)] = true
+			model = append(model, v)
+		case 2:
+			v, err := h.Pop()
+			if len(model) == 0 {
+				if err == nil {
+					t.Fatalf("step %d: Pop on empty heap should fail", step)
+				}
+				continue
+			}
+			slices.Sort(model)
+			if err != nil || v != model[0] {
+				t.Fatalf("step %d: Pop = %d (err %v), expected %d", step, v, err, model[0])
+			}
+			model = model[1:]
+			for handle := range live {
+				if handle.index < 0 {
+					delete(live, handle)
+				}
+			}
+		case 3:
+			for handle := range live {
+				old := handle.Value()
+				v := rng.IntN(1000)
+				if err := h.Update(handle, v); err != nil {
+					t.Fatalf("step %d: Update failed: %s", step, err)
+				}
+				model[slices.Index(model, old)] = v
+				break
+			}
+		}
+		if h.Size() != len(model) {
+			t.Fatalf("step %d: Size = %d, expected %d", step, h.Size(), len(model))
+		}
+		checkInvariant(t, h)
+	}
+}
@@ -0,0 +1,202 @@
+// Package treetest provides a conformance suite for tree.Tree implementations.
+//
+// The Tree interface does not fix where Add places an element, so the suite
+// checks traversal order against the node links the tree itself exposes through
+// Get: BreadthFirstSearch must be a level-order walk and DepthFirstSearch a
+// pre-order walk of that structure.
+//
+//	func TestMyTree(t *testing.T) {
+//		treetest.RunTreeSuite(t, func() tree.Tree[int] { return NewMyTree[int]() })
+//	}
+package treetest
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/Scanf-s/goods"
+	"github.com/Scanf-s/goods/tree"
+)
+
+// RunTreeSuite checks that the trees returned by newTree honor the tree.Tree
+// contract: membership, consistent parent/child links, Height, BFS/DFS order
+// and goods.ErrEmpty from traversals of an empty tree. newTree must return a
+// new, empty tree on every call.
+func RunTreeSuite(t *testing.T, newTree func() tree.Tree[int]) {
+	t.Helper()
+
+	t.Run("Empty", func(t *testing.T) { testEmpty(t, newTree()) })
+	t.Run("SingleElement", func(t *testing.T) { testSingleElement(t, newTree()) })
+	t.Run("Structure", func(t *testing.T) { testStructure(t, newTree(), []int{50, 30, 70, 20, 40, 60, 80, 10}) })
+	t.Run("SortedInput", func(t *testing.T) { testStructure(t, newTree(), []int{1, 2, 3, 4, 5, 6, 7}) })
+	t.Run("Clear", func(t *testing.T) { testClear(t, newTree()) })
+	t.Run("RandomizedModel", func(t *testing.T) { testRandomizedModel(t, newTree()) })
+}
+
+func testEmpty(t *testing.T, tr tree.Tree[int]) {
+	if !tr.IsEmpty() {
+		t.Error("a new tree should be empty")
+	}
+	if h := tr.Height(); h != -1 {
+		t.Errorf("Height of an empty tree = %d, expected -1", h)
+	}
+	if tr.Contains(1) {
+		t.Error("Contains on an empty tree should be false")
+	}
+	if node, ok := tr.Get(1); ok || node != nil {
+		t.Errorf("Get on an empty tree = (%v, %v), expected (nil, false)", node, ok)
+	}
+	if _, err := tr.BreadthFirstSearch(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("BreadthFirstSearch on empty tree error = %v, expected goods.ErrEmpty", err)
+	}
+	if _, err := tr.DepthFirstSearch(); !errors.Is(err, goods.ErrEmpty) {
+		t.Errorf("DepthFirstSearch on empty tree error = %v, expected goods.ErrEmpty", err)
+	}
+}
+
+func testSingleElement(t *testing.T, tr tree.Tree[int]) {
+	if err := tr.Add(42); err != nil {
+		t.Fatalf("Add failed: %s", err)
+	}
+	if tr.IsEmpty() {
+		t.Error("tree should not be empty after Add")
+	}
+	if h := tr.Height(); h != 0 {
+		t.Errorf("Height of a single-node tree = %d, expected 0", h)
+	}
+	node, ok := tr.Get(42)
+	if !ok || node == nil || node.Data != 42 {
+		t.Fatalf("Get(42) = (%v, %v), expected the root node", node, ok)
+	}
+	if !node.IsRoot() || !node.IsLeaf() {
+		t.Error("the only node must be both root and leaf")
+	}
+	for _, traversal := range []func() ([]int, error){tr.BreadthFirstSearch, tr.DepthFirstSearch} {
+		if got, err := traversal(); err != nil || !slices.Equal(got, []int{42}) {
+			t.Errorf("traversal = %v (err %v), expected [42]", got, err)
+		}
+	}
+}
+
+// testStructure inserts values and checks every query against the structure
+// reachable from the root node.
+func testStructure(t *testing.T, tr tree.Tree[int], values []int) {
+	for _, v := range values {
+		if err := tr.Add(v); err != nil {
+			t.Fatalf("Add(%d) failed: %s", v, err)
+		}
+	}
+	root := checkStructure(t, tr, values)
+
+	if !tr.Contains(values[0]) || tr.Contains(-1) {
+		t.Error("Contains disagrees with the inserted values")
+	}
+	if got, expected := tr.Height(), height(root); got != expected {
+		t.Errorf("Height = %d, expected %d", got, expected)
+	}
+}
+
+func testClear(t *testing.T, tr tree.Tree[int]) {
+	for _, v := range []int{2, 1, 3} {
+		if err := tr.Add(v); err != nil {
+			t.Fatalf("Add(%d) failed: %s", v, err)
+		}
+	}
+	tr.Clear()
+	testEmpty(t, tr)
+	if err := tr.Add(7); err != nil {
+		t.Fatalf("Add after Clear failed: %s", err)
+	}
+	if got, err := tr.BreadthFirstSearch(); err != nil || !slices.Equal(got, []int{7}) {
+		t.Errorf("BreadthFirstSearch after Clear = %v (err %v), expected [7]", got, err)
+	}
+}
+
+// testRandomizedModel inserts distinct random values and compares the tree with
+// a reference set after every insertion.
+func testRandomizedModel(t *testing.T, tr tree.Tree[int]) {
+	rng := rand.New(rand.NewPCG(7, 8))
+	model := make(map[int]bool)
+	var inserted []int
+	for step, v := range rng.Perm(200) {
+		if err := tr.Add(v); err != nil {
+			t.Fatalf("step %d: Add(%d) failed: %s", step, v, err)
+		}
+		model[v] = true
+		inserted = append(inserted, v)
+
+		probe := rng.IntN(400)
+		if tr.Contains(probe) != model[probe] {
+			t.Fatalf("step %d: Contains(%d) = %v, expected %v", step, probe, !model[probe], model[probe])
+		}
+		if step%20 == 0 {
+			checkStructure(t, tr, inserted)
+		}
+	}
+	checkStructure(t, tr, inserted)
+}
+
+// checkStructure verifies that every value is reachable through Get, that the
+// parent/child links are consistent, and that BFS and DFS match a level-order
+// and a pre-order walk of those links. It returns the root node.
+func checkStructure(t *testing.T, tr tree.Tree[int], values []int) *tree.Node[int] {
+	t.Helper()
+	for _, v := range values {
+		node, ok := tr.Get(v)
+		if !ok || node == nil || node.Data != v {
+			t.Fatalf("Get(%d) = (%v, %v), expected a node holding %d", v, node, ok, v)
+		}
+	}
+
+	root, _ := tr.Get(values[0])
+	for !root.IsRoot() {
+		root = root.Parent
+	}
+	var levelOrder, preOrder []int
+	queue := []*tree.Node[int]{root}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		levelOrder = append(levelOrder, node.Data)
+		for _, child := range []*tree.Node[int]{node.Left, node.Right} {
+			if child == nil {
+				continue
+			}
+			if child.Parent != node {
+				t.Fatalf("node %d has a child %d whose Parent is not %d", node.Data, child.Data, node.Data)
+			}
+			queue = append(queue, child)
+		}
+	}
+	walkPreOrder(root, &preOrder)
+
+	if len(levelOrder) != len(values) {
+		t.Fatalf("tree holds %d nodes, expected %d", len(levelOrder), len(values))
+	}
+	if got, err := tr.BreadthFirstSearch(); err != nil || !slices.Equal(got, levelOrder) {
+		t.Fatalf("BreadthFirstSearch = %v (err %v), expected level order %v", got, err, levelOrder)
+	}
+	if got, err := tr.DepthFirstSearch(); err != nil || !slices.Equal(got, preOrder) {
+		t.Fatalf("DepthFirstSearch = %v (err %v), expected pre-order %v", got, err, preOrder)
+	}
+	return root
+}
+
+func walkPreOrder(node *tree.Node[int], result *[]int) {
+	if node == nil {
+		return
+	}
+	*result = append(*result, node.Data)
+	walkPreOrder(node.Left, result)
+	walkPreOrder(node.Right, result)
+}
+
+// height returns the number of edges on the longest root-to-leaf path.
+func height(node *tree.Node[int]) int {
+	if node == nil {
+		return -1
+	}
+	return max(height(node.Left), height(node.Right)) + 1
+}